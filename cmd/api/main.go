@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,7 +17,10 @@ import (
 
 	"github.com/matheus/eth-validator-api/internal/api/handlers"
 	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/api/router"
 	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/lifecycle"
 	"github.com/matheus/eth-validator-api/internal/service"
 	"github.com/matheus/eth-validator-api/pkg/cache"
 	"github.com/matheus/eth-validator-api/pkg/ethereum"
@@ -28,10 +34,17 @@ var (
 )
 
 func main() {
+	validateConfig := flag.Bool("validate-config", false, "validate configuration, print the resolved values and exit")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		fmt.Printf("Warning: .env file not found\n")
 	}
 
+	if *validateConfig {
+		os.Exit(runValidateConfig(os.Stdout, os.Stderr))
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
@@ -46,58 +59,166 @@ func main() {
 		Str("date", date).
 		Msg("starting eth-validator-api")
 
-	ethClient, err := ethereum.NewClient(cfg)
+	ethClient, err := ethereum.NewClientFromConfig(cfg, log)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create ethereum client")
 	}
 
-	memCache := cache.NewMemoryCache(cfg.Cache.TTL, cfg.Cache.MaxSize)
-	defer memCache.Close()
+	components, err := lifecycle.New(log, lifecycle.Config{
+		MaxPanics:      cfg.Lifecycle.MaxComponentPanics,
+		RestartBackoff: cfg.Lifecycle.ComponentRestartBackoff,
+		StableAfter:    cfg.Lifecycle.ComponentStableAfter,
+		FailFast:       cfg.Lifecycle.FailFast,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create lifecycle manager")
+	}
+
+	if n := cfg.Ethereum.PrewarmConnections; n > 0 {
+		components.Go("beacon_prewarm", func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, cfg.Request.Timeout)
+			defer cancel()
+
+			succeeded, err := ethereum.Prewarm(ctx, ethClient, n)
+			if err != nil {
+				log.Warn().Err(err).Int("connections", succeeded).Int("requested", n).Msg("beacon connection prewarm incomplete")
+				return
+			}
+			log.Info().Int("connections", succeeded).Msg("beacon connections prewarmed")
+		})
+	}
+
+	// /ready fails until genesis is known, so fetch it now rather than on
+	// the first request.
+	components.Go("genesis_warmup", func(ctx context.Context) {
+		if err := ethereum.WarmGenesis(ctx, ethClient, cfg.Request.RetryDelay, log); err == nil {
+			log.Info().Msg("beacon genesis loaded")
+		}
+	})
+
+	// The service treats a nil cache as disabled; the interface values stay
+	// nil rather than holding a nil *MemoryCache.
+	var (
+		serviceCache service.Cache
+		pubkeyCache  service.Cache
+		staleCache   service.Cache
+		cacheStats   handlers.CacheStatsProvider
+	)
+	if cfg.Cache.Enabled {
+		memCache := cache.NewShardedMemoryCache(cfg.Cache.TTL, cfg.Cache.MaxSize, cfg.Cache.Shards)
+		components.OnShutdown("cache", memCache.Close)
+		serviceCache, cacheStats = memCache, memCache
+
+		if cfg.Cache.PubkeyMaxSize > 0 {
+			pubkeys := cache.NewShardedMemoryCache(cfg.Cache.PubkeyTTL, cfg.Cache.PubkeyMaxSize, cfg.Cache.Shards)
+			components.OnShutdown("pubkey_cache", pubkeys.Close)
+			pubkeyCache = pubkeys
+		}
 
-	validatorService, err := service.NewValidatorService(ethClient, log, memCache)
+		if cfg.Cache.ServeStale {
+			stale := cache.NewShardedMemoryCache(cfg.Cache.StaleTTL, cfg.Cache.StaleMaxSize, cfg.Cache.Shards)
+			components.OnShutdown("stale_cache", stale.Close)
+			staleCache = stale
+		}
+	} else {
+		log.Warn().Msg("cache disabled")
+	}
+
+	validatorService, err := service.NewValidatorService(ethClient, log, serviceCache, service.ServiceConfig{
+		MEVRelays:           cfg.MEV.RelayAddresses,
+		PubkeyCache:         pubkeyCache,
+		StaleCache:          staleCache,
+		UnfinalizedTTL:      cfg.Cache.UnfinalizedTTL,
+		EstimateRewards:     cfg.Reward.EstimationEnabled,
+		CacheKeyPrefix:      cfg.Cache.KeyPrefix,
+		RefreshWindow:       cfg.Cache.RefreshWindow,
+		EventReconnectDelay: cfg.Ethereum.ReorgReconnectDelay,
+		EventRewardTimeout:  cfg.Request.Timeout,
+		SlotsPerEpoch:       cfg.Ethereum.SlotsPerEpoch,
+		FanoutConcurrency:   cfg.Batch.MaxConcurrency,
+	})
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create validator service")
 	}
+	// Registered after the caches so it stops before they close.
+	components.OnShutdown("validator_service", validatorService.Close)
+
+	if cfg.Ethereum.ReorgWatchEnabled && serviceCache != nil {
+		reorgWatcher, err := service.NewReorgWatcher(ethClient, log, serviceCache, cfg.Cache.KeyPrefix, cfg.Ethereum.ReorgReconnectDelay, cfg.Ethereum.SlotsPerEpoch)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create reorg watcher")
+		}
+		components.Go("reorg_watcher", reorgWatcher.Run)
+	}
 
-	validatorHandler, err := handlers.NewValidatorHandler(validatorService, log)
+	validatorHandler, err := handlers.NewValidatorHandler(validatorService, log, handlers.HandlerConfig{
+		FinalizedMaxAge:     max(cfg.Cache.TTL, cache.MinTTL),
+		WriteTimeout:        cfg.Server.WriteTimeout,
+		MaxBatchSize:        cfg.Batch.MaxSlots,
+		BatchConcurrency:    cfg.Batch.MaxConcurrency,
+		BatchDeadlineMargin: cfg.Batch.DeadlineMargin,
+		CurrentSlot:         ethClient.GetCurrentSlot,
+		MaxSlotMargin:       cfg.Request.MaxSlotMargin,
+		SlotsPerEpoch:       cfg.Ethereum.SlotsPerEpoch,
+		Defaults: handlers.ResponseDefaults{
+			Unit:   domain.RewardUnit(cfg.Server.DefaultRewardUnit),
+			Pretty: cfg.Server.DefaultPretty,
+		},
+		DisabledRoutes:    disabledRoutes(cfg.Routes),
+		StrictQueryParams: cfg.Server.StrictQueryParams,
+	})
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create validator handler")
 	}
 
-	healthHandler := handlers.NewHealthHandler(version)
+	errorRate := middleware.NewErrorRate(cfg.Metrics.ErrorRateWindow)
 
-	mux := http.NewServeMux()
+	healthHandler := handlers.NewHealthHandler(version, handlers.HealthConfig{
+		Cache:                cacheStats,
+		MaxEvictionRate:      cfg.Cache.MaxEvictionRate,
+		Node:                 ethClient,
+		MinPeers:             cfg.Ethereum.MinPeers,
+		Errors:               errorRate,
+		MaxErrorRate:         cfg.Metrics.MaxErrorRate,
+		MinErrorRateRequests: cfg.Metrics.ErrorRateMinRequests,
+		Initialized:          ethClient.Initialized,
+	})
 
-	mux.HandleFunc("/health", healthHandler.Health)
-	mux.HandleFunc("/ready", healthHandler.Ready)
+	mux := router.New(router.Config{
+		NotFound:         handlers.NewNotFoundHandler(cfg.Server.NotFoundMessage),
+		MethodNotAllowed: handlers.NewMethodNotAllowedHandler(),
+	})
 
-	mux.HandleFunc("/blockreward/", validatorHandler.GetBlockReward)
-	mux.HandleFunc("/syncduties/", validatorHandler.GetSyncDuties)
+	mux.HandleFunc(http.MethodGet, "/health", healthHandler.Health)
+	mux.HandleFunc(http.MethodGet, "/livez", healthHandler.Livez)
+	mux.HandleFunc(http.MethodGet, "/ready", healthHandler.Ready)
+
+	validatorHandler.RegisterRoutes(mux)
 
 	if cfg.Metrics.Enabled {
-		mux.Handle("/metrics", promhttp.Handler())
+		mux.Handle(http.MethodGet, "/metrics", promhttp.Handler())
 	}
 
-	handler := middleware.RequestID(
-		middleware.Logging(log)(
-			middleware.Recovery(log)(
-				middleware.Metrics(
-					middleware.CORS(
-						middleware.Timeout(cfg.Request.Timeout)(mux),
-					),
-				),
-			),
-		),
-	)
+	if cfg.Server.AdminEnabled {
+		adminHandler, err := handlers.NewAdminHandler(log, handlers.AdminConfig{
+			Config: cfg.Redacted(),
+			Token:  cfg.Server.AdminToken,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create admin handler")
+		}
+		mux.HandleFunc(http.MethodGet, "/admin/loglevel", adminHandler.Authorize(adminHandler.LogLevel))
+		mux.HandleFunc(http.MethodPost, "/admin/loglevel", adminHandler.Authorize(adminHandler.LogLevel))
+		mux.HandleFunc(http.MethodGet, "/debug/config", adminHandler.Authorize(adminHandler.DebugConfig))
+	}
 
-	srv := &http.Server{
-		Addr:         ":" + cfg.Port,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	handler, err := newHandlerChain(cfg, log, errorRate, mux)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid trusted proxies")
 	}
 
+	srv := newHTTPServer(cfg, handler)
+
 	go func() {
 		log.Info().Str("port", cfg.Port).Msg("starting HTTP server")
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -111,12 +232,99 @@ func main() {
 
 	log.Info().Msg("shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal().Err(err).Msg("server forced to shutdown")
+	// Shutdown waits for handlers to return, and event streams only do when
+	// their context ends.
+	srv.RegisterOnShutdown(validatorHandler.CloseStreams)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("server forced to shutdown")
+	}
+
+	if err := components.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("background components did not stop")
 	}
 
 	log.Info().Msg("server exited")
 }
+
+func disabledRoutes(cfg config.RoutesConfig) map[string]bool {
+	return map[string]bool{
+		handlers.RouteBlockReward:   !cfg.BlockReward,
+		handlers.RouteSlotStatus:    !cfg.SlotStatus,
+		handlers.RouteBlock:         !cfg.Block,
+		handlers.RouteHeader:        !cfg.Header,
+		handlers.RouteSyncDuties:    !cfg.SyncDuties,
+		handlers.RouteProposers:     !cfg.Proposers,
+		handlers.RouteSyncCommittee: !cfg.SyncCommittee,
+		handlers.RouteMEVRelays:     !cfg.MEVRelays,
+		handlers.RouteEvents:        !cfg.Events,
+	}
+}
+
+// newHandlerChain wraps mux in the middleware every request goes through.
+func newHandlerChain(cfg *config.Config, log logger.Logger, errorRate *middleware.ErrorRate, mux http.Handler) (http.Handler, error) {
+	securityHeaders := middleware.SecurityHeaderConfig{
+		ContentTypeOptions: cfg.Server.ContentTypeOptions,
+		FrameOptions:       cfg.Server.FrameOptions,
+		ReferrerPolicy:     cfg.Server.ReferrerPolicy,
+		HSTSMaxAge:         cfg.Server.HSTSMaxAge,
+	}
+
+	trustedProxies, err := cfg.Server.TrustedProxyPrefixes()
+	if err != nil {
+		return nil, err
+	}
+
+	return middleware.RequestID(
+		middleware.ClientIP(trustedProxies)(
+			middleware.SecurityHeaders(securityHeaders)(
+				middleware.Logging(log)(
+					middleware.MetricsWithErrorRate(errorRate, "/health", "/livez", "/ready", "/metrics")(
+						middleware.Recovery(log)(
+							middleware.CORS(
+								middleware.Compress(cfg.Server.CompressionEnabled)(
+									middleware.BodyLogging(log, cfg.Server.DebugLogBodies, cfg.Server.DebugLogBodyMaxBytes)(
+										middleware.AdmissionControl(cfg.Server.MaxInflight, cfg.Server.QueueSize, cfg.Server.QueueTimeout, "/health", "/livez", "/ready", "/metrics", "/events")(
+											middleware.InflightLimit(cfg.Server.MaxInflightPerClient)(
+												middleware.Timeout(cfg.Request.Timeout, cfg.Request.MaxTimeout, "/events")(mux),
+											),
+										),
+									),
+								),
+							),
+						),
+					),
+				),
+			),
+		),
+	), nil
+}
+
+func newHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      handler,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+}
+
+func runValidateConfig(stdout, stderr io.Writer) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(cfg.Redacted()); err != nil {
+		fmt.Fprintf(stderr, "Failed to print configuration: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestRunValidateConfig(t *testing.T) {
+	t.Run("missing rpc endpoint", func(t *testing.T) {
+		t.Setenv("ETH_RPC_ENDPOINT", "")
+
+		var stdout, stderr bytes.Buffer
+		code := runValidateConfig(&stdout, &stderr)
+
+		assert.NotEqual(t, 0, code)
+		assert.Contains(t, stderr.String(), "ETH_RPC_ENDPOINT")
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("valid config masks secrets", func(t *testing.T) {
+		t.Setenv("ETH_RPC_ENDPOINT", "https://beacon.example.com/v1/secret-api-key")
+
+		var stdout, stderr bytes.Buffer
+		code := runValidateConfig(&stdout, &stderr)
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout.String(), "https://beacon.example.com/***")
+		assert.NotContains(t, stdout.String(), "secret-api-key")
+	})
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	t.Setenv("ETH_RPC_ENDPOINT", "http://localhost:5052")
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := config.Load()
+		require.NoError(t, err)
+
+		srv := newHTTPServer(cfg, http.NotFoundHandler())
+
+		assert.Equal(t, ":8080", srv.Addr)
+		assert.Equal(t, 15*time.Second, srv.ReadTimeout)
+		assert.Equal(t, 150*time.Second, srv.WriteTimeout)
+		assert.Equal(t, 60*time.Second, srv.IdleTimeout)
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv("PORT", "9090")
+		t.Setenv("SERVER_READ_TIMEOUT", "5s")
+		t.Setenv("SERVER_WRITE_TIMEOUT", "0s")
+		t.Setenv("SERVER_IDLE_TIMEOUT", "2m")
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+
+		srv := newHTTPServer(cfg, http.NotFoundHandler())
+
+		assert.Equal(t, ":9090", srv.Addr)
+		assert.Equal(t, 5*time.Second, srv.ReadTimeout)
+		assert.Equal(t, time.Duration(0), srv.WriteTimeout)
+		assert.Equal(t, 2*time.Minute, srv.IdleTimeout)
+	})
+}
+
+func TestNewHandlerChain_RecoversPanics(t *testing.T) {
+	t.Setenv("ETH_RPC_ENDPOINT", "http://localhost:5052")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	errorRate := middleware.NewErrorRate(time.Minute)
+	handler, err := newHandlerChain(cfg, logger.NewWithWriter("error", &logs), errorRate, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reward map[string]int
+		reward["total"]++
+	}))
+	require.NoError(t, err)
+
+	// The handler panics in the goroutine Timeout starts; it has to reach
+	// Recovery rather than take the process down.
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/blockreward/1", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "internal server error", response["error"])
+	assert.NotEmpty(t, response["incident_id"])
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, response["incident_id"], entry["incident_id"])
+	assert.Contains(t, entry["panic"], "assignment to entry in nil map")
+	assert.Contains(t, entry["stack"], "TestNewHandlerChain_RecoversPanics")
+
+	// Recovery's 500 counts towards the error rate /health watches.
+	errors, total := errorRate.Counts()
+	assert.Equal(t, uint64(1), errors)
+	assert.Equal(t, uint64(1), total)
+}
+
+func TestNewHandlerChain_RequestTimeout(t *testing.T) {
+	t.Setenv("ETH_RPC_ENDPOINT", "http://localhost:5052")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	handler, err := newHandlerChain(cfg, logger.NewWithWriter("error", &bytes.Buffer{}), middleware.NewErrorRate(time.Minute), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		require.True(t, ok)
+		w.Header().Set("X-Remaining", time.Until(deadline).Round(time.Second).String())
+	}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "default", want: "30s"},
+		{name: "longer override", header: "90s", want: "1m30s"},
+		{name: "override above max", header: "10m", want: "2m0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/blockreward/1", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Request-Timeout", tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Header().Get("X-Remaining"))
+		})
+	}
+}
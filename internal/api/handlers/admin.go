@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+type AdminHandler struct {
+	logger logger.Logger
+	levels logger.LevelSetter
+	config AdminConfig
+}
+
+type AdminConfig struct {
+	// Config is served as-is by /debug/config, so it must already have its
+	// secrets masked.
+	Config interface{}
+	// Token must be sent as a bearer token on every admin request.
+	Token string
+}
+
+func NewAdminHandler(log logger.Logger, cfg AdminConfig) (*AdminHandler, error) {
+	if log == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("admin token is required")
+	}
+
+	levels, ok := log.(logger.LevelSetter)
+	if !ok {
+		return nil, fmt.Errorf("logger does not support runtime level changes")
+	}
+
+	return &AdminHandler{logger: log, levels: levels, config: cfg}, nil
+}
+
+type logLevelBody struct {
+	Level string `json:"level"`
+}
+
+// Authorize rejects requests that don't carry the admin token.
+func (h *AdminHandler) Authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.config.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			h.respond(w, r, http.StatusUnauthorized, Response{Error: http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// LogLevel reports the current log level on GET and changes it on POST.
+func (h *AdminHandler) LogLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var body logLevelBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.respond(w, r, http.StatusBadRequest, Response{Error: "invalid request body"})
+			return
+		}
+
+		previous := h.levels.Level()
+		if err := h.levels.SetLevel(body.Level); err != nil {
+			h.respond(w, r, http.StatusBadRequest, Response{Error: err.Error(), Field: "level", Value: body.Level})
+			return
+		}
+
+		h.logger.Warn().
+			Str("previous_level", previous).
+			Str("level", h.levels.Level()).
+			Msg("log level changed")
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.respond(w, r, http.StatusMethodNotAllowed, Response{Error: http.StatusText(http.StatusMethodNotAllowed)})
+		return
+	}
+
+	h.respond(w, r, http.StatusOK, Response{Data: logLevelBody{Level: h.levels.Level()}})
+}
+
+// DebugConfig returns the configuration the process is running with.
+func (h *AdminHandler) DebugConfig(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, http.StatusOK, Response{Data: h.config.Config})
+}
+
+func (h *AdminHandler) respond(w http.ResponseWriter, r *http.Request, status int, response Response) {
+	if response.Error != "" {
+		response.RequestID = middleware.GetRequestID(r.Context())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error().Err(err).Msg("failed to encode response")
+	}
+}
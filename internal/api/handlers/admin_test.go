@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestAdminHandler_LogLevel(t *testing.T) {
+	var logs bytes.Buffer
+	log := logger.NewWithWriter("info", &logs)
+
+	handler, err := NewAdminHandler(log, AdminConfig{Token: "admin-token"})
+	require.NoError(t, err)
+
+	do := func(method, body string) (int, Response) {
+		rr := httptest.NewRecorder()
+		handler.LogLevel(rr, httptest.NewRequest(method, "/admin/loglevel", strings.NewReader(body)))
+
+		var response Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr.Code, response
+	}
+
+	status, response := do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"level": "info"}, response.Data)
+
+	log.Debug().Msg("hidden")
+	assert.NotContains(t, logs.String(), "hidden")
+
+	status, response = do(http.MethodPost, `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"level": "debug"}, response.Data)
+
+	log.Debug().Msg("visible")
+	assert.Contains(t, logs.String(), "visible")
+
+	status, response = do(http.MethodPost, `{"level":"loud"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "level", response.Field)
+
+	status, _ = do(http.MethodPost, `not json`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = do(http.MethodDelete, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestAdminHandler_DebugConfig(t *testing.T) {
+	cfg := &config.Config{
+		Port: "8080",
+		Ethereum: config.EthereumConfig{
+			RPCEndpoint: "https://beacon.example.com/v1/secret-api-key",
+			MinPeers:    3,
+		},
+	}
+
+	handler, err := NewAdminHandler(logger.New("error"), AdminConfig{Config: cfg.Redacted(), Token: "admin-token"})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.DebugConfig(rr, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "secret-api-key")
+
+	var response struct {
+		Data config.Config `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "https://beacon.example.com/***", response.Data.Ethereum.RPCEndpoint)
+	assert.Equal(t, "8080", response.Data.Port)
+	assert.Equal(t, uint64(3), response.Data.Ethereum.MinPeers)
+}
+
+func TestAdminHandler_Authorize(t *testing.T) {
+	_, err := NewAdminHandler(logger.New("error"), AdminConfig{})
+	assert.Error(t, err)
+
+	handler, err := NewAdminHandler(logger.New("error"), AdminConfig{Config: map[string]string{"port": "8080"}, Token: "admin-token"})
+	require.NoError(t, err)
+	debugConfig := handler.Authorize(handler.DebugConfig)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "valid token", authorization: "Bearer admin-token", wantStatus: http.StatusOK},
+		{name: "missing header", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer other-token", wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", authorization: "Basic admin-token", wantStatus: http.StatusUnauthorized},
+		{name: "token prefix", authorization: "Bearer admin", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/debug/config", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			debugConfig(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rr.Header().Get("WWW-Authenticate"))
+				assert.NotContains(t, rr.Body.String(), "8080")
+			}
+		})
+	}
+}
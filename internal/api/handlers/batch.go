@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/fanout"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+const (
+	defaultMaxBatchSize     = 100
+	defaultBatchConcurrency = 8
+	// defaultBatchDeadlineMargin leaves a partial batch time to encode and
+	// send what it fetched before the request times out.
+	defaultBatchDeadlineMargin = 500 * time.Millisecond
+
+	// maxBatchBodyBytes bounds the body both as sent and, for gzip bodies,
+	// once decompressed.
+	maxBatchBodyBytes = 1 << 20
+)
+
+type batchBlockRewardsRequest struct {
+	Slots []uint64 `json:"slots"`
+}
+
+// batchBlockReward is one entry of the batch response: either Data or Error
+// and Status are set.
+type batchBlockReward struct {
+	Slot   uint64              `json:"slot"`
+	Data   *domain.BlockReward `json:"data,omitempty"`
+	Error  string              `json:"error,omitempty"`
+	Status int                 `json:"status,omitempty"`
+}
+
+// GetBlockRewardsBatch fetches the rewards of several slots. Entries are
+// returned in request order, each with its own result or error, so one missed
+// slot doesn't fail the batch.
+func (h *ValidatorHandler) GetBlockRewardsBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	unit, err := h.config.Defaults.rewardUnit(r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid unit parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	slots, err := h.decodeBatchSlots(w, r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid batch request")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Int("slots", len(slots)).
+		Msg("processing batch block reward request")
+
+	// Each distinct slot is fetched once; duplicates share its result.
+	unique, positions := dedupeSlots(slots)
+
+	// With ?partial=true, fetching stops short of the request deadline and
+	// the slots not done by then are reported instead of failing the batch.
+	fetchCtx, partial := ctx, queryBool(r, "partial")
+	if deadline, ok := ctx.Deadline(); ok && partial {
+		var cancel context.CancelFunc
+		fetchCtx, cancel = context.WithDeadline(ctx, deadline.Add(-h.config.BatchDeadlineMargin))
+		defer cancel()
+	}
+
+	ceiling, bounded := h.slotCeiling(ctx)
+	results, err := fanout.MapConcurrent(fetchCtx, unique, h.config.BatchConcurrency, func(ctx context.Context, slot uint64) (*domain.BlockReward, error) {
+		if bounded && slot > ceiling {
+			return nil, pkgerrors.ErrSlotTooFarInFuture
+		}
+		reward, err := h.service.GetBlockReward(ctx, slot)
+		// A deadline hit while the batch still has time left is the slot's
+		// own beacon request timing out: it was processed, and failed.
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			err = fmt.Errorf("%w: %v", pkgerrors.ErrTimeout, err)
+		}
+		return reward, err
+	})
+	// Only the fetch deadline having passed is tolerated; the request's own
+	// still fails the batch.
+	cutShort := err != nil && partial && ctx.Err() == nil
+	if err != nil && !cutShort {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	var pubkeys map[uint64]string
+	if includes(r, "proposer_pubkey") {
+		pubkeys, err = h.resolveProposerPubkeys(ctx, results)
+		if err != nil {
+			h.handleServiceError(w, r, err)
+			return
+		}
+	}
+
+	var unprocessed []uint64
+	breakdown, numeric := queryBool(r, "breakdown"), queryBool(r, "numeric")
+	entries := make([]batchBlockReward, len(slots))
+	for i, slot := range slots {
+		result := results[positions[i]]
+		entries[i].Slot = slot
+		if cutShort && isContextError(result.Err) {
+			entries[i].Status = http.StatusGatewayTimeout
+			entries[i].Error = errNotProcessed.Error()
+			// Repeated slots share a result; list each one once.
+			if !slices.Contains(unprocessed, slot) {
+				unprocessed = append(unprocessed, slot)
+			}
+			continue
+		}
+		if result.Err != nil {
+			status, clientErr := h.classifyServiceError(result.Err, requestID)
+			entries[i].Status = status
+			entries[i].Error = clientErr.Error()
+			continue
+		}
+		view := blockRewardView(result.Value, unit, breakdown, numeric)
+		view.ProposerPubkey = pubkeys[view.ProposerIndex]
+		entries[i].Data = &view
+		if view.Stale {
+			w.Header().Set("Warning", staleWarning)
+		}
+	}
+
+	truncated := len(unprocessed) > 0
+	if truncated {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Int("unprocessed", len(unprocessed)).
+			Msg("batch deadline reached, returning partial results")
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	h.writeResponse(w, r, http.StatusOK, Response{Data: entries, Truncated: truncated, Unprocessed: unprocessed})
+}
+
+// errNotProcessed marks the entries of a partial batch that ran out of time.
+var errNotProcessed = errors.New("not processed before the request deadline")
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
+func (h *ValidatorHandler) decodeBatchSlots(w http.ResponseWriter, r *http.Request) ([]uint64, error) {
+	body, err := readBatchBody(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var req batchBlockRewardsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, pkgerrors.NewValidationError("body", nil, pkgerrors.ErrInvalidBatch)
+	}
+
+	if len(req.Slots) == 0 || len(req.Slots) > h.config.MaxBatchSize {
+		return nil, pkgerrors.NewValidationError("slots", len(req.Slots), pkgerrors.ErrInvalidBatch)
+	}
+
+	return req.Slots, nil
+}
+
+// resolveProposerPubkeys looks up the proposers of every fetched reward in
+// one go rather than once per entry.
+func (h *ValidatorHandler) resolveProposerPubkeys(ctx context.Context, results []fanout.Result[*domain.BlockReward]) (map[uint64]string, error) {
+	var indices []uint64
+	for _, result := range results {
+		if result.Err == nil {
+			indices = append(indices, result.Value.ProposerIndex)
+		}
+	}
+	if len(indices) == 0 {
+		return nil, nil
+	}
+	return h.service.ResolvePubkeys(ctx, indices)
+}
+
+// dedupeSlots returns the distinct slots in first-seen order, and for each
+// input slot the index of its entry in them.
+func dedupeSlots(slots []uint64) (unique []uint64, positions []int) {
+	seen := make(map[uint64]int, len(slots))
+	positions = make([]int, len(slots))
+	for i, slot := range slots {
+		position, ok := seen[slot]
+		if !ok {
+			position = len(unique)
+			seen[slot] = position
+			unique = append(unique, slot)
+		}
+		positions[i] = position
+	}
+	return unique, positions
+}
+
+// readBatchBody reads the request body, decompressing it when sent with
+// Content-Encoding: gzip. The decompressed size is capped too, so a small
+// compressed body can't expand without bound.
+func readBatchBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxBatchBodyBytes)
+
+	switch encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, pkgerrors.NewValidationError("body", nil, fmt.Errorf("%w: malformed gzip", pkgerrors.ErrInvalidBatch))
+		}
+		defer gz.Close()
+		body = gz
+	default:
+		return nil, pkgerrors.NewValidationError("content_encoding", encoding, pkgerrors.ErrInvalidBatch)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxBatchBodyBytes+1))
+	if err != nil {
+		return nil, pkgerrors.NewValidationError("body", nil, pkgerrors.ErrInvalidBatch)
+	}
+	if len(data) > maxBatchBodyBytes {
+		return nil, pkgerrors.NewValidationError("body", nil, fmt.Errorf("%w: body exceeds %d bytes", pkgerrors.ErrInvalidBatch, maxBatchBodyBytes))
+	}
+
+	return data, nil
+}
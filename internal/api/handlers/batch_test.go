@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_GetBlockRewardsBatch(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusMEV,
+		Reward: big.NewInt(2000000000),
+	}, nil)
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(nil, pkgerrors.ErrSlotNotFound)
+	svc.On("GetBlockReward", mock.Anything, uint64(3)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(3000000000),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{MaxBatchSize: 3, BatchConcurrency: 2})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodPost, "/blockrewards?unit=gwei", strings.NewReader(`{"slots":[1,2,3]}`)))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":[
+		{"slot":1,"data":{"status":"mev","reward":"2","unit":"gwei","proposer_index":0}},
+		{"slot":2,"error":"slot not found","status":404},
+		{"slot":3,"data":{"status":"vanilla","reward":"3","unit":"gwei","proposer_index":0}}
+	]}`, rr.Body.String())
+	assert.Empty(t, rr.Header().Get("Warning"))
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_Stale(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status:    domain.StatusVanilla,
+		Reward:    big.NewInt(1),
+		Finalized: true,
+		Stale:     true,
+	}, nil)
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(nil, pkgerrors.ErrSlotNotFound)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{MaxBatchSize: 2})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodPost, "/blockrewards", strings.NewReader(`{"slots":[1,2]}`)))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `110 - "Response is Stale"`, rr.Header().Get("Warning"))
+	assert.JSONEq(t, `{"data":[
+		{"slot":1,"data":{"status":"vanilla","reward":"1","unit":"wei","proposer_index":0,"finalized":true,"stale":true}},
+		{"slot":2,"error":"slot not found","status":404}
+	]}`, rr.Body.String())
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_DuplicateSlots(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusMEV,
+		Reward: big.NewInt(2000000000),
+	}, nil).Once()
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(nil, pkgerrors.ErrSlotNotFound).Once()
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{MaxBatchSize: 5})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodPost, "/blockrewards?unit=gwei", strings.NewReader(`{"slots":[2,1,2,1,1]}`)))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":[
+		{"slot":2,"error":"slot not found","status":404},
+		{"slot":1,"data":{"status":"mev","reward":"2","unit":"gwei","proposer_index":0}},
+		{"slot":2,"error":"slot not found","status":404},
+		{"slot":1,"data":{"status":"mev","reward":"2","unit":"gwei","proposer_index":0}},
+		{"slot":1,"data":{"status":"mev","reward":"2","unit":"gwei","proposer_index":0}}
+	]}`, rr.Body.String())
+
+	svc.AssertExpectations(t)
+	svc.AssertNumberOfCalls(t, "GetBlockReward", 2)
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_ProposerPubkey(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status:        domain.StatusVanilla,
+		Reward:        big.NewInt(1),
+		ProposerIndex: 10,
+	}, nil)
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(&domain.BlockReward{
+		Status:        domain.StatusVanilla,
+		Reward:        big.NewInt(2),
+		ProposerIndex: 20,
+	}, nil)
+	svc.On("GetBlockReward", mock.Anything, uint64(3)).Return(nil, pkgerrors.ErrSlotNotFound)
+	svc.On("ResolvePubkeys", mock.Anything, []uint64{10, 20}).Return(map[uint64]string{10: "0xaa"}, nil).Once()
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodPost, "/blockrewards?include=proposer_pubkey", strings.NewReader(`{"slots":[1,2,3]}`)))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":[
+		{"slot":1,"data":{"status":"vanilla","reward":"1","unit":"wei","proposer_index":10,"proposer_pubkey":"0xaa"}},
+		{"slot":2,"data":{"status":"vanilla","reward":"2","unit":"wei","proposer_index":20}},
+		{"slot":3,"error":"slot not found","status":404}
+	]}`, rr.Body.String())
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_Partial(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+	// Slot 2 hangs until its context gives up, slot 3 never gets a turn.
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil, context.DeadlineExceeded)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+		BatchConcurrency:    1,
+		BatchDeadlineMargin: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	post := func(target string) (*httptest.ResponseRecorder, time.Duration) {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"slots":[1,2,3,2]}`)).WithContext(ctx)
+		rr := httptest.NewRecorder()
+		start := time.Now()
+		serve(handler, rr, req)
+		return rr, time.Since(start)
+	}
+
+	t.Run("partial results before the deadline", func(t *testing.T) {
+		rr, elapsed := post("/blockrewards?partial=true")
+
+		assert.Less(t, elapsed, 300*time.Millisecond)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{
+			"data":[
+				{"slot":1,"data":{"status":"vanilla","reward":"1","unit":"wei","proposer_index":0}},
+				{"slot":2,"error":"not processed before the request deadline","status":504},
+				{"slot":3,"error":"not processed before the request deadline","status":504},
+				{"slot":2,"error":"not processed before the request deadline","status":504}
+			],
+			"truncated":true,
+			"unprocessed":[2,3]
+		}`, rr.Body.String())
+	})
+
+	t.Run("without partial the batch fails", func(t *testing.T) {
+		rr, _ := post("/blockrewards")
+		assert.NotEqual(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "truncated")
+	})
+
+	svc.AssertNotCalled(t, "GetBlockReward", mock.Anything, uint64(3))
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_SlotTimeout(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+	// Slot 2's beacon request times out on its own while the batch has
+	// plenty of time left.
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(nil, fmt.Errorf("failed to get block: %w", context.DeadlineExceeded))
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	for _, target := range []string{"/blockrewards", "/blockrewards?partial=true"} {
+		t.Run(target, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"slots":[1,2]}`)).WithContext(ctx))
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, `{"data":[
+				{"slot":1,"data":{"status":"vanilla","reward":"1","unit":"wei","proposer_index":0}},
+				{"slot":2,"error":"request timeout: failed to get block: context deadline exceeded","status":408}
+			]}`, rr.Body.String())
+		})
+	}
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_SlotBound(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+		CurrentSlot:   func(ctx context.Context) (uint64, error) { return 1000, nil },
+		MaxSlotMargin: 100,
+	})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodPost, "/blockrewards", strings.NewReader(`{"slots":[1,99999999999]}`)))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":[
+		{"slot":1,"data":{"status":"vanilla","reward":"1","unit":"wei","proposer_index":0}},
+		{"slot":99999999999,"error":"requested slot is too far in the future","status":400}
+	]}`, rr.Body.String())
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_InvalidRequest(t *testing.T) {
+	handler, err := NewValidatorHandler(new(mockValidatorService), logger.New("error"), HandlerConfig{MaxBatchSize: 2})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		path          string
+		body          string
+		expectedField string
+	}{
+		{name: "malformed body", path: "/blockrewards", body: `{"slots":`, expectedField: "body"},
+		{name: "negative slot", path: "/blockrewards", body: `{"slots":[-1]}`, expectedField: "body"},
+		{name: "no slots", path: "/blockrewards", body: `{"slots":[]}`, expectedField: "slots"},
+		{name: "too many slots", path: "/blockrewards", body: `{"slots":[1,2,3]}`, expectedField: "slots"},
+		{name: "invalid unit", path: "/blockrewards?unit=finney", body: `{"slots":[1]}`, expectedField: "unit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var response Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedField, response.Field)
+		})
+	}
+}
+
+func gzipBody(t *testing.T, body string) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return &buf
+}
+
+func TestValidatorHandler_GetBlockRewardsBatch_ContentEncoding(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	post := func(body io.Reader, encoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/blockrewards", body)
+		if encoding != "" {
+			req.Header.Set("Content-Encoding", encoding)
+		}
+		rr := httptest.NewRecorder()
+		serve(handler, rr, req)
+		return rr
+	}
+
+	expected := `{"data":[{"slot":1,"data":{"status":"vanilla","reward":"1","unit":"wei","proposer_index":0}}]}`
+
+	t.Run("plain", func(t *testing.T) {
+		rr := post(strings.NewReader(`{"slots":[1]}`), "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, expected, rr.Body.String())
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		rr := post(gzipBody(t, `{"slots":[1]}`), "gzip")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, expected, rr.Body.String())
+	})
+
+	invalid := []struct {
+		name          string
+		body          io.Reader
+		encoding      string
+		expectedField string
+		expectedError string
+	}{
+		{
+			name:          "malformed gzip",
+			body:          strings.NewReader(`{"slots":[1]}`),
+			encoding:      "gzip",
+			expectedField: "body",
+			expectedError: "invalid batch request: malformed gzip",
+		},
+		{
+			name:          "truncated gzip",
+			body:          bytes.NewReader(gzipBody(t, `{"slots":[1]}`).Bytes()[:20]),
+			encoding:      "gzip",
+			expectedField: "body",
+			expectedError: "invalid batch request",
+		},
+		{
+			// A few KiB on the wire that inflate past the limit.
+			name:          "decompression bomb",
+			body:          gzipBody(t, `{"slots":[1]}`+strings.Repeat(" ", 4*maxBatchBodyBytes)),
+			encoding:      "gzip",
+			expectedField: "body",
+			expectedError: fmt.Sprintf("invalid batch request: body exceeds %d bytes", maxBatchBodyBytes),
+		},
+		{
+			name:          "unsupported encoding",
+			body:          strings.NewReader(`{"slots":[1]}`),
+			encoding:      "br",
+			expectedField: "content_encoding",
+			expectedError: "invalid batch request",
+		},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := post(tt.body, tt.encoding)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var response Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedField, response.Field)
+			assert.Equal(t, tt.expectedError, response.Error)
+		})
+	}
+
+	svc.AssertNumberOfCalls(t, "GetBlockReward", 2)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// writeChunkSize is how much of a response body is written between flushes.
+const writeChunkSize = 32 << 10
+
+// writeResponse encodes the whole response into memory before anything is
+// sent, so an encoding failure still becomes a 500 rather than a truncated
+// 200. Bodies larger than one chunk are then written and flushed chunk by
+// chunk; only the write is spread out, not the encoding.
+func (h *ValidatorHandler) writeResponse(w http.ResponseWriter, r *http.Request, status int, response Response) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, response, h.config.Defaults.pretty(r)); err != nil {
+		h.logger.Error().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("failed to encode response")
+		h.respondError(w, r, http.StatusInternalServerError, pkgerrors.ErrInternal)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	written, err := h.writeChunked(w, buf.Bytes())
+	if err != nil {
+		// The status is already on the wire; all that's left is to record it.
+		h.logger.Error().
+			Str("request_id", requestID).
+			Int("bytes_written", written).
+			Int("bytes_total", buf.Len()).
+			Err(err).
+			Msg("response truncated")
+	}
+}
+
+// writeChunked writes the encoded body in chunks, flushing after each one.
+// With a write timeout configured, every chunk extends the connection's
+// write deadline so a large body isn't cut off while the client is still
+// reading it.
+func (h *ValidatorHandler) writeChunked(w http.ResponseWriter, body []byte) (int, error) {
+	if len(body) <= writeChunkSize {
+		return w.Write(body)
+	}
+
+	rc := http.NewResponseController(w)
+	written := 0
+
+	for written < len(body) {
+		if h.config.WriteTimeout > 0 {
+			if err := rc.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return written, err
+			}
+		}
+
+		end := min(written+writeChunkSize, len(body))
+		n, err := w.Write(body[written:end])
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/service"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_LargeResponse(t *testing.T) {
+	validators := make([]string, 50000)
+	for i := range validators {
+		validators[i] = fmt.Sprintf("0x%096x", i)
+	}
+
+	svc := new(mockValidatorService)
+	svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(1), service.SyncDutiesOptions{}).Return(&domain.SyncCommitteeDuties{
+		Validators: validators,
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{WriteTimeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve(handler, w, r)
+	}))
+	server.Config.WriteTimeout = 5 * time.Second
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/syncduties/1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data domain.SyncCommitteeDuties `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, validators, body.Data.Validators)
+}
+
+func TestValidatorHandler_EncodeFailure(t *testing.T) {
+	handler, err := NewValidatorHandler(new(mockValidatorService), logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.respondJSON(rr, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, math.Inf(1))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.JSONEq(t, `{"error":"internal server error"}`, rr.Body.String())
+}
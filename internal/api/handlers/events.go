@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// eventTopics are the beacon API event topics clients may subscribe to.
+var eventTopics = map[string]struct{}{
+	"head":                           {},
+	"block":                          {},
+	"block_gossip":                   {},
+	"attestation":                    {},
+	"single_attestation":             {},
+	"voluntary_exit":                 {},
+	"bls_to_execution_change":        {},
+	"proposer_slashing":              {},
+	"attester_slashing":              {},
+	"finalized_checkpoint":           {},
+	"chain_reorg":                    {},
+	"contribution_and_proof":         {},
+	"light_client_finality_update":   {},
+	"light_client_optimistic_update": {},
+	"payload_attributes":             {},
+	"blob_sidecar":                   {},
+	"data_column_sidecar":            {},
+}
+
+// GetEvents relays the beacon node's event stream as server-sent events. The
+// response stays open until the client disconnects or CloseStreams is
+// called; upstream drops are retried by the service without ending it.
+func (h *ValidatorHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	topics, err := parseTopicsParam(r.URL.Query().Get("topics"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid topics parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	unit, err := h.config.Defaults.rewardUnit(r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid unit parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	breakdown, numeric := queryBool(r, "breakdown"), queryBool(r, "numeric")
+
+	rc := http.NewResponseController(w)
+	// The server's write timeout is meant for ordinary responses; a stream
+	// would be cut off after it.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Error().Str("request_id", requestID).Err(err).Msg("failed to clear write deadline")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	stop := context.AfterFunc(h.streams, cancel)
+	defer stop()
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Strs("topics", topics).
+		Msg("event stream opened")
+
+	_ = h.service.StreamEvents(ctx, topics, func(event domain.Event) {
+		if head, ok := event.Data.(*domain.HeadEvent); ok && head.Reward != nil {
+			view := *head
+			reward := blockRewardView(head.Reward, unit, breakdown, numeric)
+			view.Reward = &reward
+			event.Data = view
+		}
+
+		if err := writeEvent(w, rc, event); err != nil {
+			h.logger.Debug().Str("request_id", requestID).Err(err).Msg("failed to write event")
+			cancel()
+		}
+	})
+
+	h.logger.Info().Str("request_id", requestID).Msg("event stream closed")
+}
+
+// CloseStreams ends every open event stream. http.Server.Shutdown doesn't
+// cancel the requests it waits for, so register it with RegisterOnShutdown.
+func (h *ValidatorHandler) CloseStreams() {
+	h.closeStreams()
+}
+
+func writeEvent(w http.ResponseWriter, rc *http.ResponseController, event domain.Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Topic, data); err != nil {
+		return err
+	}
+
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func parseTopicsParam(value string) ([]string, error) {
+	if value == "" {
+		return nil, pkgerrors.NewValidationError("topics", value, pkgerrors.ErrInvalidTopic)
+	}
+
+	var topics []string
+	seen := make(map[string]struct{})
+	for _, topic := range strings.Split(value, ",") {
+		topic = strings.TrimSpace(topic)
+		if _, ok := eventTopics[topic]; !ok {
+			return nil, pkgerrors.NewValidationError("topics", topic, pkgerrors.ErrInvalidTopic)
+		}
+		if _, dup := seen[topic]; dup {
+			continue
+		}
+		seen[topic] = struct{}{}
+		topics = append(topics, topic)
+	}
+
+	return topics, nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_GetEvents(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("StreamEvents", mock.Anything, []string{"head", "finalized_checkpoint"}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(domain.Event))
+			fn(domain.Event{Topic: "head", Data: &domain.HeadEvent{
+				Slot:  100,
+				Block: "0xaa",
+				Reward: &domain.BlockReward{
+					Status:    domain.StatusMEV,
+					Reward:    big.NewInt(2_000_000_000),
+					Breakdown: &domain.RewardBreakdown{},
+				},
+			}})
+			fn(domain.Event{Topic: "finalized_checkpoint", Data: json.RawMessage(`{"epoch":"3"}`)})
+		}).
+		Return(nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodGet, "/events?topics=head,finalized_checkpoint,head&unit=gwei", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "event: head\n"+
+		`data: {"slot":100,"block":"0xaa","state":"","epoch_transition":false,"previous_duty_dependent_root":"","current_duty_dependent_root":"","reward":{"status":"mev","proposer_index":0,"reward":"2","unit":"gwei"}}`+"\n\n"+
+		"event: finalized_checkpoint\n"+
+		`data: {"epoch":"3"}`+"\n\n", rr.Body.String())
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetEvents_InvalidTopics(t *testing.T) {
+	handler, err := NewValidatorHandler(new(mockValidatorService), logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/events", expected: `{"error":"invalid event topic","field":"topics","value":""}`},
+		{path: "/events?topics=head,bogus", expected: `{"error":"invalid event topic","field":"topics","value":"bogus"}`},
+		{path: "/events?topics=head,", expected: `{"error":"invalid event topic","field":"topics","value":""}`},
+		{path: "/events?topics=head&unit=finney", expected: `{"error":"invalid reward unit","field":"unit","value":"finney"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, tt.expected, rr.Body.String())
+		})
+	}
+}
+
+func TestValidatorHandler_CloseStreams(t *testing.T) {
+	opened := make(chan struct{})
+	svc := new(mockValidatorService)
+	svc.On("StreamEvents", mock.Anything, []string{"head"}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			close(opened)
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(context.Canceled)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serve(handler, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events?topics=head", nil))
+	}()
+
+	<-opened
+	handler.CloseStreams()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("event stream still open after CloseStreams")
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// ResponseDefaults are the server-wide response options used when a request
+// doesn't set them itself with ?unit or ?pretty.
+type ResponseDefaults struct {
+	// Unit is the reward unit. Empty means wei.
+	Unit domain.RewardUnit
+	// Pretty indents JSON bodies.
+	Pretty bool
+}
+
+// rewardUnit resolves ?unit, falling back to the server default.
+func (d ResponseDefaults) rewardUnit(r *http.Request) (domain.RewardUnit, error) {
+	value := r.URL.Query().Get("unit")
+	if value == "" {
+		return d.Unit, nil
+	}
+
+	unit := domain.RewardUnit(strings.ToLower(value))
+	if !unit.IsValid() {
+		return "", pkgerrors.NewValidationError("unit", value, pkgerrors.ErrInvalidUnit)
+	}
+	return unit, nil
+}
+
+// pretty resolves ?pretty, falling back to the server default. A bare
+// ?pretty counts as true; an unparseable value is ignored.
+func (d ResponseDefaults) pretty(r *http.Request) bool {
+	query := r.URL.Query()
+	if !query.Has("pretty") {
+		return d.Pretty
+	}
+
+	value := query.Get("pretty")
+	if value == "" {
+		return true
+	}
+	pretty, err := strconv.ParseBool(value)
+	if err != nil {
+		return d.Pretty
+	}
+	return pretty
+}
+
+func encodeJSON(w io.Writer, v interface{}, pretty bool) error {
+	enc := json.NewEncoder(w)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(v)
+}
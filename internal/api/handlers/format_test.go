@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_ResponseDefaults(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(100)).Return(&domain.BlockReward{
+		Status: domain.StatusMEV,
+		Reward: big.NewInt(3_000_000_000),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+		Defaults: ResponseDefaults{Unit: domain.UnitGwei, Pretty: true},
+	})
+	require.NoError(t, err)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		return rr
+	}
+
+	t.Run("server defaults apply without params", func(t *testing.T) {
+		rr := get("/blockreward/100")
+		assert.JSONEq(t, `{"data":{"status":"mev","reward":"3","unit":"gwei","proposer_index":0}}`, rr.Body.String())
+		assert.True(t, strings.HasPrefix(rr.Body.String(), "{\n  \"data\""), rr.Body.String())
+	})
+
+	t.Run("params override defaults", func(t *testing.T) {
+		rr := get("/blockreward/100?unit=wei&pretty=false")
+		assert.JSONEq(t, `{"data":{"status":"mev","reward":"3000000000","unit":"wei","proposer_index":0}}`, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "\n  ")
+	})
+}
+
+func TestResponseDefaults_Pretty(t *testing.T) {
+	tests := []struct {
+		query    string
+		fallback bool
+		expected bool
+	}{
+		{query: "", fallback: false, expected: false},
+		{query: "", fallback: true, expected: true},
+		{query: "pretty", fallback: false, expected: true},
+		{query: "pretty=1", fallback: false, expected: true},
+		{query: "pretty=false", fallback: true, expected: false},
+		{query: "pretty=maybe", fallback: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/blockreward/1?"+tt.query, nil)
+		assert.Equal(t, tt.expected, ResponseDefaults{Pretty: tt.fallback}.pretty(req), tt.query)
+	}
+}
+
+func TestNewValidatorHandler_InvalidDefaultUnit(t *testing.T) {
+	_, err := NewValidatorHandler(new(mockValidatorService), logger.New("error"), HandlerConfig{
+		Defaults: ResponseDefaults{Unit: "finney"},
+	})
+	assert.Error(t, err)
+
+	handler, err := NewValidatorHandler(new(mockValidatorService), logger.New("error"), HandlerConfig{
+		Defaults: ResponseDefaults{Unit: "GWEI"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UnitGwei, handler.config.Defaults.Unit)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+func FuzzParseSlotParam(f *testing.F) {
+	seeds := []string{
+		"0", "1", "123/", "007", "18446744073709551615", "18446744073709551616",
+		"0x", "0x0", "0xff", "0XFF", "0xffffffffffffffff", "0x10000000000000000", "0x0x1",
+		"", "/", "//", "1//", "-1", "+1", " 1", "1 ", "1_000", "1e3", "1.0",
+		"1\x00", "\x001", "١٢٣", "１２３", "0x١", "²", "0b101", "0o17",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		slot, err := parseSlotParam(value)
+		if err != nil {
+			var validationErr pkgerrors.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "slot" || !errors.Is(err, pkgerrors.ErrInvalidSlot) {
+				t.Fatalf("parseSlotParam(%q) returned %v, want a slot ValidationError", value, err)
+			}
+			return
+		}
+
+		digits, base := strings.TrimSuffix(value, "/"), 10
+		if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+			digits, base = digits[2:], 16
+		}
+		if !allDigits(digits, base) {
+			t.Fatalf("parseSlotParam(%q) accepted non-digit input as %d", value, slot)
+		}
+	})
+}
+
+// allDigits reports whether s is a non-empty run of ASCII digits in base.
+func allDigits(s string, base int) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case base == 16 && (c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// GetHeaderByStateRoot returns the header at ?slot= with the given state
+// root, including headers of non-canonical forks, so competing blocks can be
+// inspected after a reorg.
+func (h *ValidatorHandler) GetHeaderByStateRoot(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	stateRoot, err := parseStateRootParam(r.PathValue("root"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid state root parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	slot, err := h.parseSlot(ctx, r.URL.Query().Get("slot"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid slot parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Uint64("slot", slot).
+		Str("state_root", stateRoot).
+		Msg("processing header by state root request")
+
+	header, err := h.service.GetBlockHeaderByStateRoot(ctx, slot, stateRoot)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	h.respondJSON(w, r, http.StatusOK, header)
+}
+
+func parseStateRootParam(value string) (string, error) {
+	root := strings.TrimSuffix(value, "/")
+	if !isRoot(root) {
+		return "", pkgerrors.NewValidationError("state_root", root, pkgerrors.ErrInvalidStateRoot)
+	}
+	return strings.ToLower(root), nil
+}
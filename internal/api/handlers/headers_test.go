@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_GetHeaderByStateRoot(t *testing.T) {
+	stateRoot := "0x" + strings.Repeat("ab", 32)
+	unknown := "0x" + strings.Repeat("99", 32)
+
+	svc := new(mockValidatorService)
+	svc.On("GetBlockHeaderByStateRoot", mock.Anything, uint64(100), stateRoot).Return(&domain.BeaconHeader{
+		Slot:          100,
+		ProposerIndex: 7,
+		Root:          "0x44",
+		ParentRoot:    "0x11",
+		StateRoot:     stateRoot,
+		BodyRoot:      "0x33",
+	}, nil)
+	svc.On("GetBlockHeaderByStateRoot", mock.Anything, uint64(100), unknown).Return(nil, pkgerrors.ErrHeaderNotFound)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "found",
+			path:           "/header/stateroot/" + stateRoot + "?slot=100",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"slot":100,"proposer_index":7,"root":"0x44","parent_root":"0x11","state_root":"` + stateRoot + `","body_root":"0x33","canonical":false}}`,
+		},
+		{
+			name:           "uppercase root is normalized",
+			path:           "/header/stateroot/0x" + strings.Repeat("AB", 32) + "?slot=100",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"slot":100,"proposer_index":7,"root":"0x44","parent_root":"0x11","state_root":"` + stateRoot + `","body_root":"0x33","canonical":false}}`,
+		},
+		{
+			name:           "not found",
+			path:           "/header/stateroot/" + unknown + "?slot=100",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"block header not found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "short root",
+			path:           "/header/stateroot/0x22?slot=100",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid state root","field":"state_root","value":"0x22"}`,
+		},
+		{
+			name:           "missing slot",
+			path:           "/header/stateroot/" + stateRoot,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid slot number","field":"slot","value":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	svc.AssertExpectations(t)
+}
@@ -1,22 +1,95 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
 	"runtime"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
 )
 
+type CacheStatsProvider interface {
+	Stats() cache.Stats
+}
+
+// ErrorRateProvider reports the 5xx responses and all responses over a recent
+// window.
+type ErrorRateProvider interface {
+	Counts() (errors, total uint64)
+}
+
+// NodeInfoProvider is the part of the beacon client the health checks use.
+type NodeInfoProvider interface {
+	GetPeerCount(ctx context.Context) (*ethereum.PeerCount, error)
+	GetNodeVersion(ctx context.Context) (string, error)
+}
+
+type HealthConfig struct {
+	// Cache enables the cache saturation check when set.
+	Cache CacheStatsProvider
+	// MaxEvictionRate is the average evictions per second above which the
+	// cache is reported as degraded.
+	MaxEvictionRate float64
+	// Node enables the beacon node checks when set.
+	Node NodeInfoProvider
+	// MinPeers is the connected peer count below which the service isn't
+	// ready.
+	MinPeers uint64
+	// Errors enables the recent error rate check when set.
+	Errors ErrorRateProvider
+	// MaxErrorRate is the fraction of 5xx responses above which the service
+	// is reported as degraded, once at least MinErrorRateRequests were seen.
+	MaxErrorRate         float64
+	MinErrorRateRequests uint64
+	// Initialized, when set, keeps /ready failing until it reports true,
+	// e.g. until the beacon client knows genesis.
+	Initialized func() bool
+}
+
 type HealthHandler struct {
 	startTime time.Time
 	version   string
+	config    HealthConfig
+
+	now func() time.Time
+
+	mu            sync.Mutex
+	lastSample    time.Time
+	lastEvictions uint64
+	evictionRate  float64
 }
 
-func NewHealthHandler(version string) *HealthHandler {
-	return &HealthHandler{
-		startTime: time.Now(),
-		version:   version,
+const (
+	// evictionSampleWindow is the shortest interval the eviction rate is
+	// measured over. Checks closer together than this report the current
+	// rate, so a handful of evictions between two quick checks can't pass
+	// for a burst.
+	evictionSampleWindow = 10 * time.Second
+	// evictionRateSmoothing is the time constant of the moving average the
+	// samples feed: a sustained change shows up fully after a few of it.
+	evictionRateSmoothing = time.Minute
+)
+
+func NewHealthHandler(version string, cfg HealthConfig) *HealthHandler {
+	now := time.Now()
+	h := &HealthHandler{
+		startTime:  now,
+		version:    version,
+		config:     cfg,
+		now:        time.Now,
+		lastSample: now,
+	}
+	if cfg.Cache != nil {
+		h.lastEvictions = cfg.Cache.Stats().Evictions
 	}
+	return h
 }
 
 type HealthResponse struct {
@@ -47,17 +120,154 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	if h.config.Cache != nil || h.config.Node != nil || h.config.Errors != nil {
+		response.Checks = map[string]string{}
+	}
+	if h.config.Cache != nil && !h.checkCache(response.Checks) {
+		response.Status = "degraded"
+	}
+	if h.config.Errors != nil && !h.checkErrors(response.Checks) {
+		response.Status = "degraded"
+	}
+	if h.config.Node != nil {
+		version, err := h.config.Node.GetNodeVersion(r.Context())
+		if err != nil {
+			response.Checks["beacon_node"] = "unreachable"
+			response.Status = "degraded"
+		} else {
+			response.Checks["beacon_node"] = "ok"
+			response.Checks["beacon_version"] = version
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
 
+// checkCache reports utilization and the eviction rate, averaged over the
+// last few minutes so /health doesn't flap between checks. A full cache is
+// normal; constant evictions mean it's undersized.
+func (h *HealthHandler) checkCache(checks map[string]string) bool {
+	stats := h.config.Cache.Stats()
+	rate := h.sampleEvictionRate(stats.Evictions)
+
+	var utilization float64
+	if stats.MaxSize > 0 {
+		utilization = float64(stats.Size) / float64(stats.MaxSize)
+	}
+
+	checks["cache_utilization"] = fmt.Sprintf("%.2f", utilization)
+	checks["cache_eviction_rate"] = fmt.Sprintf("%.2f/s", rate)
+
+	if rate > h.config.MaxEvictionRate {
+		checks["cache"] = "degraded"
+		return false
+	}
+
+	checks["cache"] = "ok"
+	return true
+}
+
+// sampleEvictionRate folds the evictions since the previous sample into the
+// moving average once evictionSampleWindow has passed, and returns it.
+func (h *HealthHandler) sampleEvictionRate(evictions uint64) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	elapsed := now.Sub(h.lastSample)
+	if elapsed < evictionSampleWindow {
+		return h.evictionRate
+	}
+
+	evicted := evictions - h.lastEvictions
+	if evictions < h.lastEvictions {
+		// The counter was reset by a SnapshotStats(true) since the last sample.
+		evicted = evictions
+	}
+	h.lastSample, h.lastEvictions = now, evictions
+
+	sample := float64(evicted) / elapsed.Seconds()
+	weight := 1 - math.Exp(-elapsed.Seconds()/evictionRateSmoothing.Seconds())
+	h.evictionRate += weight * (sample - h.evictionRate)
+	return h.evictionRate
+}
+
+// checkErrors reports the share of recent responses that were 5xx. Too few
+// requests to judge count as ok, so a single failure on an idle server
+// doesn't flip the status.
+func (h *HealthHandler) checkErrors(checks map[string]string) bool {
+	errors, total := h.config.Errors.Counts()
+
+	var rate float64
+	if total > 0 {
+		rate = float64(errors) / float64(total)
+	}
+	checks["http_error_rate"] = fmt.Sprintf("%.2f", rate)
+
+	if total >= h.config.MinErrorRateRequests && rate > h.config.MaxErrorRate {
+		checks["http_errors"] = "degraded"
+		return false
+	}
+
+	checks["http_errors"] = "ok"
+	return true
+}
+
+// Livez answers as long as the server can handle requests. It checks no
+// dependency, so a beacon node outage fails /ready without getting the
+// process restarted.
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "alive"})
+}
+
 func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
 	response := map[string]string{
 		"status": "ready",
 	}
+	status := http.StatusOK
+
+	if h.config.Initialized != nil && !h.config.Initialized() {
+		response["status"] = "not ready"
+		response["reason"] = "beacon genesis not yet known"
+		status = http.StatusServiceUnavailable
+	} else if h.config.Node != nil {
+		reason, peers := h.checkPeers(r.Context())
+		if reason != "" {
+			response["status"] = "not ready"
+			response["reason"] = reason
+			status = http.StatusServiceUnavailable
+		}
+		if peers != "" {
+			response["peers"] = peers
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(response)
 }
+
+// checkPeers returns why the beacon node can't serve useful data, if it
+// can't, along with its connected peer count when known. A node without
+// peers answers requests but can't follow the chain.
+func (h *HealthHandler) checkPeers(ctx context.Context) (reason, peers string) {
+	count, err := h.config.Node.GetPeerCount(ctx)
+	if err != nil {
+		return "beacon node unreachable", ""
+	}
+
+	connected, err := strconv.ParseUint(count.Connected, 10, 64)
+	if err != nil {
+		return "invalid beacon peer count", ""
+	}
+
+	peers = strconv.FormatUint(connected, 10)
+	if connected < h.config.MinPeers {
+		return "beacon node has too few peers", peers
+	}
+	return "", peers
+}
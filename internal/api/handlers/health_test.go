@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+)
+
+func getHealth(t *testing.T, h *HealthHandler) HealthResponse {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	h.Health(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response HealthResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response
+}
+
+func TestHealthHandler_CacheSaturation(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+
+	h := NewHealthHandler("test", HealthConfig{Cache: memCache, MaxEvictionRate: 10})
+	now := h.lastSample
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		memCache.Set(fmt.Sprintf("key:%d", i), i)
+	}
+
+	response := getHealth(t, h)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "ok", response.Checks["cache"])
+	assert.Equal(t, "0.50", response.Checks["cache_utilization"])
+
+	// Thrash the undersized cache.
+	for i := 0; i < 1000; i++ {
+		memCache.Set(fmt.Sprintf("thrash:%d", i), i)
+	}
+	require.Equal(t, uint64(995), memCache.Stats().Evictions)
+
+	// Too soon after the last sample for the evictions to count yet.
+	now = now.Add(time.Second)
+	response = getHealth(t, h)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "0.00/s", response.Checks["cache_eviction_rate"])
+
+	now = now.Add(9 * time.Second)
+	response = getHealth(t, h)
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, "degraded", response.Checks["cache"])
+	assert.Equal(t, "1.00", response.Checks["cache_utilization"])
+	assert.Equal(t, "15.28/s", response.Checks["cache_eviction_rate"])
+
+	// The average decays instead of dropping to zero on the next quiet check.
+	now = now.Add(10 * time.Second)
+	response = getHealth(t, h)
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, "12.93/s", response.Checks["cache_eviction_rate"])
+
+	now = now.Add(2 * time.Minute)
+	response = getHealth(t, h)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "1.75/s", response.Checks["cache_eviction_rate"])
+}
+
+func TestHealthHandler_CacheEvictionBursts(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+
+	h := NewHealthHandler("test", HealthConfig{Cache: memCache, MaxEvictionRate: 10})
+	now := h.lastSample
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		memCache.Set(fmt.Sprintf("key:%d", i), i)
+	}
+
+	// Short bursts between frequent checks would read as hundreds of
+	// evictions per second if each check measured only its own interval.
+	key := 0
+	for check := 0; check < 60; check++ {
+		if check%5 == 0 {
+			for i := 0; i < 40; i++ {
+				memCache.Set(fmt.Sprintf("burst:%d", key), key)
+				key++
+			}
+		}
+		now = now.Add(100 * time.Millisecond)
+		if check%5 == 4 {
+			now = now.Add(10 * time.Second)
+		}
+
+		response := getHealth(t, h)
+		require.Equal(t, "healthy", response.Status, "check %d: %s", check, response.Checks["cache_eviction_rate"])
+	}
+}
+
+func TestHealthHandler_WithoutCache(t *testing.T) {
+	response := getHealth(t, NewHealthHandler("test", HealthConfig{}))
+
+	assert.Equal(t, "healthy", response.Status)
+	assert.Empty(t, response.Checks)
+}
+
+type fakeErrorRate struct {
+	errors, total uint64
+}
+
+func (f *fakeErrorRate) Counts() (uint64, uint64) {
+	return f.errors, f.total
+}
+
+func TestHealthHandler_ErrorRate(t *testing.T) {
+	rate := &fakeErrorRate{}
+	h := NewHealthHandler("test", HealthConfig{Errors: rate, MaxErrorRate: 0.1, MinErrorRateRequests: 10})
+
+	response := getHealth(t, h)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "ok", response.Checks["http_errors"])
+	assert.Equal(t, "0.00", response.Checks["http_error_rate"])
+
+	// Too few requests to judge.
+	rate.errors, rate.total = 3, 5
+	response = getHealth(t, h)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "0.60", response.Checks["http_error_rate"])
+
+	rate.errors, rate.total = 5, 20
+	response = getHealth(t, h)
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, "degraded", response.Checks["http_errors"])
+	assert.Equal(t, "0.25", response.Checks["http_error_rate"])
+
+	rate.errors, rate.total = 2, 20
+	response = getHealth(t, h)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "ok", response.Checks["http_errors"])
+}
+
+type mockNodeInfo struct {
+	peers   *ethereum.PeerCount
+	version string
+	err     error
+}
+
+func (m *mockNodeInfo) GetPeerCount(ctx context.Context) (*ethereum.PeerCount, error) {
+	return m.peers, m.err
+}
+
+func (m *mockNodeInfo) GetNodeVersion(ctx context.Context) (string, error) {
+	return m.version, m.err
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		node           *mockNodeInfo
+		minPeers       uint64
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "healthy peer count",
+			node:           &mockNodeInfo{peers: &ethereum.PeerCount{Connected: "56"}},
+			minPeers:       1,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ready","peers":"56"}`,
+		},
+		{
+			name:           "zero peers",
+			node:           &mockNodeInfo{peers: &ethereum.PeerCount{Connected: "0"}},
+			minPeers:       1,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"not ready","reason":"beacon node has too few peers","peers":"0"}`,
+		},
+		{
+			name:           "below custom threshold",
+			node:           &mockNodeInfo{peers: &ethereum.PeerCount{Connected: "5"}},
+			minPeers:       10,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"not ready","reason":"beacon node has too few peers","peers":"5"}`,
+		},
+		{
+			name:           "zero threshold accepts no peers",
+			node:           &mockNodeInfo{peers: &ethereum.PeerCount{Connected: "0"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ready","peers":"0"}`,
+		},
+		{
+			name:           "unreachable",
+			node:           &mockNodeInfo{err: errors.New("connection refused")},
+			minPeers:       1,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"not ready","reason":"beacon node unreachable"}`,
+		},
+		{
+			name:           "malformed peer count",
+			node:           &mockNodeInfo{peers: &ethereum.PeerCount{Connected: "many"}},
+			minPeers:       1,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"not ready","reason":"invalid beacon peer count"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler("test", HealthConfig{Node: tt.node, MinPeers: tt.minPeers})
+
+			rr := httptest.NewRecorder()
+			h.Ready(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	t.Run("without node check", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		NewHealthHandler("test", HealthConfig{}).Ready(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"status":"ready"}`, rr.Body.String())
+	})
+}
+
+func TestHealthHandler_Livez(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	for name, node := range map[string]NodeInfoProvider{
+		"beacon node down": &mockNodeInfo{err: errors.New("connection refused")},
+		"beacon node hung": &blockingNodeInfo{release: blocked},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := NewHealthHandler("test", HealthConfig{Node: node, MinPeers: 1})
+
+			rr := httptest.NewRecorder()
+			h.Livez(rr, httptest.NewRequest(http.MethodGet, "/livez", nil))
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, `{"status":"alive"}`, rr.Body.String())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			rr = httptest.NewRecorder()
+			h.Ready(rr, httptest.NewRequest(http.MethodGet, "/ready", nil).WithContext(ctx))
+			assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		})
+	}
+}
+
+// blockingNodeInfo never answers before its context ends.
+type blockingNodeInfo struct {
+	release chan struct{}
+}
+
+func (b *blockingNodeInfo) GetPeerCount(ctx context.Context) (*ethereum.PeerCount, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.release:
+		return nil, errors.New("released")
+	}
+}
+
+func (b *blockingNodeInfo) GetNodeVersion(ctx context.Context) (string, error) {
+	_, err := b.GetPeerCount(ctx)
+	return "", err
+}
+
+func TestHealthHandler_ReadyBeforeInitialized(t *testing.T) {
+	var initialized atomic.Bool
+	h := NewHealthHandler("test", HealthConfig{
+		Node:        &mockNodeInfo{peers: &ethereum.PeerCount{Connected: "56"}},
+		MinPeers:    1,
+		Initialized: initialized.Load,
+	})
+
+	rr := httptest.NewRecorder()
+	h.Ready(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.JSONEq(t, `{"status":"not ready","reason":"beacon genesis not yet known"}`, rr.Body.String())
+
+	initialized.Store(true)
+
+	rr = httptest.NewRecorder()
+	h.Ready(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ready","peers":"56"}`, rr.Body.String())
+}
+
+func TestHealthHandler_BeaconVersion(t *testing.T) {
+	response := getHealth(t, NewHealthHandler("test", HealthConfig{
+		Node: &mockNodeInfo{version: "Lighthouse/v5.1.0-1b5c7a3/x86_64-linux"},
+	}))
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "ok", response.Checks["beacon_node"])
+	assert.Equal(t, "Lighthouse/v5.1.0-1b5c7a3/x86_64-linux", response.Checks["beacon_version"])
+
+	response = getHealth(t, NewHealthHandler("test", HealthConfig{
+		Node: &mockNodeInfo{err: errors.New("connection refused")},
+	}))
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, "unreachable", response.Checks["beacon_node"])
+	assert.NotContains(t, response.Checks, "beacon_version")
+}
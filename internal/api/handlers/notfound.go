@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+)
+
+const (
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+)
+
+// NewNotFoundHandler returns the catch-all handler for unregistered paths so
+// they get the same JSON error envelope as the rest of the API.
+func NewNotFoundHandler(message string) http.HandlerFunc {
+	if message == "" {
+		message = http.StatusText(http.StatusNotFound)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(Response{
+			Error:     message,
+			Code:      CodeNotFound,
+			RequestID: middleware.GetRequestID(r.Context()),
+		})
+	}
+}
+
+// NewMethodNotAllowedHandler answers requests to a known path with the wrong
+// method. The router sets the Allow header.
+func NewMethodNotAllowedHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(Response{
+			Error:     http.StatusText(http.StatusMethodNotAllowed),
+			Code:      CodeMethodNotAllowed,
+			RequestID: middleware.GetRequestID(r.Context()),
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/api/router"
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestNotFoundHandler(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	assert.NoError(t, err)
+
+	mux := router.New(router.Config{NotFound: NewNotFoundHandler("resource not found")})
+	handler.RegisterRoutes(mux)
+
+	t.Run("unknown path", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest("GET", "/foo", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "resource not found", response["error"])
+		assert.Equal(t, CodeNotFound, response["code"])
+	})
+
+	t.Run("request id in body", func(t *testing.T) {
+		withID := middleware.RequestIDWithGenerator(func() string { return "req-404" })(mux)
+
+		rr := httptest.NewRecorder()
+		withID.ServeHTTP(rr, httptest.NewRequest("GET", "/foo", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{"error":"resource not found","code":"NOT_FOUND","request_id":"req-404"}`, rr.Body.String())
+		assert.Equal(t, "req-404", rr.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("registered prefix still routed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest("GET", "/blockreward/1", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		svc.AssertExpectations(t)
+	})
+}
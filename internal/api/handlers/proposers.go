@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// GetEpochProposers lists the epoch's scheduled proposals with what each
+// proposer earned, including missed slots.
+func (h *ValidatorHandler) GetEpochProposers(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	epoch, err := parseEpochParam(r.PathValue("epoch"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid epoch parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	unit, err := h.config.Defaults.rewardUnit(r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid unit parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Uint64("epoch", epoch).
+		Msg("processing epoch proposers request")
+
+	rewards, err := h.service.GetEpochProposerRewards(ctx, epoch)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	numeric := queryBool(r, "numeric")
+	for i := range rewards {
+		rewards[i].Unit = unit
+		rewards[i].Numeric = numeric
+	}
+
+	h.respondJSON(w, r, http.StatusOK, rewards)
+}
+
+func parseEpochParam(value string) (uint64, error) {
+	epoch, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, pkgerrors.NewValidationError("epoch", value, pkgerrors.ErrInvalidEpoch)
+	}
+	return epoch, nil
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_GetEpochProposers(t *testing.T) {
+	rewards := func() []domain.ProposerReward {
+		return []domain.ProposerReward{
+			{Slot: 320, ValidatorIndex: 1, Pubkey: "0xaa", Proposed: true, Status: domain.StatusMEV, Reward: big.NewInt(2000000000)},
+			{Slot: 321, ValidatorIndex: 2, Pubkey: "0xbb", Reward: new(big.Int)},
+		}
+	}
+
+	svc := new(mockValidatorService)
+	svc.On("GetEpochProposerRewards", mock.Anything, uint64(10)).Return(rewards(), nil).Once()
+	svc.On("GetEpochProposerRewards", mock.Anything, uint64(10)).Return(rewards(), nil).Once()
+	svc.On("GetEpochProposerRewards", mock.Anything, uint64(99999)).Return(nil, pkgerrors.ErrFutureEpoch)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			path:           "/epoch/10/proposers",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[
+				{"slot":320,"validator_index":1,"pubkey":"0xaa","proposed":true,"status":"mev","reward":"2000000000","unit":"wei"},
+				{"slot":321,"validator_index":2,"pubkey":"0xbb","proposed":false,"reward":"0","unit":"wei"}
+			]}`,
+		},
+		{
+			path:           "/epoch/10/proposers?unit=gwei&numeric=true",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[
+				{"slot":320,"validator_index":1,"pubkey":"0xaa","proposed":true,"status":"mev","reward":2,"unit":"gwei"},
+				{"slot":321,"validator_index":2,"pubkey":"0xbb","proposed":false,"reward":0,"unit":"gwei"}
+			]}`,
+		},
+		{
+			path:           "/epoch/99999/proposers",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"requested epoch is in the future"}`,
+		},
+		{
+			path:           "/epoch/abc/proposers",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid epoch","field":"epoch","value":"abc"}`,
+		},
+		{
+			path:           "/epoch/10/proposers?unit=finney",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid reward unit","field":"unit","value":"finney"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	svc.AssertExpectations(t)
+}
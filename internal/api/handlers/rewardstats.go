@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// maxRewardStatsRange caps the slots of one stats request. Every uncached
+// slot costs a block and a rewards call to the beacon node.
+const maxRewardStatsRange = 256
+
+// GetBlockRewardStats returns the mean, median, min and max block reward of
+// the slots from ?from through ?to, with how many were proposed and missed.
+func (h *ValidatorHandler) GetBlockRewardStats(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	from, to, err := parseRange(r, maxRewardStatsRange, pkgerrors.ErrInvalidSlot, pkgerrors.ErrInvalidSlotRange)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid slot range")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	unit, err := h.config.Defaults.rewardUnit(r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid unit parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Uint64("from", from).
+		Uint64("to", to).
+		Msg("processing block reward stats request")
+
+	stats, err := h.service.GetBlockRewardStats(ctx, from, to)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	stats.Unit = unit
+	stats.Numeric = queryBool(r, "numeric")
+	if stats.Stale {
+		w.Header().Set("Warning", staleWarning)
+	}
+
+	h.respondJSON(w, r, http.StatusOK, stats)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_GetBlockRewardStats(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockRewardStats", mock.Anything, uint64(10), uint64(12)).Return(&domain.BlockRewardStats{
+		From:     10,
+		To:       12,
+		Proposed: 2,
+		Missed:   1,
+		Total:    big.NewInt(30_000_000_000),
+		Mean:     big.NewInt(15_000_000_000),
+		Median:   big.NewInt(15_000_000_000),
+		Min:      big.NewInt(10_000_000_000),
+		Max:      big.NewInt(20_000_000_000),
+	}, nil)
+	svc.On("GetBlockRewardStats", mock.Anything, uint64(20), uint64(20)).Return(&domain.BlockRewardStats{From: 20, To: 20, Missed: 1}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			path:           "/blockrewards/stats?from=10&to=12",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"from":10,"to":12,"proposed":2,"missed":1,"unit":"wei",
+				"total":"30000000000","mean":"15000000000","median":"15000000000","min":"10000000000","max":"20000000000"}}`,
+		},
+		{
+			path:           "/blockrewards/stats?from=10&to=12&unit=gwei&numeric=true",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"from":10,"to":12,"proposed":2,"missed":1,"unit":"gwei",
+				"total":30,"mean":15,"median":15,"min":10,"max":20}}`,
+		},
+		{
+			path:           "/blockrewards/stats?from=20&to=20",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"from":20,"to":20,"proposed":0,"missed":1,"unit":"wei",
+				"total":null,"mean":null,"median":null,"min":null,"max":null}}`,
+		},
+		{
+			path:           "/blockrewards/stats?from=1&to=257",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid slot range","field":"to","value":"257"}`,
+		},
+		{
+			path:           "/blockrewards/stats?from=3&to=2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid slot range","field":"to","value":"2"}`,
+		},
+		{
+			path:           "/blockrewards/stats?from=x&to=2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid slot number","field":"from","value":"x"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestValidatorHandler_GetBlockRewardStats_Stale(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockRewardStats", mock.Anything, uint64(1), uint64(2)).Return(&domain.BlockRewardStats{
+		From:     1,
+		To:       2,
+		Proposed: 1,
+		Missed:   1,
+		Stale:    true,
+		Total:    big.NewInt(7),
+		Mean:     big.NewInt(7),
+		Median:   big.NewInt(7),
+		Min:      big.NewInt(7),
+		Max:      big.NewInt(7),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodGet, "/blockrewards/stats?from=1&to=2", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, staleWarning, rr.Header().Get("Warning"))
+	assert.JSONEq(t, `{"data":{"from":1,"to":2,"proposed":1,"missed":1,"stale":true,"unit":"wei",
+		"total":"7","mean":"7","median":"7","min":"7","max":"7"}}`, rr.Body.String())
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/api/router"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// Route groups name the endpoints that HandlerConfig.DisabledRoutes can turn
+// off together.
+const (
+	RouteBlockReward   = "blockreward"
+	RouteSlotStatus    = "slotstatus"
+	RouteBlock         = "block"
+	RouteHeader        = "header"
+	RouteSyncDuties    = "syncduties"
+	RouteProposers     = "proposers"
+	RouteSyncCommittee = "synccommittee"
+	RouteMEVRelays     = "mevrelays"
+	RouteEvents        = "events"
+)
+
+type route struct {
+	group   string
+	method  string
+	pattern string
+	handler http.HandlerFunc
+	// params are the query parameters the handler reads, besides
+	// commonQueryParams. STRICT_QUERY_PARAMS rejects any other.
+	params []string
+}
+
+// commonQueryParams are accepted by every endpoint.
+var commonQueryParams = []string{"pretty", "nocache"}
+
+func (h *ValidatorHandler) routes() []route {
+	rewardParams := []string{"unit", "breakdown", "numeric", "include"}
+
+	return []route{
+		{RouteBlockReward, http.MethodGet, "/blockreward/{slot...}", h.GetBlockReward, rewardParams},
+		{RouteBlockReward, http.MethodPost, "/blockrewards", h.GetBlockRewardsBatch, append([]string{"partial"}, rewardParams...)},
+		{RouteBlockReward, http.MethodGet, "/blockrewards/stats", h.GetBlockRewardStats, []string{"from", "to", "unit", "numeric"}},
+		{RouteSlotStatus, http.MethodGet, "/slot/{slot}/status", h.GetSlotStatus, []string{"include"}},
+		{RouteBlock, http.MethodGet, "/block/{slot...}", h.GetBlock, []string{"include"}},
+		{RouteHeader, http.MethodGet, "/header/stateroot/{root...}", h.GetHeaderByStateRoot, []string{"slot"}},
+		{RouteSyncDuties, http.MethodGet, "/syncduties/{slot...}", h.GetSyncDuties, []string{"include", "cursor", "offset", "limit"}},
+		{RouteProposers, http.MethodGet, "/epoch/{epoch}/proposers", h.GetEpochProposers, []string{"unit", "numeric"}},
+		{RouteSyncCommittee, http.MethodGet, "/synccommittee/period/{period...}", h.GetSyncCommitteeByPeriod, nil},
+		{RouteSyncCommittee, http.MethodGet, "/synccommittee/periods", h.GetSyncCommitteePeriods, []string{"from", "to", "include"}},
+		{RouteSyncCommittee, http.MethodGet, "/synccommittee/state", h.GetSyncCommitteeAtState, []string{"state_id"}},
+		{RouteSyncCommittee, http.MethodGet, "/synccommittee/state/{state...}", h.GetSyncCommitteeAtState, nil},
+		{RouteMEVRelays, http.MethodGet, "/mev/relays", h.GetMEVRelays, nil},
+		{RouteEvents, http.MethodGet, "/events", h.GetEvents, []string{"topics", "unit", "breakdown", "numeric"}},
+	}
+}
+
+// RegisterRoutes mounts the validator endpoints, leaving out the groups in
+// DisabledRoutes so they answer 404. Remainder wildcards keep malformed
+// paths such as "/blockreward/" answering 400 from the parameter parsers
+// instead of 404.
+func (h *ValidatorHandler) RegisterRoutes(r router.Router) {
+	for _, rt := range h.routes() {
+		if h.config.DisabledRoutes[rt.group] {
+			continue
+		}
+
+		handler := rt.handler
+		if h.config.StrictQueryParams {
+			handler = h.rejectUnknownParams(rt.params, handler)
+		}
+		r.HandleFunc(rt.method, rt.pattern, handler)
+	}
+}
+
+// rejectUnknownParams answers 400 listing the query parameters that are
+// neither in params nor commonQueryParams, so a typo such as ?unti=ether
+// isn't silently ignored.
+func (h *ValidatorHandler) rejectUnknownParams(params []string, next http.HandlerFunc) http.HandlerFunc {
+	known := make(map[string]bool, len(params)+len(commonQueryParams))
+	for _, list := range [][]string{params, commonQueryParams} {
+		for _, param := range list {
+			known[param] = true
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var unknown []string
+		for key := range r.URL.Query() {
+			if !known[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		if len(unknown) == 0 {
+			next(w, r)
+			return
+		}
+
+		sort.Strings(unknown)
+		err := pkgerrors.NewValidationError("query", unknown, pkgerrors.ErrUnknownQueryParam)
+		h.logger.Warn().
+			Str("request_id", middleware.GetRequestID(r.Context())).
+			Strs("params", unknown).
+			Msg("unknown query parameters")
+		h.respondError(w, r, http.StatusBadRequest, err)
+	}
+}
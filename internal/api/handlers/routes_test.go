@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_DisabledRoutes(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+		DisabledRoutes: map[string]bool{RouteSyncDuties: true, RouteBlockReward: false},
+	})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodGet, "/blockreward/1", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	for _, path := range []string{"/syncduties/1", "/syncduties/"} {
+		rr = httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code, path)
+	}
+
+	svc.AssertNotCalled(t, "GetSyncCommitteeDuties", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestValidatorHandler_StrictQueryParams(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1_000_000_000_000_000_000),
+	}, nil)
+
+	tests := []struct {
+		name           string
+		strict         bool
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "lenient ignores a typo",
+			path:           "/blockreward/1?unti=ether",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"status":"vanilla","reward":"1000000000000000000","proposer_index":0,"unit":"wei"}}`,
+		},
+		{
+			name:           "strict rejects a typo",
+			strict:         true,
+			path:           "/blockreward/1?unti=ether",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unknown query parameter","field":"query","value":["unti"]}`,
+		},
+		{
+			name:           "strict lists every unknown parameter",
+			strict:         true,
+			path:           "/blockreward/1?unit=ether&numric=true&breakdwon=true",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unknown query parameter","field":"query","value":["breakdwon","numric"]}`,
+		},
+		{
+			name:           "strict accepts known and common parameters",
+			strict:         true,
+			path:           "/blockreward/1?unit=ether&nocache=true&pretty=false",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"status":"vanilla","reward":"1","proposer_index":0,"unit":"ether"}}`,
+		},
+		{
+			name:           "strict checks per endpoint",
+			strict:         true,
+			path:           "/synccommittee/period/1?unit=ether",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unknown query parameter","field":"query","value":["unit"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{StrictQueryParams: tt.strict})
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	svc.AssertNotCalled(t, "GetSyncCommitteeByPeriod", mock.Anything, mock.Anything)
+}
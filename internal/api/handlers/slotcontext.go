@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+)
+
+// SlotContext places a requested slot relative to the chain head. It's only
+// added to responses that ask for it with ?include=context.
+type SlotContext struct {
+	CurrentSlot   uint64 `json:"current_slot"`
+	RequestedSlot uint64 `json:"requested_slot"`
+	// SlotsBehindHead is negative for slots after the current one. It's
+	// clamped to the int64 range.
+	SlotsBehindHead int64  `json:"slots_behind_head"`
+	Epoch           uint64 `json:"epoch"`
+}
+
+// slotContext returns the context for slot when the request includes it. It
+// returns nil when it wasn't asked for or the current slot is unknown, so the
+// response is still served without it.
+func (h *ValidatorHandler) slotContext(ctx context.Context, r *http.Request, slot uint64) *SlotContext {
+	if !includes(r, "context") || h.config.CurrentSlot == nil {
+		return nil
+	}
+
+	current, err := h.config.CurrentSlot(ctx)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", middleware.GetRequestID(ctx)).
+			Err(err).
+			Msg("failed to get current slot for response context")
+		return nil
+	}
+
+	return &SlotContext{
+		CurrentSlot:     current,
+		RequestedSlot:   slot,
+		SlotsBehindHead: slotsBehind(current, slot),
+		Epoch:           h.spec.SlotToEpoch(slot),
+	}
+}
+
+// slotsBehind returns current-slot, computed in uint64 so slots beyond
+// math.MaxInt64 don't overflow.
+func slotsBehind(current, slot uint64) int64 {
+	if current >= slot {
+		return int64(min(current-slot, math.MaxInt64))
+	}
+	return -int64(min(slot-current, math.MaxInt64))
+}
+
+// respondWithContext is respondJSON with an optional slot context.
+func (h *ValidatorHandler) respondWithContext(w http.ResponseWriter, r *http.Request, data interface{}, slotCtx *SlotContext) {
+	h.writeResponse(w, r, http.StatusOK, Response{Data: data, Context: slotCtx})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+var namedStates = map[string]struct{}{
+	"head":      {},
+	"genesis":   {},
+	"finalized": {},
+	"justified": {},
+}
+
+// parseStateID validates a beacon API state identifier and normalizes it to
+// the form the beacon node expects: a named state, a decimal slot or a
+// lowercase 0x-prefixed 32-byte state root. Every endpoint that takes a state
+// goes through it so they all accept the same identifiers.
+func parseStateID(value string) (string, error) {
+	stateID := strings.TrimSuffix(value, "/")
+
+	if _, ok := namedStates[stateID]; ok {
+		return stateID, nil
+	}
+
+	if _, err := strconv.ParseUint(stateID, 10, 64); err == nil {
+		return stateID, nil
+	}
+
+	if isRoot(stateID) {
+		return strings.ToLower(stateID), nil
+	}
+
+	return "", pkgerrors.NewValidationError("state", stateID, pkgerrors.ErrInvalidStateID)
+}
+
+// isRoot reports whether value is a 0x-prefixed 32-byte hex root.
+func isRoot(value string) bool {
+	root, ok := strings.CutPrefix(value, "0x")
+	if !ok || len(root) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(root)
+	return err == nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+func TestParseStateID(t *testing.T) {
+	root := "0x" + strings.Repeat("ab", 32)
+
+	valid := []struct {
+		value, expected string
+	}{
+		{value: "head", expected: "head"},
+		{value: "genesis", expected: "genesis"},
+		{value: "finalized", expected: "finalized"},
+		{value: "justified/", expected: "justified"},
+		{value: "0", expected: "0"},
+		{value: "8994816", expected: "8994816"},
+		{value: root, expected: root},
+		{value: "0x" + strings.Repeat("AB", 32), expected: root},
+	}
+	for _, tt := range valid {
+		stateID, err := parseStateID(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, stateID, tt.value)
+	}
+
+	invalid := []string{
+		"",
+		"Head",
+		"latest",
+		"-1",
+		"18446744073709551616",
+		"0x1",
+		"0X" + strings.Repeat("ab", 32),
+		"0x" + strings.Repeat("zz", 32),
+		root + "ab",
+	}
+	for _, value := range invalid {
+		_, err := parseStateID(value)
+		assert.ErrorIs(t, err, pkgerrors.ErrInvalidStateID, value)
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// maxSyncCommitteePeriodRange caps the periods of one range request. Each
+// committee is 512 members, so a full range with members stays a few MB.
+const maxSyncCommitteePeriodRange = 64
+
+// GetSyncCommitteePeriods returns a summary of every committee from ?from
+// through ?to, or the full members with ?include=members.
+func (h *ValidatorHandler) GetSyncCommitteePeriods(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	from, to, err := parseRange(r, maxSyncCommitteePeriodRange, pkgerrors.ErrInvalidPeriod, pkgerrors.ErrInvalidPeriodRange)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid period range")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Uint64("from", from).
+		Uint64("to", to).
+		Msg("processing sync committee period range request")
+
+	committees, err := h.service.GetSyncCommitteesByPeriodRange(ctx, from, to)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	members := includes(r, "members")
+	summaries := make([]domain.SyncCommitteeSummary, len(committees))
+	for i, committee := range committees {
+		summaries[i] = syncCommitteeSummary(committee, members)
+	}
+
+	h.respondJSON(w, r, http.StatusOK, summaries)
+}
+
+// syncCommitteeSummary hashes the members in committee order, since a
+// member's position determines its subnet and reordering is a change too.
+func syncCommitteeSummary(committee *domain.SyncCommitteeDuties, members bool) domain.SyncCommitteeSummary {
+	hash := sha256.Sum256([]byte(strings.Join(committee.Validators, ",")))
+
+	summary := domain.SyncCommitteeSummary{
+		Period:          committee.Period,
+		PeriodStartSlot: committee.PeriodStartSlot,
+		PeriodEndSlot:   committee.PeriodEndSlot,
+		Size:            len(committee.Validators),
+		MembersHash:     "0x" + hex.EncodeToString(hash[:]),
+	}
+	if members {
+		summary.Validators = committee.Validators
+	}
+	return summary
+}
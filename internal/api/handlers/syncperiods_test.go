@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorHandler_GetSyncCommitteePeriods(t *testing.T) {
+	committees := []*domain.SyncCommitteeDuties{
+		{Period: 1, PeriodStartSlot: 8192, PeriodEndSlot: 16383, Validators: []string{"0x01", "0x02"}},
+		{Period: 2, PeriodStartSlot: 16384, PeriodEndSlot: 24575, Validators: []string{"0x02", "0x01"}},
+	}
+
+	svc := new(mockValidatorService)
+	svc.On("GetSyncCommitteesByPeriodRange", mock.Anything, uint64(1), uint64(2)).Return(committees, nil)
+	svc.On("GetSyncCommitteesByPeriodRange", mock.Anything, uint64(1), uint64(64)).Return([]*domain.SyncCommitteeDuties{}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			path:           "/synccommittee/periods?from=1&to=2",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[
+				{"period":1,"period_start_slot":8192,"period_end_slot":16383,"size":2,"members_hash":"0xaaa4ab961d552511d2d575bf453ccccb687e01c3ed476d19a158022ec49175ec"},
+				{"period":2,"period_start_slot":16384,"period_end_slot":24575,"size":2,"members_hash":"0x5200245cde7bb350ee90c54d0b45da3691830743d910d1683d9906f6a762dce8"}
+			]}`,
+		},
+		{
+			path:           "/synccommittee/periods?from=1&to=2&include=members",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[
+				{"period":1,"period_start_slot":8192,"period_end_slot":16383,"size":2,"members_hash":"0xaaa4ab961d552511d2d575bf453ccccb687e01c3ed476d19a158022ec49175ec","validators":["0x01","0x02"]},
+				{"period":2,"period_start_slot":16384,"period_end_slot":24575,"size":2,"members_hash":"0x5200245cde7bb350ee90c54d0b45da3691830743d910d1683d9906f6a762dce8","validators":["0x02","0x01"]}
+			]}`,
+		},
+		{
+			path:           "/synccommittee/periods?from=1&to=64",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+		},
+		{
+			path:           "/synccommittee/periods?from=1&to=65",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid sync committee period range","field":"to","value":"65"}`,
+		},
+		{
+			path:           "/synccommittee/periods?from=3&to=2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid sync committee period range","field":"to","value":"2"}`,
+		},
+		{
+			path:           "/synccommittee/periods?to=2",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid sync committee period","field":"from","value":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	svc.AssertExpectations(t)
+}
@@ -1,24 +1,69 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/domain"
 	"github.com/matheus/eth-validator-api/internal/service"
+	"github.com/matheus/eth-validator-api/pkg/beaconmath"
 	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
 	"github.com/matheus/eth-validator-api/pkg/logger"
+	"github.com/matheus/eth-validator-api/pkg/pagination"
 )
 
 type ValidatorHandler struct {
 	service service.ValidatorService
 	logger  logger.Logger
+	config  HandlerConfig
+	spec    beaconmath.Spec
+
+	// streams ends open event streams when cancelled by CloseStreams.
+	streams      context.Context
+	closeStreams context.CancelFunc
+}
+
+type HandlerConfig struct {
+	// FinalizedMaxAge is the Cache-Control max-age advertised for finalized
+	// results. Zero disables public caching.
+	FinalizedMaxAge time.Duration
+	// WriteTimeout, when set, is the deadline granted to each chunk of a
+	// large response instead of to the response as a whole.
+	WriteTimeout time.Duration
+	// MaxBatchSize caps the slots accepted by one batch request.
+	MaxBatchSize int
+	// BatchConcurrency bounds the slots of a batch fetched at once.
+	BatchConcurrency int
+	// BatchDeadlineMargin is how long before the request deadline a batch
+	// with ?partial=true stops fetching and returns what it has. Zero means
+	// defaultBatchDeadlineMargin.
+	BatchDeadlineMargin time.Duration
+	// CurrentSlot, together with a non-zero MaxSlotMargin, rejects slots
+	// more than MaxSlotMargin past the current slot before they reach the
+	// service.
+	CurrentSlot   func(ctx context.Context) (uint64, error)
+	MaxSlotMargin uint64
+	// SlotsPerEpoch is used for the epoch in ?include=context. Zero means
+	// beaconmath.DefaultSlotsPerEpoch.
+	SlotsPerEpoch uint64
+	// Defaults apply to requests without ?unit or ?pretty.
+	Defaults ResponseDefaults
+	// DisabledRoutes holds the route groups, such as RouteSyncDuties, that
+	// RegisterRoutes leaves out.
+	DisabledRoutes map[string]bool
+	// StrictQueryParams rejects requests with query parameters the endpoint
+	// doesn't know instead of ignoring them.
+	StrictQueryParams bool
 }
 
-func NewValidatorHandler(service service.ValidatorService, logger logger.Logger) (*ValidatorHandler, error) {
+func NewValidatorHandler(service service.ValidatorService, logger logger.Logger, cfg HandlerConfig) (*ValidatorHandler, error) {
 	if service == nil {
 		return nil, errors.New("validator service is required")
 	}
@@ -26,28 +71,74 @@ func NewValidatorHandler(service service.ValidatorService, logger logger.Logger)
 		return nil, errors.New("logger is required")
 	}
 
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = defaultMaxBatchSize
+	}
+	if cfg.BatchConcurrency <= 0 {
+		cfg.BatchConcurrency = defaultBatchConcurrency
+	}
+	if cfg.BatchDeadlineMargin <= 0 {
+		cfg.BatchDeadlineMargin = defaultBatchDeadlineMargin
+	}
+	if cfg.Defaults.Unit == "" {
+		cfg.Defaults.Unit = domain.UnitWei
+	}
+	cfg.Defaults.Unit = domain.RewardUnit(strings.ToLower(string(cfg.Defaults.Unit)))
+	if !cfg.Defaults.Unit.IsValid() {
+		return nil, fmt.Errorf("invalid default reward unit %q", cfg.Defaults.Unit)
+	}
+
+	streams, closeStreams := context.WithCancel(context.Background())
+
 	return &ValidatorHandler{
-		service: service,
-		logger:  logger,
+		service:      service,
+		logger:       logger,
+		config:       cfg,
+		spec:         beaconmath.NewSpec(0, cfg.SlotsPerEpoch, 0),
+		streams:      streams,
+		closeStreams: closeStreams,
 	}, nil
 }
 
 type Response struct {
 	Data  interface{} `json:"data,omitempty"`
 	Error string      `json:"error,omitempty"`
+	Code  string      `json:"code,omitempty"`
+	Field string      `json:"field,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+	// NextCursor is set on paged responses that have more items.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// RequestID is echoed on errors so they can be matched to server logs.
+	RequestID string `json:"request_id,omitempty"`
+	// Context is set when the request asks for it with ?include=context.
+	Context *SlotContext `json:"context,omitempty"`
+	// Truncated marks a partial batch response; Unprocessed lists the slots
+	// that weren't fetched before the deadline.
+	Truncated   bool     `json:"truncated,omitempty"`
+	Unprocessed []uint64 `json:"unprocessed,omitempty"`
 }
 
 func (h *ValidatorHandler) GetBlockReward(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	ctx := serviceContext(r)
 	requestID := middleware.GetRequestID(ctx)
 
-	slot, err := h.parseSlotFromPath(r.URL.Path, "/blockreward/")
+	slot, err := h.parseSlot(ctx, r.PathValue("slot"))
 	if err != nil {
 		h.logger.Warn().
 			Str("request_id", requestID).
 			Err(err).
 			Msg("invalid slot parameter")
-		h.respondError(w, http.StatusBadRequest, pkgerrors.ErrInvalidSlot)
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	unit, err := h.config.Defaults.rewardUnit(r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid unit parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
 		return
 	}
 
@@ -58,24 +149,123 @@ func (h *ValidatorHandler) GetBlockReward(w http.ResponseWriter, r *http.Request
 
 	reward, err := h.service.GetBlockReward(ctx, slot)
 	if err != nil {
-		h.handleServiceError(w, err, requestID)
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	view := blockRewardView(reward, unit, queryBool(r, "breakdown"), queryBool(r, "numeric"))
+
+	if includes(r, "execution") {
+		view.ExecutionBlockNumber = reward.ExecutionBlockNumber
+		view.ExecutionBlockHash = reward.ExecutionBlockHash
+	}
+
+	if includes(r, "proposer_pubkey") {
+		pubkey, err := h.service.ResolvePubkey(ctx, reward.ProposerIndex)
+		if err != nil {
+			h.handleServiceError(w, r, err)
+			return
+		}
+		view.ProposerPubkey = pubkey
+	}
+
+	if reward.Stale {
+		w.Header().Set("Warning", staleWarning)
+	}
+
+	// The context moves with the head, so it can't be cached as immutable.
+	// Neither can a stale reward, or the warning would outlive the outage.
+	slotCtx := h.slotContext(ctx, r, slot)
+	h.setCacheControl(w, reward.Finalized && !reward.Stale && slotCtx == nil)
+	h.respondWithContext(w, r, view, slotCtx)
+}
+
+func (h *ValidatorHandler) GetSlotStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	slot, err := h.parseSlot(ctx, r.PathValue("slot"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid slot parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := h.service.GetSlotStatus(ctx, slot)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	slotCtx := h.slotContext(ctx, r, slot)
+	h.setCacheControl(w, status.Finalized && slotCtx == nil)
+	h.respondWithContext(w, r, status, slotCtx)
+}
+
+func (h *ValidatorHandler) GetBlock(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	slot, err := h.parseSlot(ctx, r.PathValue("slot"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid slot parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	block, err := h.service.GetBlock(ctx, slot)
+	if err != nil {
+		h.handleServiceError(w, r, err)
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, reward)
+	// The blob count and participation are only shown on request.
+	view := *block
+	if !includes(r, "blobs") {
+		view.BlobCount = nil
+	}
+	if !includes(r, "participation") {
+		view.Participation = nil
+	}
+
+	slotCtx := h.slotContext(ctx, r, slot)
+	h.setCacheControl(w, block.Finalized && slotCtx == nil)
+	h.respondWithContext(w, r, view, slotCtx)
+}
+
+// staleWarning is the Warning header of responses carrying a stale reward.
+const staleWarning = `110 - "Response is Stale"`
+
+// blockRewardView copies reward for presentation, since the service may hand
+// out cached values. The execution block fields are only shown on request.
+func blockRewardView(reward *domain.BlockReward, unit domain.RewardUnit, breakdown, numeric bool) domain.BlockReward {
+	view := *reward
+	view.Unit = unit
+	view.Numeric = numeric
+	if !breakdown {
+		view.Breakdown = nil
+	}
+	view.ExecutionBlockNumber, view.ExecutionBlockHash = 0, ""
+	return view
 }
 
 func (h *ValidatorHandler) GetSyncDuties(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	ctx := serviceContext(r)
 	requestID := middleware.GetRequestID(ctx)
 
-	slot, err := h.parseSlotFromPath(r.URL.Path, "/syncduties/")
+	slot, err := h.parseSlot(ctx, r.PathValue("slot"))
 	if err != nil {
 		h.logger.Warn().
 			Str("request_id", requestID).
 			Err(err).
 			Msg("invalid slot parameter")
-		h.respondError(w, http.StatusBadRequest, pkgerrors.ErrInvalidSlot)
+		h.respondError(w, r, http.StatusBadRequest, err)
 		return
 	}
 
@@ -84,83 +274,383 @@ func (h *ValidatorHandler) GetSyncDuties(w http.ResponseWriter, r *http.Request)
 		Uint64("slot", slot).
 		Msg("processing sync duties request")
 
-	duties, err := h.service.GetSyncCommitteeDuties(ctx, slot)
+	page, paged, err := parsePageParams(r)
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid pagination parameters")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	opts := service.SyncDutiesOptions{
+		IncludeNext: includes(r, "next"),
+	}
+
+	duties, err := h.service.GetSyncCommitteeDuties(ctx, slot, opts)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	slotCtx := h.slotContext(ctx, r, slot)
+	if !paged {
+		h.respondWithContext(w, r, duties, slotCtx)
+		return
+	}
+
+	// Only the current committee is paged; the copy keeps the cached value intact.
+	start, end, next := pagination.Bounds(len(duties.Validators), page.offset, page.limit)
+	view := *duties
+	view.Validators = duties.Validators[start:end]
+
+	h.writeResponse(w, r, http.StatusOK, Response{Data: &view, NextCursor: next, Context: slotCtx})
+}
+
+func (h *ValidatorHandler) GetSyncCommitteeByPeriod(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	period, err := parsePeriodParam(r.PathValue("period"))
+	if err != nil {
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid period parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("request_id", requestID).
+		Uint64("period", period).
+		Msg("processing sync committee period request")
+
+	duties, err := h.service.GetSyncCommitteeByPeriod(ctx, period)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondJSON(w, r, http.StatusOK, duties)
+}
+
+func (h *ValidatorHandler) GetSyncCommitteeAtState(w http.ResponseWriter, r *http.Request) {
+	ctx := serviceContext(r)
+	requestID := middleware.GetRequestID(ctx)
+
+	value := r.PathValue("state")
+	if value == "" {
+		value = r.URL.Query().Get("state_id")
+	}
+
+	stateID, err := parseStateID(value)
 	if err != nil {
-		h.handleServiceError(w, err, requestID)
+		h.logger.Warn().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("invalid state ID parameter")
+		h.respondError(w, r, http.StatusBadRequest, err)
 		return
 	}
 
-	h.respondJSON(w, http.StatusOK, duties)
+	h.logger.Info().
+		Str("request_id", requestID).
+		Str("state_id", stateID).
+		Msg("processing sync committee state request")
+
+	committee, err := h.service.GetSyncCommitteeAtState(ctx, stateID)
+	if err != nil {
+		h.handleServiceError(w, r, err)
+		return
+	}
+
+	h.respondJSON(w, r, http.StatusOK, committee)
 }
 
-func (h *ValidatorHandler) parseSlotFromPath(path, prefix string) (uint64, error) {
-	if !strings.HasPrefix(path, prefix) {
-		return 0, pkgerrors.NewValidationError("path", path, pkgerrors.ErrInvalidSlot)
+// GetMEVRelays lists the relays and transaction selectors blocks are
+// classified against.
+func (h *ValidatorHandler) GetMEVRelays(w http.ResponseWriter, r *http.Request) {
+	h.respondJSON(w, r, http.StatusOK, h.service.MEVRelays())
+}
+
+// parseSlot parses a slot parameter and rejects slots beyond slotCeiling.
+func (h *ValidatorHandler) parseSlot(ctx context.Context, value string) (uint64, error) {
+	slot, err := parseSlotParam(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if ceiling, ok := h.slotCeiling(ctx); ok && slot > ceiling {
+		return 0, pkgerrors.NewValidationError("slot", slot, pkgerrors.ErrSlotTooFarInFuture)
 	}
 
-	slotStr := strings.TrimPrefix(path, prefix)
-	slotStr = strings.TrimSuffix(slotStr, "/")
+	return slot, nil
+}
+
+// slotCeiling is the highest slot worth asking the service about. It reports
+// false when the bound is disabled or the current slot is unknown, leaving the
+// service's own checks to apply.
+func (h *ValidatorHandler) slotCeiling(ctx context.Context) (uint64, bool) {
+	if h.config.CurrentSlot == nil || h.config.MaxSlotMargin == 0 {
+		return 0, false
+	}
+
+	current, err := h.config.CurrentSlot(ctx)
+	if err != nil {
+		return 0, false
+	}
+
+	if current > math.MaxUint64-h.config.MaxSlotMargin {
+		return math.MaxUint64, true
+	}
+	return current + h.config.MaxSlotMargin, true
+}
+
+func parseSlotParam(value string) (uint64, error) {
+	slotStr := strings.TrimSuffix(value, "/")
 
 	if slotStr == "" {
 		return 0, pkgerrors.NewValidationError("slot", "", pkgerrors.ErrInvalidSlot)
 	}
 
-	slot, err := strconv.ParseUint(slotStr, 10, 64)
+	digits, base := slotStr, 10
+	if strings.HasPrefix(slotStr, "0x") || strings.HasPrefix(slotStr, "0X") {
+		digits, base = slotStr[2:], 16
+	}
+
+	// ParseUint with an explicit base rejects signs, underscores and nested
+	// prefixes such as "0x0x1".
+	slot, err := strconv.ParseUint(digits, base, 64)
 	if err != nil {
-		return 0, pkgerrors.NewValidationError("slot", slotStr, err)
+		return 0, pkgerrors.NewValidationError("slot", slotStr, pkgerrors.ErrInvalidSlot)
 	}
 
 	return slot, nil
 }
 
-func (h *ValidatorHandler) handleServiceError(w http.ResponseWriter, err error, requestID string) {
+func parsePeriodParam(value string) (uint64, error) {
+	periodStr := strings.TrimSuffix(value, "/")
+
+	period, err := strconv.ParseUint(periodStr, 10, 64)
+	if err != nil {
+		return 0, pkgerrors.NewValidationError("period", periodStr, pkgerrors.ErrInvalidPeriod)
+	}
+
+	return period, nil
+}
+
+type pageParams struct {
+	offset uint64
+	limit  int
+}
+
+// parsePageParams reads ?limit= and either ?offset= or ?cursor=. It reports
+// false when none are set so unpaged responses stay unchanged.
+func parsePageParams(r *http.Request) (pageParams, bool, error) {
+	query := r.URL.Query()
+	cursor, offset, limit := query.Get("cursor"), query.Get("offset"), query.Get("limit")
+
+	var page pageParams
+	if cursor == "" && offset == "" && limit == "" {
+		return page, false, nil
+	}
+
+	if cursor != "" && offset != "" {
+		return page, false, pkgerrors.NewValidationError("cursor", cursor, pkgerrors.ErrInvalidPagination)
+	}
+
+	if cursor != "" {
+		decoded, err := pagination.DecodeCursor(cursor)
+		if err != nil {
+			return page, false, pkgerrors.NewValidationError("cursor", cursor, pkgerrors.ErrInvalidCursor)
+		}
+		page.offset = decoded
+	}
+
+	if offset != "" {
+		parsed, err := strconv.ParseUint(offset, 10, 64)
+		if err != nil {
+			return page, false, pkgerrors.NewValidationError("offset", offset, pkgerrors.ErrInvalidPagination)
+		}
+		page.offset = parsed
+	}
+
+	if limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			return page, false, pkgerrors.NewValidationError("limit", limit, pkgerrors.ErrInvalidPagination)
+		}
+		page.limit = parsed
+	}
+
+	return page, true, nil
+}
+
+// serviceContext honours ?nocache=true, which skips cache reads for this
+// request while still caching the fresh result.
+func serviceContext(r *http.Request) context.Context {
+	if queryBool(r, "nocache") {
+		return service.WithCacheBypass(r.Context())
+	}
+	return r.Context()
+}
+
+func queryBool(r *http.Request, name string) bool {
+	value, err := strconv.ParseBool(r.URL.Query().Get(name))
+	return err == nil && value
+}
+
+// includes reports whether value was requested via ?include=a,b or repeated
+// include parameters.
+func includes(r *http.Request, value string) bool {
+	for _, param := range r.URL.Query()["include"] {
+		for _, v := range strings.Split(param, ",") {
+			if strings.TrimSpace(v) == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseRange reads ?from and ?to, an inclusive range of at most maxSize
+// values. A bound that isn't a number fails with invalid, a range that is
+// reversed or too long with invalidRange.
+func parseRange(r *http.Request, maxSize uint64, invalid, invalidRange error) (uint64, uint64, error) {
+	query := r.URL.Query()
+
+	from, err := strconv.ParseUint(query.Get("from"), 10, 64)
+	if err != nil {
+		return 0, 0, pkgerrors.NewValidationError("from", query.Get("from"), invalid)
+	}
+	to, err := strconv.ParseUint(query.Get("to"), 10, 64)
+	if err != nil {
+		return 0, 0, pkgerrors.NewValidationError("to", query.Get("to"), invalid)
+	}
+
+	if to < from || to-from >= maxSize {
+		return 0, 0, pkgerrors.NewValidationError("to", query.Get("to"), invalidRange)
+	}
+	return from, to, nil
+}
+
+func (h *ValidatorHandler) setCacheControl(w http.ResponseWriter, finalized bool) {
+	if finalized && h.config.FinalizedMaxAge > 0 {
+		maxAge := int64(h.config.FinalizedMaxAge / time.Second)
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", maxAge))
+		return
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+}
+
+func (h *ValidatorHandler) handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	status, clientErr := h.classifyServiceError(err, middleware.GetRequestID(r.Context()))
+	h.respondError(w, r, status, clientErr)
+}
+
+// classifyServiceError logs err and returns the status and error to report
+// to the client.
+func (h *ValidatorHandler) classifyServiceError(err error, requestID string) (int, error) {
 	switch {
 	case pkgerrors.IsNotFound(err):
 		h.logger.Info().
 			Str("request_id", requestID).
 			Err(err).
 			Msg("resource not found")
-		h.respondError(w, http.StatusNotFound, err)
+		return http.StatusNotFound, err
 
 	case pkgerrors.IsBadRequest(err):
 		h.logger.Warn().
 			Str("request_id", requestID).
 			Err(err).
 			Msg("bad request")
-		h.respondError(w, http.StatusBadRequest, err)
+		return http.StatusBadRequest, err
 
 	case pkgerrors.IsTimeout(err):
 		h.logger.Error().
 			Str("request_id", requestID).
 			Err(err).
 			Msg("request timeout")
-		h.respondError(w, http.StatusRequestTimeout, err)
+		return http.StatusRequestTimeout, err
+
+	case pkgerrors.IsMalformedUpstream(err):
+		h.logger.Error().
+			Str("request_id", requestID).
+			Err(err).
+			Msg("malformed beacon data")
+		return http.StatusBadGateway, pkgerrors.ErrMalformedUpstream
+
+	case isBeaconHTTPError(err):
+		upstream, _ := pkgerrors.BeaconStatusCode(err)
+		event := h.logger.Warn()
+		if isBeaconAuthStatus(upstream) {
+			event = h.logger.Error()
+		}
+		event.
+			Str("request_id", requestID).
+			Int("upstream_status", upstream).
+			Err(err).
+			Msg("beacon node error")
+		return mapBeaconStatus(upstream)
 
 	default:
 		h.logger.Error().
 			Str("request_id", requestID).
 			Err(err).
 			Msg("internal server error")
-		h.respondError(w, http.StatusInternalServerError, pkgerrors.ErrInternal)
+		return http.StatusInternalServerError, pkgerrors.ErrInternal
 	}
 }
 
-func (h *ValidatorHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+func isBeaconHTTPError(err error) bool {
+	_, ok := pkgerrors.BeaconStatusCode(err)
+	return ok
+}
 
-	response := Response{Data: data}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.Error().Err(err).Msg("failed to encode response")
+// mapBeaconStatus translates an upstream beacon status into the status and
+// error returned to the client. The upstream body is only logged.
+func mapBeaconStatus(upstream int) (int, error) {
+	switch {
+	case upstream == http.StatusServiceUnavailable || upstream == http.StatusTooManyRequests:
+		return http.StatusServiceUnavailable, pkgerrors.ErrBeaconUnavailable
+	case isBeaconAuthStatus(upstream):
+		return http.StatusBadGateway, pkgerrors.ErrBeaconFailure
+	case upstream >= 400 && upstream < 500:
+		return http.StatusBadRequest, pkgerrors.ErrBeaconBadRequest
+	default:
+		return http.StatusBadGateway, pkgerrors.ErrBeaconFailure
 	}
 }
 
-func (h *ValidatorHandler) respondError(w http.ResponseWriter, status int, err error) {
+// isBeaconAuthStatus reports whether the beacon node refused our credentials,
+// which is a server misconfiguration rather than a bad client request.
+func isBeaconAuthStatus(upstream int) bool {
+	return upstream == http.StatusUnauthorized || upstream == http.StatusForbidden
+}
+
+func (h *ValidatorHandler) respondJSON(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	h.writeResponse(w, r, status, Response{Data: data})
+}
+
+func (h *ValidatorHandler) respondError(w http.ResponseWriter, r *http.Request, status int, err error) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
-	response := Response{Error: err.Error()}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	response := Response{Error: err.Error(), RequestID: middleware.GetRequestID(r.Context())}
+	if status == http.StatusNotFound {
+		response.Code = CodeNotFound
+	}
+
+	var validationErr pkgerrors.ValidationError
+	if errors.As(err, &validationErr) {
+		response.Error = validationErr.Err.Error()
+		response.Field = validationErr.Field
+		response.Value = validationErr.Value
+	}
+
+	if err := encodeJSON(w, response, h.config.Defaults.pretty(r)); err != nil {
 		h.logger.Error().Err(err).Msg("failed to encode error response")
 	}
 }
@@ -4,18 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/matheus/eth-validator-api/internal/api/middleware"
+	"github.com/matheus/eth-validator-api/internal/api/router"
 	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/service"
 	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
 	"github.com/matheus/eth-validator-api/pkg/logger"
+	"github.com/matheus/eth-validator-api/pkg/pagination"
 )
 
 type mockValidatorService struct {
@@ -30,14 +38,109 @@ func (m *mockValidatorService) GetBlockReward(ctx context.Context, slot uint64)
 	return args.Get(0).(*domain.BlockReward), args.Error(1)
 }
 
-func (m *mockValidatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint64) (*domain.SyncCommitteeDuties, error) {
-	args := m.Called(ctx, slot)
+func (m *mockValidatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint64, opts service.SyncDutiesOptions) (*domain.SyncCommitteeDuties, error) {
+	args := m.Called(ctx, slot, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SyncCommitteeDuties), args.Error(1)
+}
+
+func (m *mockValidatorService) GetSyncCommitteeByPeriod(ctx context.Context, period uint64) (*domain.SyncCommitteeDuties, error) {
+	args := m.Called(ctx, period)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.SyncCommitteeDuties), args.Error(1)
 }
 
+func (m *mockValidatorService) GetBlockRewardStats(ctx context.Context, from, to uint64) (*domain.BlockRewardStats, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BlockRewardStats), args.Error(1)
+}
+
+func (m *mockValidatorService) GetSyncCommitteesByPeriodRange(ctx context.Context, from, to uint64) ([]*domain.SyncCommitteeDuties, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SyncCommitteeDuties), args.Error(1)
+}
+
+func (m *mockValidatorService) GetEpochProposerRewards(ctx context.Context, epoch uint64) ([]domain.ProposerReward, error) {
+	args := m.Called(ctx, epoch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProposerReward), args.Error(1)
+}
+
+func (m *mockValidatorService) GetBlockHeaderByStateRoot(ctx context.Context, slot uint64, stateRoot string) (*domain.BeaconHeader, error) {
+	args := m.Called(ctx, slot, stateRoot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BeaconHeader), args.Error(1)
+}
+
+func (m *mockValidatorService) MEVRelays() domain.MEVRelays {
+	return m.Called().Get(0).(domain.MEVRelays)
+}
+
+func (m *mockValidatorService) Close() {}
+
+func (m *mockValidatorService) ResolvePubkey(ctx context.Context, index uint64) (string, error) {
+	args := m.Called(ctx, index)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockValidatorService) ResolvePubkeys(ctx context.Context, indices []uint64) (map[uint64]string, error) {
+	args := m.Called(ctx, indices)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint64]string), args.Error(1)
+}
+
+func (m *mockValidatorService) GetSyncCommitteeAtState(ctx context.Context, stateID string) (*domain.SyncCommitteeAtState, error) {
+	args := m.Called(ctx, stateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SyncCommitteeAtState), args.Error(1)
+}
+
+func (m *mockValidatorService) GetSlotStatus(ctx context.Context, slot uint64) (*domain.SlotStatus, error) {
+	args := m.Called(ctx, slot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SlotStatus), args.Error(1)
+}
+
+func (m *mockValidatorService) GetBlock(ctx context.Context, slot uint64) (*domain.Block, error) {
+	args := m.Called(ctx, slot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Block), args.Error(1)
+}
+
+func (m *mockValidatorService) StreamEvents(ctx context.Context, topics []string, fn func(domain.Event)) error {
+	args := m.Called(ctx, topics, fn)
+	return args.Error(0)
+}
+
+// serve dispatches through the router so path parameters are populated.
+func serve(handler *ValidatorHandler, w http.ResponseWriter, req *http.Request) {
+	r := router.New(router.Config{})
+	handler.RegisterRoutes(r)
+	r.ServeHTTP(w, req)
+}
+
 func TestValidatorHandler_GetBlockReward(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -51,15 +154,17 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 			path: "/blockreward/12345",
 			setupMock: func(svc *mockValidatorService) {
 				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
-					Status: "mev",
+					Status: domain.StatusMEV,
 					Reward: big.NewInt(1000000000000000000),
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"data": map[string]interface{}{
-					"status": "mev",
-					"reward": "1000000000000000000",
+					"status":         "mev",
+					"reward":         "1000000000000000000",
+					"unit":           "wei",
+					"proposer_index": float64(0),
 				},
 			},
 		},
@@ -71,6 +176,315 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
 				"error": "invalid slot number",
+				"field": "slot",
+				"value": "invalid",
+			},
+		},
+		{
+			name: "hex slot",
+			path: "/blockreward/0x3039",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusVanilla,
+					Reward: big.NewInt(1),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "1",
+					"unit":           "wei",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name: "reward in gwei",
+			path: "/blockreward/12345?unit=gwei",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusMEV,
+					Reward: big.NewInt(1234567890123456789),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "mev",
+					"reward":         "1234567890",
+					"unit":           "gwei",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name: "reward in ether",
+			path: "/blockreward/12345?unit=ether",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusMEV,
+					Reward: big.NewInt(1234567890123456789),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "mev",
+					"reward":         "1.234567890123456789",
+					"unit":           "ether",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name: "small reward in ether keeps full precision",
+			path: "/blockreward/12345?unit=ether",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusVanilla,
+					Reward: big.NewInt(1500),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "0.0000000000000015",
+					"unit":           "ether",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name: "whole ether",
+			path: "/blockreward/12345?unit=ether",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusVanilla,
+					Reward: big.NewInt(2000000000000000000),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "2",
+					"unit":           "ether",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name: "proposer pubkey",
+			path: "/blockreward/12345?include=proposer_pubkey",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status:        domain.StatusVanilla,
+					Reward:        big.NewInt(1),
+					ProposerIndex: 4242,
+				}, nil)
+				svc.On("ResolvePubkey", mock.Anything, uint64(4242)).Return("0xabcd", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":          "vanilla",
+					"reward":          "1",
+					"unit":            "wei",
+					"proposer_index":  float64(4242),
+					"proposer_pubkey": "0xabcd",
+				},
+			},
+		},
+		{
+			name: "genesis slot",
+			path: "/blockreward/0",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(0)).Return(&domain.BlockReward{
+					Status:    domain.StatusVanilla,
+					Reward:    new(big.Int),
+					Finalized: true,
+					Genesis:   true,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "0",
+					"unit":           "wei",
+					"proposer_index": float64(0),
+					"finalized":      true,
+					"genesis":        true,
+				},
+			},
+		},
+		{
+			name: "execution block",
+			path: "/blockreward/12345?include=execution",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status:               domain.StatusVanilla,
+					Reward:               big.NewInt(1),
+					ExecutionBlockNumber: 19500000,
+					ExecutionBlockHash:   "0x3333",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":                 "vanilla",
+					"reward":                 "1",
+					"unit":                   "wei",
+					"proposer_index":         float64(0),
+					"execution_block_number": float64(19500000),
+					"execution_block_hash":   "0x3333",
+				},
+			},
+		},
+		{
+			name: "execution block not requested",
+			path: "/blockreward/12345",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status:               domain.StatusVanilla,
+					Reward:               big.NewInt(1),
+					ExecutionBlockNumber: 19500000,
+					ExecutionBlockHash:   "0x3333",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "1",
+					"unit":           "wei",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name: "proposer pubkey not found",
+			path: "/blockreward/12345?include=proposer_pubkey",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status:        domain.StatusVanilla,
+					Reward:        big.NewInt(1),
+					ProposerIndex: 4242,
+				}, nil)
+				svc.On("ResolvePubkey", mock.Anything, uint64(4242)).Return("", pkgerrors.ErrValidatorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]interface{}{
+				"error": "validator not found",
+				"code":  "NOT_FOUND",
+			},
+		},
+		{
+			name: "reward breakdown",
+			path: "/blockreward/12345?breakdown=true",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusVanilla,
+					Reward: big.NewInt(1950000000000000),
+					Breakdown: &domain.RewardBreakdown{
+						Attestations:      big.NewInt(300000000000000),
+						SyncAggregate:     big.NewInt(1650000000000000),
+						ProposerSlashings: big.NewInt(0),
+						AttesterSlashings: big.NewInt(0),
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "1950000000000000",
+					"unit":           "wei",
+					"proposer_index": float64(0),
+					"breakdown": map[string]interface{}{
+						"attestations":       "300000000000000",
+						"sync_aggregate":     "1650000000000000",
+						"proposer_slashings": "0",
+						"attester_slashings": "0",
+					},
+				},
+			},
+		},
+		{
+			name: "breakdown omitted by default",
+			path: "/blockreward/12345",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+					Status: domain.StatusVanilla,
+					Reward: big.NewInt(1950000000000000),
+					Breakdown: &domain.RewardBreakdown{
+						Attestations:      big.NewInt(300000000000000),
+						SyncAggregate:     big.NewInt(1650000000000000),
+						ProposerSlashings: big.NewInt(0),
+						AttesterSlashings: big.NewInt(0),
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"status":         "vanilla",
+					"reward":         "1950000000000000",
+					"unit":           "wei",
+					"proposer_index": float64(0),
+				},
+			},
+		},
+		{
+			name:           "invalid unit",
+			path:           "/blockreward/12345?unit=finney",
+			setupMock:      func(svc *mockValidatorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "invalid reward unit",
+				"field": "unit",
+				"value": "finney",
+			},
+		},
+		{
+			name:           "hex prefix without digits",
+			path:           "/blockreward/0x",
+			setupMock:      func(svc *mockValidatorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "invalid slot number",
+			},
+		},
+		{
+			name:           "negative slot",
+			path:           "/blockreward/-1",
+			setupMock:      func(svc *mockValidatorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "invalid slot number",
+			},
+		},
+		{
+			name:           "nested hex prefix",
+			path:           "/blockreward/0x0x3039",
+			setupMock:      func(svc *mockValidatorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "invalid slot number",
+			},
+		},
+		{
+			name: "invalid slot with structured fields",
+			path: "/blockreward/abc",
+			setupMock: func(svc *mockValidatorService) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "invalid slot number",
+				"field": "slot",
+				"value": "abc",
 			},
 		},
 		{
@@ -92,6 +506,7 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			expectedBody: map[string]interface{}{
 				"error": "slot not found",
+				"code":  "NOT_FOUND",
 			},
 		},
 		{
@@ -116,6 +531,66 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 				"error": "internal server error",
 			},
 		},
+		{
+			name: "upstream bad request",
+			path: "/blockreward/12347",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12347)).Return(nil,
+					fmt.Errorf("failed to get block: %w", pkgerrors.BeaconHTTPError{StatusCode: 400, Body: `{"code":400,"message":"Invalid block ID"}`}))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "beacon node rejected the request",
+			},
+		},
+		{
+			name: "upstream rejected credentials",
+			path: "/blockreward/12351",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12351)).Return(nil,
+					fmt.Errorf("failed to get block: %w", pkgerrors.BeaconHTTPError{StatusCode: 403, Body: "forbidden"}))
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody: map[string]interface{}{
+				"error": "beacon node error",
+			},
+		},
+		{
+			name: "upstream internal error",
+			path: "/blockreward/12348",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12348)).Return(nil,
+					fmt.Errorf("failed to get block: %w", pkgerrors.BeaconHTTPError{StatusCode: 500, Body: "boom"}))
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody: map[string]interface{}{
+				"error": "beacon node error",
+			},
+		},
+		{
+			name: "malformed upstream reward",
+			path: "/blockreward/12350",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12350)).Return(nil,
+					fmt.Errorf("failed to parse reward: %w", pkgerrors.UpstreamDataError{Field: "reward", Value: "-1", Reason: "negative"}))
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody: map[string]interface{}{
+				"error": "beacon node returned malformed data",
+			},
+		},
+		{
+			name: "upstream unavailable",
+			path: "/blockreward/12349",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetBlockReward", mock.Anything, uint64(12349)).Return(nil,
+					fmt.Errorf("failed to get block: %w", pkgerrors.BeaconHTTPError{StatusCode: 503, Body: "syncing"}))
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: map[string]interface{}{
+				"error": "beacon node unavailable",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -123,7 +598,7 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 			svc := new(mockValidatorService)
 			log := logger.New("error")
 
-			handler, err := NewValidatorHandler(svc, log)
+			handler, err := NewValidatorHandler(svc, log, HandlerConfig{})
 			assert.NoError(t, err)
 
 			tt.setupMock(svc)
@@ -134,7 +609,7 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 
 			rr := httptest.NewRecorder()
 
-			handler.GetBlockReward(rr, req)
+			serve(handler, rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
 
@@ -148,6 +623,11 @@ func TestValidatorHandler_GetBlockReward(t *testing.T) {
 			if tt.expectedBody["error"] != nil {
 				assert.Equal(t, tt.expectedBody["error"], response["error"])
 			}
+			for _, key := range []string{"code", "field", "value"} {
+				if tt.expectedBody[key] != nil {
+					assert.Equal(t, tt.expectedBody[key], response[key])
+				}
+			}
 
 			svc.AssertExpectations(t)
 		})
@@ -166,23 +646,52 @@ func TestValidatorHandler_GetSyncDuties(t *testing.T) {
 			name: "successful sync duties",
 			path: "/syncduties/12345",
 			setupMock: func(svc *mockValidatorService) {
-				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(12345)).Return(&domain.SyncCommitteeDuties{
-					Validators: []string{"0xvalidator1", "0xvalidator2"},
+				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(12345), service.SyncDutiesOptions{}).Return(&domain.SyncCommitteeDuties{
+					Period:          1,
+					PeriodStartSlot: 8192,
+					PeriodEndSlot:   16383,
+					Validators:      []string{"0xvalidator1", "0xvalidator2"},
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"data": map[string]interface{}{
-					"validators": []interface{}{"0xvalidator1", "0xvalidator2"},
+					"period":            float64(1),
+					"period_start_slot": float64(8192),
+					"period_end_slot":   float64(16383),
+					"validators":        []interface{}{"0xvalidator1", "0xvalidator2"},
 				},
 			},
 		},
 		{
-			name: "invalid slot format",
-			path: "/syncduties/abc",
+			name: "sync duties with next committee",
+			path: "/syncduties/12345?include=next",
 			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(12345), service.SyncDutiesOptions{IncludeNext: true}).Return(&domain.SyncCommitteeDuties{
+					Period:          1,
+					PeriodStartSlot: 8192,
+					PeriodEndSlot:   16383,
+					Validators:      []string{"0xvalidator1"},
+					NextValidators:  []string{"0xvalidator2"},
+				}, nil)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"period":            float64(1),
+					"period_start_slot": float64(8192),
+					"period_end_slot":   float64(16383),
+					"validators":        []interface{}{"0xvalidator1"},
+					"next_validators":   []interface{}{"0xvalidator2"},
+				},
+			},
+		},
+		{
+			name: "invalid slot format",
+			path: "/syncduties/abc",
+			setupMock: func(svc *mockValidatorService) {
+			},
+			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
 				"error": "invalid slot number",
 			},
@@ -191,7 +700,7 @@ func TestValidatorHandler_GetSyncDuties(t *testing.T) {
 			name: "slot not found",
 			path: "/syncduties/99999",
 			setupMock: func(svc *mockValidatorService) {
-				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(99999)).Return(nil, pkgerrors.ErrSlotNotFound)
+				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(99999), service.SyncDutiesOptions{}).Return(nil, pkgerrors.ErrSlotNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: map[string]interface{}{
@@ -202,7 +711,7 @@ func TestValidatorHandler_GetSyncDuties(t *testing.T) {
 			name: "slot too far in future",
 			path: "/syncduties/999999",
 			setupMock: func(svc *mockValidatorService) {
-				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(999999)).Return(nil, pkgerrors.ErrSlotTooFarInFuture)
+				svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(999999), service.SyncDutiesOptions{}).Return(nil, pkgerrors.ErrSlotTooFarInFuture)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
@@ -216,7 +725,7 @@ func TestValidatorHandler_GetSyncDuties(t *testing.T) {
 			svc := new(mockValidatorService)
 			log := logger.New("error")
 
-			handler, err := NewValidatorHandler(svc, log)
+			handler, err := NewValidatorHandler(svc, log, HandlerConfig{})
 			assert.NoError(t, err)
 
 			tt.setupMock(svc)
@@ -227,7 +736,7 @@ func TestValidatorHandler_GetSyncDuties(t *testing.T) {
 
 			rr := httptest.NewRecorder()
 
-			handler.GetSyncDuties(rr, req)
+			serve(handler, rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
 
@@ -247,25 +756,750 @@ func TestValidatorHandler_GetSyncDuties(t *testing.T) {
 	}
 }
 
+func TestValidatorHandler_GetSyncDuties_Pagination(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(12345), service.SyncDutiesOptions{}).Return(&domain.SyncCommitteeDuties{
+		Period:     1,
+		Validators: []string{"0xa", "0xb", "0xc"},
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	get := func(query string) (int, Response) {
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest("GET", "/syncduties/12345"+query, nil))
+
+		var response Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr.Code, response
+	}
+	validators := func(response Response) interface{} {
+		return response.Data.(map[string]interface{})["validators"]
+	}
+
+	status, response := get("")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{"0xa", "0xb", "0xc"}, validators(response))
+	assert.Empty(t, response.NextCursor)
+
+	status, response = get("?limit=2")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{"0xa", "0xb"}, validators(response))
+	require.NotEmpty(t, response.NextCursor)
+
+	status, response = get("?limit=2&cursor=" + response.NextCursor)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{"0xc"}, validators(response))
+	assert.Empty(t, response.NextCursor)
+
+	status, response = get("?offset=1&limit=1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{"0xb"}, validators(response))
+	assert.NotEmpty(t, response.NextCursor)
+
+	invalid := map[string]string{
+		"?cursor=tampered": "cursor",
+		"?offset=1&cursor=" + pagination.EncodeCursor(1): "cursor",
+		"?offset=-1": "offset",
+		"?limit=0":   "limit",
+	}
+	for query, field := range invalid {
+		status, response = get(query)
+		assert.Equal(t, http.StatusBadRequest, status, query)
+		assert.Equal(t, field, response.Field, query)
+	}
+}
+
+func TestValidatorHandler_GetSyncCommitteeByPeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*mockValidatorService)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "valid period",
+			path: "/synccommittee/period/1",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetSyncCommitteeByPeriod", mock.Anything, uint64(1)).Return(&domain.SyncCommitteeDuties{
+					Period:          1,
+					PeriodStartSlot: 8192,
+					PeriodEndSlot:   16383,
+					Validators:      []string{"0xvalidator1"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"data": map[string]interface{}{
+					"period":            float64(1),
+					"period_start_slot": float64(8192),
+					"period_end_slot":   float64(16383),
+					"validators":        []interface{}{"0xvalidator1"},
+				},
+			},
+		},
+		{
+			name:           "invalid period",
+			path:           "/synccommittee/period/0x1",
+			setupMock:      func(svc *mockValidatorService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "invalid sync committee period",
+				"field": "period",
+			},
+		},
+		{
+			name: "period too far in future",
+			path: "/synccommittee/period/1000",
+			setupMock: func(svc *mockValidatorService) {
+				svc.On("GetSyncCommitteeByPeriod", mock.Anything, uint64(1000)).Return(nil, pkgerrors.ErrPeriodTooFar)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "requested sync committee period is beyond the next period",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(mockValidatorService)
+
+			handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+			assert.NoError(t, err)
+
+			tt.setupMock(svc)
+
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest("GET", tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			for key, expected := range tt.expectedBody {
+				assert.Equal(t, expected, response[key], key)
+			}
+
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidatorHandler_GetSlotStatus(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetSlotStatus", mock.Anything, uint64(100)).Return(&domain.SlotStatus{Slot: 100, Proposed: true, Finalized: true}, nil)
+	svc.On("GetSlotStatus", mock.Anything, uint64(101)).Return(&domain.SlotStatus{Slot: 101}, nil)
+	svc.On("GetSlotStatus", mock.Anything, uint64(999999)).Return(nil, pkgerrors.ErrFutureSlot)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{FinalizedMaxAge: time.Hour})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+		expectedCache  string
+	}{
+		{
+			path:           "/slot/100/status",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"slot":100,"proposed":true,"finalized":true}}`,
+			expectedCache:  "public, max-age=3600, immutable",
+		},
+		{
+			path:           "/slot/101/status",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"slot":101,"proposed":false,"finalized":false}}`,
+			expectedCache:  "no-cache",
+		},
+		{
+			path:           "/slot/999999/status",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"requested slot is in the future"}`,
+		},
+		{
+			path:           "/slot/abc/status",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid slot number","field":"slot","value":"abc"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+			if tt.expectedCache != "" {
+				assert.Equal(t, tt.expectedCache, rr.Header().Get("Cache-Control"))
+			}
+		})
+	}
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetBlock(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlock", mock.Anything, uint64(100)).Return(&domain.Block{
+		Slot:          100,
+		ProposerIndex: 7,
+		ParentRoot:    "0x11",
+		StateRoot:     "0x22",
+		Body:          domain.BlockBody{Graffiti: "0x33"},
+		Finalized:     true,
+	}, nil)
+	svc.On("GetBlock", mock.Anything, uint64(101)).Return(nil, pkgerrors.ErrSlotNotFound)
+	svc.On("GetBlock", mock.Anything, uint64(999999)).Return(nil, pkgerrors.ErrFutureSlot)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{FinalizedMaxAge: time.Hour})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{path: "/block/100", expectedStatus: http.StatusOK},
+		{path: "/block/101", expectedStatus: http.StatusNotFound, expectedError: "slot not found"},
+		{path: "/block/999999", expectedStatus: http.StatusBadRequest, expectedError: "requested slot is in the future"},
+		{path: "/block/abc", expectedStatus: http.StatusBadRequest, expectedError: "invalid slot number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, response["error"])
+				return
+			}
+
+			data := response["data"].(map[string]interface{})
+			assert.Equal(t, float64(100), data["slot"])
+			assert.Equal(t, float64(7), data["proposer_index"])
+			assert.Equal(t, "0x11", data["parent_root"])
+			assert.Equal(t, "0x22", data["state_root"])
+			assert.Equal(t, "0x33", data["body"].(map[string]interface{})["graffiti"])
+			assert.Equal(t, "public, max-age=3600, immutable", rr.Header().Get("Cache-Control"))
+		})
+	}
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetBlock_IncludeBlobs(t *testing.T) {
+	blobs := 3
+	svc := new(mockValidatorService)
+	svc.On("GetBlock", mock.Anything, uint64(100)).Return(&domain.Block{Slot: 100, BlobCount: &blobs}, nil)
+	svc.On("GetBlock", mock.Anything, uint64(200)).Return(&domain.Block{Slot: 200}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path      string
+		wantBlobs interface{}
+	}{
+		{path: "/block/100"},
+		{path: "/block/100?include=blobs", wantBlobs: float64(3)},
+		{path: "/block/200?include=blobs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var response map[string]map[string]interface{}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantBlobs, response["data"]["blob_count"])
+		})
+	}
+}
+
+func TestValidatorHandler_GetBlock_IncludeParticipation(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlock", mock.Anything, uint64(100)).Return(&domain.Block{
+		Slot:          100,
+		Participation: &domain.SyncParticipation{Participated: 487, Total: 512, Rate: 0.951171875},
+	}, nil)
+	svc.On("GetBlock", mock.Anything, uint64(200)).Return(&domain.Block{Slot: 200}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{path: "/block/100"},
+		{path: "/block/100?include=participation", want: map[string]interface{}{"participated": float64(487), "total": float64(512), "rate": 0.951171875}},
+		{path: "/block/200?include=participation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var response map[string]map[string]interface{}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.want, response["data"]["participation"])
+		})
+	}
+}
+
+func TestValidatorHandler_GetSyncCommitteeAtState(t *testing.T) {
+	const root = "0xabababababababababababababababababababababababababababababababab"
+
+	svc := new(mockValidatorService)
+	for _, stateID := range []string{"head", "finalized", "8994816", root} {
+		svc.On("GetSyncCommitteeAtState", mock.Anything, stateID).Return(&domain.SyncCommitteeAtState{
+			StateID:    stateID,
+			Validators: []string{"0xvalidator1"},
+		}, nil)
+	}
+	svc.On("GetSyncCommitteeAtState", mock.Anything, "12").Return(nil, pkgerrors.ErrStateNotFound)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedState  string
+	}{
+		{path: "/synccommittee/state/head", expectedStatus: http.StatusOK, expectedState: "head"},
+		{path: "/synccommittee/state/finalized/", expectedStatus: http.StatusOK, expectedState: "finalized"},
+		{path: "/synccommittee/state/8994816", expectedStatus: http.StatusOK, expectedState: "8994816"},
+		{path: "/synccommittee/state/0x" + strings.ToUpper(root[2:]), expectedStatus: http.StatusOK, expectedState: root},
+		{path: "/synccommittee/state/12", expectedStatus: http.StatusNotFound},
+		{path: "/synccommittee/state/latest", expectedStatus: http.StatusBadRequest},
+		{path: "/synccommittee/state/-1", expectedStatus: http.StatusBadRequest},
+		{path: "/synccommittee/state/0xabcd", expectedStatus: http.StatusBadRequest},
+		{path: "/synccommittee/state/0x" + strings.Repeat("zz", 32), expectedStatus: http.StatusBadRequest},
+		{path: "/synccommittee/state/", expectedStatus: http.StatusBadRequest},
+		{path: "/synccommittee/state?state_id=finalized", expectedStatus: http.StatusOK, expectedState: "finalized"},
+		{path: "/synccommittee/state?state_id=latest", expectedStatus: http.StatusBadRequest},
+		{path: "/synccommittee/state", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			var response struct {
+				Data  domain.SyncCommitteeAtState `json:"data"`
+				Field string                      `json:"field"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			switch tt.expectedStatus {
+			case http.StatusOK:
+				assert.Equal(t, tt.expectedState, response.Data.StateID)
+				assert.Equal(t, []string{"0xvalidator1"}, response.Data.Validators)
+			case http.StatusBadRequest:
+				assert.Equal(t, "state", response.Field)
+			}
+		})
+	}
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetBlockReward_CacheControl(t *testing.T) {
+	tests := []struct {
+		name      string
+		finalized bool
+		stale     bool
+		expected  string
+	}{
+		{name: "finalized", finalized: true, expected: "public, max-age=86400, immutable"},
+		{name: "not finalized", finalized: false, expected: "no-cache"},
+		{name: "stale", finalized: true, stale: true, expected: "no-cache"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(mockValidatorService)
+			svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+				Status:    domain.StatusVanilla,
+				Reward:    big.NewInt(1),
+				Finalized: tt.finalized,
+				Stale:     tt.stale,
+			}, nil)
+
+			handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+				FinalizedMaxAge: 24 * time.Hour,
+			})
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest("GET", "/blockreward/12345", nil))
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expected, rr.Header().Get("Cache-Control"))
+			if tt.stale {
+				assert.Equal(t, `110 - "Response is Stale"`, rr.Header().Get("Warning"))
+				assert.Contains(t, rr.Body.String(), `"stale":true`)
+			} else {
+				assert.Empty(t, rr.Header().Get("Warning"))
+			}
+		})
+	}
+}
+
+func TestValidatorHandler_SlotBound(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1050)).Return(nil, pkgerrors.ErrFutureSlot)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+		CurrentSlot:   func(ctx context.Context) (uint64, error) { return 1000, nil },
+		MaxSlotMargin: 100,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "absurd slot",
+			path:     "/blockreward/18446744073709551615",
+			expected: `{"error":"requested slot is too far in the future","field":"slot","value":18446744073709551615}`,
+		},
+		{
+			name:     "just past the margin",
+			path:     "/slot/1101/status",
+			expected: `{"error":"requested slot is too far in the future","field":"slot","value":1101}`,
+		},
+		{
+			// Within the margin the service still decides.
+			name:     "reasonable future slot",
+			path:     "/blockreward/1050",
+			expected: `{"error":"requested slot is in the future"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, tt.expected, rr.Body.String())
+		})
+	}
+
+	t.Run("current slot unknown", func(t *testing.T) {
+		svc := new(mockValidatorService)
+		svc.On("GetBlockReward", mock.Anything, uint64(5000)).Return(nil, pkgerrors.ErrFutureSlot)
+
+		handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+			CurrentSlot:   func(ctx context.Context) (uint64, error) { return 0, errors.New("genesis unavailable") },
+			MaxSlotMargin: 100,
+		})
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, "/blockreward/5000", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		svc.AssertExpectations(t)
+	})
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_SlotContext(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(9936)).Return(&domain.BlockReward{
+		Status:    domain.StatusVanilla,
+		Reward:    big.NewInt(1000),
+		Finalized: true,
+	}, nil)
+	svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(10100), mock.Anything).Return(&domain.SyncCommitteeDuties{
+		Period:     1,
+		Validators: []string{"1", "2"},
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{
+		FinalizedMaxAge: time.Hour,
+		CurrentSlot:     func(ctx context.Context) (uint64, error) { return 10000, nil },
+	})
+	require.NoError(t, err)
+
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) map[string]json.RawMessage {
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("past slot", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, "/blockreward/9936?include=context", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		body := decode(t, rr)
+		assert.JSONEq(t, `{"current_slot":10000,"requested_slot":9936,"slots_behind_head":64,"epoch":310}`, string(body["context"]))
+		assert.JSONEq(t, `{"status":"vanilla","reward":"1000","proposer_index":0,"unit":"wei","finalized":true}`, string(body["data"]))
+		assert.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+	})
+
+	t.Run("future slot", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, "/syncduties/10100?include=next,context", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"current_slot":10000,"requested_slot":10100,"slots_behind_head":-100,"epoch":315}`, string(decode(t, rr)["context"]))
+	})
+
+	t.Run("slots beyond int64", func(t *testing.T) {
+		assert.Equal(t, int64(64), slotsBehind(10000, 9936))
+		assert.Equal(t, int64(-100), slotsBehind(10000, 10100))
+		assert.Equal(t, int64(math.MaxInt64), slotsBehind(math.MaxUint64, 0))
+		assert.Equal(t, int64(-math.MaxInt64), slotsBehind(0, math.MaxUint64))
+		assert.Equal(t, int64(-math.MaxInt64), slotsBehind(10000, math.MaxUint64))
+		assert.Equal(t, int64(1), slotsBehind(math.MaxUint64, math.MaxUint64-1))
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, "/blockreward/9936", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, decode(t, rr), "context")
+		assert.Contains(t, rr.Header().Get("Cache-Control"), "immutable")
+	})
+}
+
+func TestValidatorHandler_ErrorRequestID(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(nil, pkgerrors.ErrSlotNotFound)
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(nil, errors.New("boom"))
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/blockreward/1", expected: `{"error":"slot not found","code":"NOT_FOUND","request_id":"req-1"}`},
+		{path: "/blockreward/2", expected: `{"error":"internal server error","request_id":"req-1"}`},
+		{path: "/blockreward/abc", expected: `{"error":"invalid slot number","field":"slot","value":"abc","request_id":"req-1"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.RequestIDKey, "req-1"))
+
+			rr := httptest.NewRecorder()
+			serve(handler, rr, req)
+
+			assert.JSONEq(t, tt.expected, rr.Body.String())
+		})
+	}
+}
+
+func TestValidatorHandler_GetBlockReward_Numeric(t *testing.T) {
+	large, _ := new(big.Int).SetString("123456789012345678901", 10)
+
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(1)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(45_000_000_000_000_000),
+		Breakdown: &domain.RewardBreakdown{
+			Attestations:      big.NewInt(40_000_000_000_000_000),
+			SyncAggregate:     big.NewInt(5_000_000_000_000_000),
+			ProposerSlashings: big.NewInt(0),
+			AttesterSlashings: big.NewInt(0),
+		},
+	}, nil)
+	svc.On("GetBlockReward", mock.Anything, uint64(2)).Return(&domain.BlockReward{
+		Status: domain.StatusMEV,
+		Reward: large,
+	}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/blockreward/1",
+			expected: `{"data":{"status":"vanilla","proposer_index":0,"reward":"45000000000000000","unit":"wei"}}`,
+		},
+		{
+			// Above 2^53, so a string even though a float64 holds it exactly.
+			path:     "/blockreward/1?numeric=true",
+			expected: `{"data":{"status":"vanilla","proposer_index":0,"reward":"45000000000000000","unit":"wei"}}`,
+		},
+		{
+			path:     "/blockreward/1?numeric=true&unit=gwei",
+			expected: `{"data":{"status":"vanilla","proposer_index":0,"reward":45000000,"unit":"gwei"}}`,
+		},
+		{
+			path:     "/blockreward/1?numeric=true&unit=ether&breakdown=true",
+			expected: `{"data":{"status":"vanilla","proposer_index":0,"reward":0.045,"unit":"ether","breakdown":{"attestations":0.04,"sync_aggregate":0.005,"proposer_slashings":0,"attester_slashings":0}}}`,
+		},
+		{
+			// Past 2^53 a float64 can't hold the value, so it stays a string.
+			path:     "/blockreward/2?numeric=true",
+			expected: `{"data":{"status":"mev","proposer_index":0,"reward":"123456789012345678901","unit":"wei"}}`,
+		},
+		{
+			path:     "/blockreward/2?numeric=true&unit=gwei",
+			expected: `{"data":{"status":"mev","proposer_index":0,"reward":123456789012,"unit":"gwei"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serve(handler, rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, tt.expected, rr.Body.String())
+		})
+	}
+}
+
+func TestValidatorHandler_NoCache(t *testing.T) {
+	bypassed := mock.MatchedBy(func(ctx context.Context) bool { return service.CacheBypassed(ctx) })
+	cached := mock.MatchedBy(func(ctx context.Context) bool { return !service.CacheBypassed(ctx) })
+
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", bypassed, uint64(1)).Return(&domain.BlockReward{Status: domain.StatusVanilla, Reward: big.NewInt(2)}, nil)
+	svc.On("GetBlockReward", cached, uint64(1)).Return(&domain.BlockReward{Status: domain.StatusVanilla, Reward: big.NewInt(1)}, nil)
+	svc.On("GetSyncCommitteeDuties", bypassed, uint64(1), service.SyncDutiesOptions{}).Return(&domain.SyncCommitteeDuties{}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"/blockreward/1?nocache=true":  `"reward":"2"`,
+		"/blockreward/1?nocache=false": `"reward":"1"`,
+		"/blockreward/1":               `"reward":"1"`,
+	} {
+		rr := httptest.NewRecorder()
+		serve(handler, rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rr.Code, path)
+		assert.Contains(t, rr.Body.String(), expected, path)
+	}
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodGet, "/syncduties/1?nocache=true", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// nocache takes precedence over a conditional request: the result is
+	// fetched fresh and returned in full.
+	req := httptest.NewRequest(http.MethodGet, "/blockreward/1?nocache=true", nil)
+	req.Header.Set("If-None-Match", "*")
+	rr = httptest.NewRecorder()
+	serve(handler, rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"reward":"2"`)
+	assert.Empty(t, rr.Header().Get("ETag"))
+
+	svc.AssertExpectations(t)
+}
+
 func TestValidatorHandler_Constructor(t *testing.T) {
 	log := logger.New("error")
 	svc := new(mockValidatorService)
 
 	t.Run("nil service", func(t *testing.T) {
-		_, err := NewValidatorHandler(nil, log)
+		_, err := NewValidatorHandler(nil, log, HandlerConfig{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validator service is required")
 	})
 
 	t.Run("nil logger", func(t *testing.T) {
-		_, err := NewValidatorHandler(svc, nil)
+		_, err := NewValidatorHandler(svc, nil, HandlerConfig{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "logger is required")
 	})
 
 	t.Run("valid construction", func(t *testing.T) {
-		handler, err := NewValidatorHandler(svc, log)
+		handler, err := NewValidatorHandler(svc, log, HandlerConfig{})
 		assert.NoError(t, err)
 		assert.NotNil(t, handler)
 	})
 }
+
+func TestValidatorHandler_Routes(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("GetBlockReward", mock.Anything, uint64(12345)).Return(&domain.BlockReward{
+		Status: domain.StatusVanilla,
+		Reward: big.NewInt(1),
+	}, nil)
+	svc.On("GetSyncCommitteeDuties", mock.Anything, uint64(77), service.SyncDutiesOptions{}).Return(&domain.SyncCommitteeDuties{}, nil)
+	svc.On("GetSyncCommitteeByPeriod", mock.Anything, uint64(3)).Return(&domain.SyncCommitteeDuties{}, nil)
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	r := router.New(router.Config{
+		NotFound:         NewNotFoundHandler(""),
+		MethodNotAllowed: NewMethodNotAllowedHandler(),
+	})
+	handler.RegisterRoutes(r)
+
+	for _, path := range []string{"/blockreward/12345", "/blockreward/0x3039/", "/syncduties/77", "/synccommittee/period/3"} {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rr.Code, path)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/blockreward/12345", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	assert.Equal(t, "GET", rr.Header().Get("Allow"))
+	assert.Contains(t, rr.Body.String(), CodeMethodNotAllowed)
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), CodeNotFound)
+
+	svc.AssertExpectations(t)
+}
+
+func TestValidatorHandler_GetMEVRelays(t *testing.T) {
+	svc := new(mockValidatorService)
+	svc.On("MEVRelays").Return(domain.MEVRelays{
+		Relays:    []string{"0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5"},
+		Selectors: []string{"0xa22cb465"},
+	})
+
+	handler, err := NewValidatorHandler(svc, logger.New("error"), HandlerConfig{})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	serve(handler, rr, httptest.NewRequest(http.MethodGet, "/mev/relays", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":{"relays":["0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5"],"selectors":["0xa22cb465"]}}`, rr.Body.String())
+	svc.AssertExpectations(t)
+}
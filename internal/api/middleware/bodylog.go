@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+// sensitiveField matches JSON string fields whose name suggests a secret.
+// It works on truncated bodies too, since it doesn't need valid JSON.
+var sensitiveField = regexp.MustCompile(`(?i)("[^"]*(?:token|secret|password|passwd|api[_-]?key|authorization|private[_-]?key|credential)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// BodyLogging logs the first maxBytes of every request and response body
+// at debug level, with sensitive JSON fields redacted. The request body is
+// re-buffered so handlers still read all of it. Bodies with a
+// Content-Encoding are not logged, only their encoding.
+func BodyLogging(log logger.Logger, enabled bool, maxBytes int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := GetRequestID(r.Context())
+
+			event := log.Debug().
+				Str("request_id", requestID).
+				Str("method", r.Method).
+				Str("path", r.URL.Path)
+			if encoding := r.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+				event = event.Str("body_encoding", encoding)
+			} else {
+				body, truncated := peekBody(r, maxBytes)
+				event = event.Str("body", redactBody(body)).Bool("truncated", truncated)
+			}
+			event.Msg("request body")
+
+			bw := &bodyLogWriter{ResponseWriter: w, max: maxBytes}
+			next.ServeHTTP(bw, r)
+
+			event = log.Debug().
+				Str("request_id", requestID).
+				Int("status", bw.status)
+			if encoding := bw.encoding; encoding != "" && encoding != "identity" {
+				event = event.Str("body_encoding", encoding)
+			} else {
+				event = event.Str("body", redactBody(bw.body.Bytes())).Bool("truncated", bw.truncated)
+			}
+			event.Msg("response body")
+		})
+	}
+}
+
+// peekBody reads up to maxBytes of the request body for logging and puts
+// them back in front of the rest.
+func peekBody(r *http.Request, maxBytes int) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false
+	}
+
+	prefix, _ := io.ReadAll(io.LimitReader(r.Body, int64(maxBytes)+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), r.Body), r.Body}
+
+	if len(prefix) > maxBytes {
+		return prefix[:maxBytes], true
+	}
+	return prefix, false
+}
+
+func redactBody(body []byte) string {
+	return sensitiveField.ReplaceAllString(string(body), `$1"***"`)
+}
+
+// bodyLogWriter keeps a copy of the first max bytes written. encoding is
+// the Content-Encoding of those bytes, taken before the header reaches the
+// writers below, since Compress adds gzip there to a body captured as
+// plain text.
+type bodyLogWriter struct {
+	http.ResponseWriter
+	max       int
+	body      bytes.Buffer
+	truncated bool
+	status    int
+	encoding  string
+}
+
+func (bw *bodyLogWriter) WriteHeader(code int) {
+	bw.capture(code)
+	bw.ResponseWriter.WriteHeader(code)
+}
+
+func (bw *bodyLogWriter) capture(code int) {
+	if bw.status == 0 {
+		bw.status = code
+		bw.encoding = bw.Header().Get("Content-Encoding")
+	}
+}
+
+func (bw *bodyLogWriter) Write(b []byte) (int, error) {
+	bw.capture(http.StatusOK)
+	if room := bw.max - bw.body.Len(); room > 0 {
+		bw.body.Write(b[:min(room, len(b))])
+		if len(b) > room {
+			bw.truncated = true
+		}
+	} else if len(b) > 0 {
+		bw.truncated = true
+	}
+	return bw.ResponseWriter.Write(b)
+}
+
+func (bw *bodyLogWriter) Unwrap() http.ResponseWriter {
+	return bw.ResponseWriter
+}
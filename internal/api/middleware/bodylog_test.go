@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestBodyLogging(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+
+	t.Run("logs bodies and keeps them readable", func(t *testing.T) {
+		var logs bytes.Buffer
+		handler := RequestID(BodyLogging(logger.NewWithWriter("debug", &logs), true, 1024)(echo))
+
+		body := `{"slots":[1,2],"api_key":"hunter2"}`
+		req := httptest.NewRequest("POST", "/blockrewards", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, body, rr.Body.String())
+
+		output := logs.String()
+		assert.Contains(t, output, "request body")
+		assert.Contains(t, output, "response body")
+		assert.Contains(t, output, `\"slots\":[1,2]`)
+		assert.Contains(t, output, `\"api_key\":\"***\"`)
+		assert.NotContains(t, output, "hunter2")
+		assert.Contains(t, output, rr.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("truncates large bodies", func(t *testing.T) {
+		var logs bytes.Buffer
+		handler := BodyLogging(logger.NewWithWriter("debug", &logs), true, 8)(echo)
+
+		body := strings.Repeat("a", 100)
+		req := httptest.NewRequest("POST", "/blockrewards", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, body, rr.Body.String())
+		assert.Contains(t, logs.String(), `"body":"aaaaaaaa","truncated":true`)
+		assert.NotContains(t, logs.String(), "aaaaaaaaa")
+	})
+
+	t.Run("encoded bodies are not logged", func(t *testing.T) {
+		var logs bytes.Buffer
+		handler := BodyLogging(logger.NewWithWriter("debug", &logs), true, 1024)(echo)
+
+		req := httptest.NewRequest("POST", "/blockrewards", strings.NewReader("compressed"))
+		req.Header.Set("Content-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, "compressed", rr.Body.String())
+		assert.Contains(t, logs.String(), `"body_encoding":"gzip"`)
+	})
+
+	t.Run("logs the encoding the body was captured in", func(t *testing.T) {
+		var logs bytes.Buffer
+		handler := Compress(true)(BodyLogging(logger.NewWithWriter("debug", &logs), true, 1024)(echo))
+
+		req := httptest.NewRequest("POST", "/blockrewards", strings.NewReader(`{"slots":[1]}`))
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		assert.Contains(t, logs.String(), `"status":201,"body":"{\"slots\":[1]}"`)
+		assert.NotContains(t, logs.String(), "body_encoding")
+
+		logs.Reset()
+		precompressed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			w.Write([]byte("brotli bytes"))
+		})
+		handler = Compress(true)(BodyLogging(logger.NewWithWriter("debug", &logs), true, 1024)(precompressed))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Contains(t, logs.String(), `"status":200,"body_encoding":"br"`)
+		assert.NotContains(t, logs.String(), "brotli bytes")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var logs bytes.Buffer
+		handler := BodyLogging(logger.NewWithWriter("debug", &logs), false, 1024)(echo)
+
+		req := httptest.NewRequest("POST", "/blockrewards", strings.NewReader(`{}`))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, `{}`, rr.Body.String())
+		assert.Empty(t, logs.String())
+	})
+}
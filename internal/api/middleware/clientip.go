@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const ClientIPKey contextKey = "client_ip"
+
+// ClientIP resolves each request's client IP once and stores it for
+// GetClientIP, the logs and the per-client limits. X-Forwarded-For, or
+// X-Real-IP without it, is only believed when the request comes from one of
+// the trusted proxies; otherwise anyone could pick their own IP. Without
+// trusted proxies the remote address is always used.
+func ClientIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), ClientIPKey, resolveClientIP(r, trusted))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(ClientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// clientIP returns the IP resolved by ClientIP, or the remote IP when the
+// middleware isn't installed.
+func clientIP(r *http.Request) string {
+	if ip := GetClientIP(r.Context()); ip != "" {
+		return ip
+	}
+	return resolveClientIP(r, nil)
+}
+
+// resolveClientIP walks X-Forwarded-For from the right, skipping trusted
+// proxies, so the first untrusted hop is the client; entries to its left are
+// whatever the client sent. If every hop is trusted the leftmost one is
+// used. An unparsable hop ends the walk at the last trusted one.
+func resolveClientIP(r *http.Request, trusted []netip.Prefix) string {
+	remote, ok := parseIP(r.RemoteAddr)
+	if !ok {
+		return r.RemoteAddr
+	}
+	if !isTrusted(remote, trusted) {
+		return remote.String()
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	if len(hops) == 0 {
+		if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			hops = []string{realIP}
+		}
+	}
+
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseIP(hops[i])
+		if !ok {
+			break
+		}
+		client = hop
+		if !isTrusted(hop, trusted) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// parseIP reads an IP with or without a port, including bracketed IPv6 such
+// as "[::1]:1234" or "[::1]". IPv4-mapped IPv6 addresses become plain IPv4
+// and zones are dropped, so one client always gets the same key.
+func parseIP(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	host, _, err := net.SplitHostPort(value)
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap().WithZone(""), true
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("fd00::/8"),
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		trusted    []netip.Prefix
+		want       string
+	}{
+		{name: "ipv4", remoteAddr: "203.0.113.7:1234", want: "203.0.113.7"},
+		{name: "bracketed ipv6", remoteAddr: "[2001:db8::1]:1234", want: "2001:db8::1"},
+		{name: "bracketed ipv6 without port", remoteAddr: "[2001:db8::1]", want: "2001:db8::1"},
+		{name: "bare ipv6", remoteAddr: "2001:db8::1", want: "2001:db8::1"},
+		{name: "ipv6 with zone", remoteAddr: "[fe80::1%eth0]:1234", want: "fe80::1"},
+		{name: "ipv4-mapped ipv6", remoteAddr: "[::ffff:203.0.113.7]:1234", want: "203.0.113.7"},
+		{name: "ipv4 without port", remoteAddr: "203.0.113.7", want: "203.0.113.7"},
+		{name: "unparsable remote address", remoteAddr: "pipe", want: "pipe"},
+		{
+			name:       "headers ignored without trusted proxies",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.2"},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "headers ignored from untrusted peer",
+			remoteAddr: "203.0.113.7:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			trusted:    trusted,
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "forwarded for from trusted proxy",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			trusted:    trusted,
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "spoofed hops left of the client are ignored",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.1, 10.0.0.2"},
+			trusted:    trusted,
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "every hop trusted",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"},
+			trusted:    trusted,
+			want:       "10.0.0.3",
+		},
+		{
+			name:       "ipv6 proxy and client",
+			remoteAddr: "[fd00::1]:1234",
+			headers:    map[string]string{"X-Forwarded-For": "[2001:db8::5]:4711"},
+			trusted:    trusted,
+			want:       "2001:db8::5",
+		},
+		{
+			name:       "unparsable hop stops at the last trusted one",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1, unknown, 10.0.0.2"},
+			trusted:    trusted,
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "real ip without forwarded for",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.2"},
+			trusted:    trusted,
+			want:       "198.51.100.2",
+		},
+		{
+			name:       "forwarded for wins over real ip",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.2"},
+			trusted:    trusted,
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "no headers from trusted proxy",
+			remoteAddr: "10.0.0.1:1234",
+			trusted:    trusted,
+			want:       "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.Equal(t, tt.want, resolveClientIP(req, tt.trusted))
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	var got string
+	handler := ClientIP([]netip.Prefix{netip.MustParsePrefix("10.0.0.1/32")})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = clientIP(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "198.51.100.1", got)
+
+	t.Run("without the middleware", func(t *testing.T) {
+		assert.Equal(t, "10.0.0.1", clientIP(req))
+	})
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompress(t *testing.T) {
+	body := `{"status":"mev","reward":"1000000000000000000"}`
+
+	serve := func(acceptEncoding string, inner http.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/blockreward/1", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rr := httptest.NewRecorder()
+		Compress(true)(inner).ServeHTTP(rr, req)
+		return rr
+	}
+
+	jsonHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+
+	t.Run("json is compressed", func(t *testing.T) {
+		rr := serve("br, gzip", jsonHandler)
+
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+
+		gz, err := gzip.NewReader(rr.Body)
+		require.NoError(t, err)
+		decoded, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("already encoded response is not re-wrapped", func(t *testing.T) {
+		rr := serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "br")
+			w.Write([]byte("precompressed"))
+		})
+
+		assert.Equal(t, "br", rr.Header().Get("Content-Encoding"))
+		assert.Empty(t, rr.Header().Get("Vary"))
+		assert.Equal(t, "precompressed", rr.Body.String())
+	})
+
+	t.Run("other content types pass through", func(t *testing.T) {
+		rr := serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("event: head\n\n"))
+		})
+
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, "event: head\n\n", rr.Body.String())
+	})
+
+	t.Run("client without gzip", func(t *testing.T) {
+		for _, header := range []string{"", "identity", "gzip;q=0"} {
+			rr := serve(header, jsonHandler)
+			assert.Empty(t, rr.Header().Get("Content-Encoding"), header)
+			assert.Equal(t, body, rr.Body.String(), header)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/blockreward/1", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		Compress(false)(http.HandlerFunc(jsonHandler)).ServeHTTP(rr, req)
+
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	})
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errorRateBuckets is how many slices the window is split into; counts
+// expire one bucket at a time.
+const errorRateBuckets = 60
+
+// ErrorRate counts responses and 5xx errors over a rolling window, kept as
+// time-bucketed counters in a ring.
+type ErrorRate struct {
+	mu      sync.Mutex
+	width   time.Duration
+	buckets [errorRateBuckets]errorBucket
+	now     func() time.Time
+}
+
+type errorBucket struct {
+	// index is the bucket's start time in widths since the epoch; a stale
+	// index means the slot holds counts from an earlier lap.
+	index  int64
+	total  uint64
+	errors uint64
+}
+
+// NewErrorRate returns an ErrorRate over window. A non-positive window
+// defaults to one minute.
+func NewErrorRate(window time.Duration) *ErrorRate {
+	if window <= 0 {
+		window = time.Minute
+	}
+	return &ErrorRate{
+		width: max(window/errorRateBuckets, time.Millisecond),
+		now:   time.Now,
+	}
+}
+
+// Record counts a response with the given status.
+func (e *ErrorRate) Record(status int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	index := e.now().UnixNano() / int64(e.width)
+	bucket := &e.buckets[index%errorRateBuckets]
+	if bucket.index != index {
+		*bucket = errorBucket{index: index}
+	}
+
+	bucket.total++
+	if status >= http.StatusInternalServerError {
+		bucket.errors++
+	}
+}
+
+// Counts returns the 5xx responses and all responses within the window.
+func (e *ErrorRate) Counts() (errors, total uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current := e.now().UnixNano() / int64(e.width)
+	for _, bucket := range e.buckets {
+		if bucket.index > current-errorRateBuckets && bucket.index <= current {
+			errors += bucket.errors
+			total += bucket.total
+		}
+	}
+	return errors, total
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorRate_Window(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rate := NewErrorRate(time.Minute)
+	rate.now = func() time.Time { return now }
+
+	rate.Record(http.StatusOK)
+	rate.Record(http.StatusInternalServerError)
+	rate.Record(http.StatusBadRequest)
+
+	errors, total := rate.Counts()
+	assert.Equal(t, uint64(1), errors)
+	assert.Equal(t, uint64(3), total)
+
+	now = now.Add(30 * time.Second)
+	rate.Record(http.StatusServiceUnavailable)
+
+	errors, total = rate.Counts()
+	assert.Equal(t, uint64(2), errors)
+	assert.Equal(t, uint64(4), total)
+
+	// The first requests age out of the window, the later one doesn't.
+	now = now.Add(45 * time.Second)
+	errors, total = rate.Counts()
+	assert.Equal(t, uint64(1), errors)
+	assert.Equal(t, uint64(1), total)
+
+	// A full lap later the reused buckets don't carry old counts.
+	now = now.Add(2 * time.Minute)
+	rate.Record(http.StatusOK)
+	errors, total = rate.Counts()
+	assert.Equal(t, uint64(0), errors)
+	assert.Equal(t, uint64(1), total)
+}
+
+func TestMetricsWithErrorRate(t *testing.T) {
+	rate := NewErrorRate(time.Minute)
+
+	handler := MetricsWithErrorRate(rate, "/ready")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+
+	for _, target := range []string{"/blockreward/1", "/blockreward/2?fail=1", "/ready?fail=1"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	}
+
+	errors, total := rate.Counts()
+	assert.Equal(t, uint64(1), errors)
+	assert.Equal(t, uint64(2), total)
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+const handlerDoneKey contextKey = "handler_done"
+
+// handlerDone tracks whether the handler serving a request has returned.
+// Timeout answers a request that ran out of time while the handler goroutine
+// is still running, so a limit held for the handler's lifetime can't simply
+// be released when ServeHTTP returns.
+type handlerDone struct {
+	mu       sync.Mutex
+	detached bool
+	finished bool
+	pending  []func()
+}
+
+// releaseWhenDone returns r carrying the request's handlerDone, and a
+// function to defer around next.ServeHTTP in place of release. It runs
+// release straight away unless Timeout gave up on the handler, in which case
+// release runs once the handler goroutine returns.
+func releaseWhenDone(r *http.Request, release func()) (*http.Request, func()) {
+	done, ok := r.Context().Value(handlerDoneKey).(*handlerDone)
+	if !ok {
+		done = &handlerDone{}
+		r = r.WithContext(context.WithValue(r.Context(), handlerDoneKey, done))
+	}
+
+	done.mu.Lock()
+	done.pending = append(done.pending, release)
+	done.mu.Unlock()
+
+	return r, func() {
+		done.mu.Lock()
+		defer done.mu.Unlock()
+
+		if !done.detached {
+			release()
+		}
+	}
+}
+
+func handlerDoneFrom(ctx context.Context) *handlerDone {
+	done, _ := ctx.Value(handlerDoneKey).(*handlerDone)
+	return done
+}
+
+// detach hands the pending releases to the handler goroutine, unless it has
+// already returned.
+func (d *handlerDone) detach() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.finished {
+		d.detached = true
+	}
+}
+
+// finish marks the handler as returned and runs the pending releases if
+// they were handed over by detach.
+func (d *handlerDone) finish() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.finished = true
+	if d.detached {
+		for _, release := range d.pending {
+			release()
+		}
+	}
+}
@@ -2,7 +2,13 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,20 +31,58 @@ var (
 		Name: "http_requests_total",
 		Help: "Total number of HTTP requests.",
 	}, []string{"path", "method", "status"})
+
+	admissionQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "http_admission_queue_depth",
+		Help: "Number of requests waiting for an admission slot.",
+	})
+
+	admissionShed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_requests_shed_total",
+		Help: "Total number of requests rejected with 503 by admission control.",
+	})
 )
 
+// maxRequestIDLength bounds an incoming X-Request-ID; a UUID is 36.
+const maxRequestIDLength = 128
+
 func RequestID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestID := r.Header.Get("X-Request-ID")
-		if requestID == "" {
-			requestID = uuid.New().String()
-		}
+	return RequestIDWithGenerator(func() string { return uuid.New().String() })(next)
+}
 
-		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
-		w.Header().Set("X-Request-ID", requestID)
+// RequestIDWithGenerator is RequestID with a custom generator for requests
+// that arrive without a usable X-Request-ID, e.g. a fixed sequence in tests.
+func RequestIDWithGenerator(generate func() string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if !validRequestID(requestID) {
+				requestID = generate()
+			}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+			ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+			w.Header().Set("X-Request-ID", requestID)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// validRequestID only accepts IDs that are safe to echo into headers and log
+// lines as-is. Anything else is replaced rather than sanitised.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
 }
 
 func Logging(log logger.Logger) func(http.Handler) http.Handler {
@@ -55,6 +99,7 @@ func Logging(log logger.Logger) func(http.Handler) http.Handler {
 				Str("method", r.Method).
 				Str("path", r.URL.Path).
 				Str("remote_addr", r.RemoteAddr).
+				Str("client_ip", clientIP(r)).
 				Msg("request started")
 
 			next.ServeHTTP(wrapped, r)
@@ -73,25 +118,38 @@ func Logging(log logger.Logger) func(http.Handler) http.Handler {
 }
 
 func Metrics(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+	return MetricsWithErrorRate(nil)(next)
+}
+
+// MetricsWithErrorRate is Metrics that also records each response status in
+// rate. Requests to the exempt paths, such as probes whose 503s only mean
+// "not ready", aren't recorded.
+func MetricsWithErrorRate(rate *ErrorRate, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			wrapped := wrapResponseWriter(w)
+			next.ServeHTTP(wrapped, r)
 
-		wrapped := wrapResponseWriter(w)
-		next.ServeHTTP(wrapped, r)
+			duration := time.Since(start).Seconds()
+			status := wrapped.Status()
 
-		duration := time.Since(start).Seconds()
-		status := wrapped.Status()
+			httpDuration.WithLabelValues(r.URL.Path, r.Method, http.StatusText(status)).Observe(duration)
+			httpRequests.WithLabelValues(r.URL.Path, r.Method, http.StatusText(status)).Inc()
 
-		httpDuration.WithLabelValues(r.URL.Path, r.Method, http.StatusText(status)).Observe(duration)
-		httpRequests.WithLabelValues(r.URL.Path, r.Method, http.StatusText(status)).Inc()
-	})
+			if rate != nil && !slices.Contains(exempt, r.URL.Path) {
+				rate.Record(status)
+			}
+		})
+	}
 }
 
 func CORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, X-Request-Timeout")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -102,21 +160,159 @@ func CORS(next http.Handler) http.Handler {
 	})
 }
 
+// InflightLimit rejects a request with 429 while its client already has
+// maxPerClient requests in progress, so one client can't hold every beacon
+// permit. Clients are keyed by the IP resolved by ClientIP, or the remote
+// IP without it. Zero disables the limit.
+func InflightLimit(maxPerClient int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if maxPerClient <= 0 {
+			return next
+		}
+
+		limiter := &inflightLimiter{max: maxPerClient, inflight: make(map[string]int)}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := clientIP(r)
+			if !limiter.acquire(client) {
+				w.Header().Set("Retry-After", "1")
+				writeError(w, r, http.StatusTooManyRequests, errorBody{Error: "too many concurrent requests"})
+				return
+			}
+			// Behind Timeout the handler may outlive this call; the slot is
+			// held until it returns.
+			r, release := releaseWhenDone(r, func() { limiter.release(client) })
+			defer release()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AdmissionControl caps requests in progress across all clients at
+// maxInflight. Up to queueSize more wait at most queueTimeout for a slot;
+// anything beyond that is shed with 503 and Retry-After straight away, so a
+// slow beacon node can't pile up goroutines. Exempt paths (probes, streams)
+// bypass it. Zero maxInflight disables it.
+func AdmissionControl(maxInflight, queueSize int, queueTimeout time.Duration, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if maxInflight <= 0 {
+			return next
+		}
+
+		slots := make(chan struct{}, maxInflight)
+		var queued int64
+
+		shed := func(w http.ResponseWriter, r *http.Request) {
+			admissionShed.Inc()
+			w.Header().Set("Retry-After", "1")
+			writeError(w, r, http.StatusServiceUnavailable, errorBody{Error: "server overloaded"})
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(exempt, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			select {
+			case slots <- struct{}{}:
+			default:
+				if atomic.AddInt64(&queued, 1) > int64(queueSize) {
+					atomic.AddInt64(&queued, -1)
+					shed(w, r)
+					return
+				}
+				admissionQueueDepth.Inc()
+
+				timer := time.NewTimer(queueTimeout)
+				var admitted bool
+				select {
+				case slots <- struct{}{}:
+					admitted = true
+				case <-timer.C:
+				case <-r.Context().Done():
+				}
+				timer.Stop()
+				atomic.AddInt64(&queued, -1)
+				admissionQueueDepth.Dec()
+
+				if !admitted {
+					shed(w, r)
+					return
+				}
+			}
+			// As with InflightLimit, the slot is held until the handler
+			// returns, even after Timeout has answered.
+			r, release := releaseWhenDone(r, func() { <-slots })
+			defer release()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type inflightLimiter struct {
+	mu       sync.Mutex
+	max      int
+	inflight map[string]int
+}
+
+func (l *inflightLimiter) acquire(client string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight[client] >= l.max {
+		return false
+	}
+	l.inflight[client]++
+	return true
+}
+
+func (l *inflightLimiter) release(client string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop idle clients so the map only holds active ones.
+	if l.inflight[client]--; l.inflight[client] <= 0 {
+		delete(l.inflight, client)
+	}
+}
+
+// Recovery turns a panic into a 500. The panic value and stack are logged
+// under a fresh incident ID, which is the only detail returned to the client
+// so it can be quoted in a report.
 func Recovery(log logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
-				if err := recover(); err != nil {
-					requestID := GetRequestID(r.Context())
-
-					log.Error().
-						Str("request_id", requestID).
-						Interface("panic", err).
-						Msg("panic recovered")
+				err := recover()
+				if err == nil {
+					return
+				}
+				// The server uses this sentinel to abort a response on purpose.
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
 
-					w.WriteHeader(http.StatusInternalServerError)
-					w.Write([]byte(`{"error":"internal server error"}`))
+				value, stack := err, debug.Stack()
+				if p, ok := err.(handlerPanic); ok {
+					value, stack = p.value, p.stack
 				}
+
+				incidentID := uuid.New().String()
+
+				log.Error().
+					Str("request_id", GetRequestID(r.Context())).
+					Str("incident_id", incidentID).
+					Str("panic", fmt.Sprint(value)).
+					Str("stack", string(stack)).
+					Msg("panic recovered")
+
+				writeError(w, r, http.StatusInternalServerError, errorBody{
+					Error:      "internal server error",
+					IncidentID: incidentID,
+				})
 			}()
 
 			next.ServeHTTP(w, r)
@@ -124,31 +320,86 @@ func Recovery(log logger.Logger) func(http.Handler) http.Handler {
 	}
 }
 
-func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+// Timeout bounds each request by timeout. Clients may ask for a different
+// value via X-Request-Timeout; it is clamped to maxTimeout and ignored when
+// malformed or non-positive. Requests to the exempt paths, long-lived streams,
+// are passed through unbounded.
+func Timeout(timeout, maxTimeout time.Duration, exempt ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			if slices.Contains(exempt, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), requestTimeout(r, timeout, maxTimeout))
 			defer cancel()
 
 			r = r.WithContext(ctx)
+			handler := handlerDoneFrom(ctx)
 
+			// The handler runs in its own goroutine, out of reach of Recovery,
+			// so a panic is carried back here and re-raised. One after the
+			// timeout response has nowhere to go and is dropped, as
+			// http.TimeoutHandler does.
 			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
 			go func() {
+				defer func() {
+					handler.finish()
+					if p := recover(); p != nil {
+						if p != http.ErrAbortHandler {
+							p = handlerPanic{value: p, stack: debug.Stack()}
+						}
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
 				next.ServeHTTP(w, r)
-				close(done)
 			}()
 
 			select {
+			case p := <-panicked:
+				panic(p)
 			case <-done:
 				return
 			case <-ctx.Done():
-				w.WriteHeader(http.StatusRequestTimeout)
-				w.Write([]byte(`{"error":"request timeout"}`))
+				handler.detach()
+				writeError(w, r, http.StatusRequestTimeout, errorBody{Error: "request timeout"})
 			}
 		})
 	}
 }
 
+// handlerPanic is a panic re-raised by Timeout, with the stack of the
+// goroutine it happened in.
+type handlerPanic struct {
+	value interface{}
+	stack []byte
+}
+
+func (p handlerPanic) String() string {
+	return fmt.Sprint(p.value)
+}
+
+func requestTimeout(r *http.Request, timeout, maxTimeout time.Duration) time.Duration {
+	header := r.Header.Get("X-Request-Timeout")
+	if header == "" {
+		return timeout
+	}
+
+	requested, err := time.ParseDuration(header)
+	if err != nil || requested <= 0 {
+		return timeout
+	}
+
+	if maxTimeout > 0 && requested > maxTimeout {
+		return maxTimeout
+	}
+	return requested
+}
+
 func GetRequestID(ctx context.Context) string {
 	if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
 		return requestID
@@ -167,6 +418,12 @@ func wrapResponseWriter(w http.ResponseWriter) *responseWriter {
 	return &responseWriter{ResponseWriter: w}
 }
 
+// Unwrap lets http.ResponseController reach the connection for flushes and
+// write deadlines.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func (rw *responseWriter) Status() int {
 	return rw.status
 }
@@ -189,3 +446,19 @@ func (rw *responseWriter) Write(buf []byte) (int, error) {
 	rw.written += int64(n)
 	return n, err
 }
+
+// errorBody mirrors the handlers' error envelope for responses written before
+// a request reaches them.
+type errorBody struct {
+	Error      string `json:"error"`
+	RequestID  string `json:"request_id,omitempty"`
+	IncidentID string `json:"incident_id,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, body errorBody) {
+	body.RequestID = GetRequestID(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,348 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestInflightLimit(t *testing.T) {
+	release := make(chan struct{})
+	entered := make(chan struct{}, 10)
+
+	handler := InflightLimit(2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+	}))
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/blockreward/1", nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Two requests from the same IP, on different ports, fill its budget;
+	// another client has a budget of its own.
+	var done sync.WaitGroup
+	for _, addr := range []string{"10.0.0.1:1000", "10.0.0.1:1001", "10.0.0.2:1000"} {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			assert.Equal(t, http.StatusOK, request(addr).Code)
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		<-entered
+	}
+
+	rejected := request("10.0.0.1:1002")
+	assert.Equal(t, http.StatusTooManyRequests, rejected.Code)
+	assert.JSONEq(t, `{"error":"too many concurrent requests"}`, rejected.Body.String())
+	assert.Equal(t, "1", rejected.Header().Get("Retry-After"))
+
+	close(release)
+	done.Wait()
+
+	// Completed requests give their slots back.
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1003").Code)
+}
+
+func TestInflightLimit_HeldUntilHandlerReturns(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+
+	handler := InflightLimit(1)(Timeout(10*time.Millisecond, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			defer close(finished)
+			<-release
+		}
+	})))
+
+	request := func(path string) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		return rr.Code
+	}
+
+	// The client gets its 408, but the handler is still running and keeps
+	// the slot.
+	assert.Equal(t, http.StatusRequestTimeout, request("/slow"))
+	assert.Equal(t, http.StatusTooManyRequests, request("/fast"))
+
+	close(release)
+	<-finished
+	assert.Eventually(t, func() bool {
+		return request("/fast") == http.StatusOK
+	}, time.Second, time.Millisecond)
+}
+
+func TestInflightLimit_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := InflightLimit(0)(next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestAdmissionControl(t *testing.T) {
+	release := make(chan struct{})
+	entered := make(chan struct{}, 10)
+
+	handler := AdmissionControl(2, 1, time.Minute, "/health")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		entered <- struct{}{}
+		<-release
+	}))
+
+	request := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		return rr
+	}
+
+	depthBefore := testutil.ToFloat64(admissionQueueDepth)
+	shedBefore := testutil.ToFloat64(admissionShed)
+
+	// Two requests take both slots and a third waits in the queue.
+	var done sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			assert.Equal(t, http.StatusOK, request("/blockreward/1").Code)
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		<-entered
+	}
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(admissionQueueDepth) == depthBefore+1
+	}, time.Second, time.Millisecond)
+
+	// The queue is full, so the next request is shed straight away.
+	rejected := request("/blockreward/1")
+	assert.Equal(t, http.StatusServiceUnavailable, rejected.Code)
+	assert.JSONEq(t, `{"error":"server overloaded"}`, rejected.Body.String())
+	assert.Equal(t, "1", rejected.Header().Get("Retry-After"))
+	assert.Equal(t, shedBefore+1, testutil.ToFloat64(admissionShed))
+
+	// Exempt paths are still served.
+	assert.Equal(t, http.StatusOK, request("/health").Code)
+
+	close(release)
+	done.Wait()
+
+	assert.Equal(t, depthBefore, testutil.ToFloat64(admissionQueueDepth))
+	assert.Equal(t, http.StatusOK, request("/blockreward/1").Code)
+}
+
+func TestAdmissionControl_QueueTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	entered := make(chan struct{})
+
+	handler := AdmissionControl(1, 1, 10*time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+	}))
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/blockreward/1", nil))
+	<-entered
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/blockreward/2", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestAdmissionControl_HeldUntilHandlerReturns(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+
+	handler := AdmissionControl(1, 0, 0)(Timeout(10*time.Millisecond, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			defer close(finished)
+			<-release
+		}
+	})))
+
+	request := func(path string) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusRequestTimeout, request("/slow"))
+	assert.Equal(t, http.StatusServiceUnavailable, request("/fast"))
+
+	close(release)
+	<-finished
+	assert.Eventually(t, func() bool {
+		return request("/fast") == http.StatusOK
+	}, time.Second, time.Millisecond)
+}
+
+func TestAdmissionControl_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := AdmissionControl(0, 0, 0)(next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	handler := Recovery(logger.NewWithWriter("info", &logs))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reward map[string]int
+		reward["total"]++
+	}))
+
+	req := httptest.NewRequest("GET", "/blockreward/1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), RequestIDKey, "req-1"))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "internal server error", response["error"])
+	assert.Equal(t, "req-1", response["request_id"])
+	_, err := uuid.Parse(response["incident_id"])
+	require.NoError(t, err)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, response["incident_id"], entry["incident_id"])
+	assert.Contains(t, entry["panic"], "assignment to entry in nil map")
+	assert.Contains(t, entry["stack"], "TestRecovery")
+}
+
+func TestRecovery_AbortHandler(t *testing.T) {
+	handler := Recovery(logger.Nop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	})
+}
+
+func TestTimeout_RequestOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+	}{
+		{name: "no header", header: "", expected: 10 * time.Second},
+		{name: "valid override", header: "45s", expected: 45 * time.Second},
+		{name: "over max is clamped", header: "10h", expected: time.Minute},
+		{name: "malformed is ignored", header: "soon", expected: 10 * time.Second},
+		{name: "negative is ignored", header: "-5s", expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			handler := Timeout(10*time.Second, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				assert.True(t, ok)
+				remaining = time.Until(deadline)
+			}))
+
+			req := httptest.NewRequest("GET", "/blockreward/1", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Request-Timeout", tt.header)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.InDelta(t, tt.expected.Seconds(), remaining.Seconds(), 1)
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	next := 0
+	handler := RequestIDWithGenerator(func() string {
+		next++
+		return fmt.Sprintf("test-%d", next)
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(GetRequestID(r.Context())))
+	}))
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "missing", header: "", expected: "test-1"},
+		{name: "valid", header: "abc-123_x.y:z", expected: "abc-123_x.y:z"},
+		{name: "uuid", header: "6f1c5a0e-3b1d-4a8e-9c77-0e2b5d3f4a11", expected: "6f1c5a0e-3b1d-4a8e-9c77-0e2b5d3f4a11"},
+		{name: "newline injection", header: "abc\n{\"level\":\"error\"}", expected: "test-2"},
+		{name: "carriage return", header: "abc\rdef", expected: "test-3"},
+		{name: "spaces", header: "abc def", expected: "test-4"},
+		{name: "non-ascii", header: "abcé", expected: "test-5"},
+		{name: "too long", header: strings.Repeat("a", maxRequestIDLength+1), expected: "test-6"},
+		{name: "max length", header: strings.Repeat("a", maxRequestIDLength), expected: strings.Repeat("a", maxRequestIDLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Request-ID", tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Body.String())
+			assert.Equal(t, tt.expected, rr.Header().Get("X-Request-ID"))
+		})
+	}
+}
+
+func TestRequestID_DefaultGenerator(t *testing.T) {
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+
+	_, err := uuid.Parse(rr.Header().Get("X-Request-ID"))
+	assert.NoError(t, err)
+}
+
+func TestTimeout_Exempt(t *testing.T) {
+	var bounded bool
+	handler := Timeout(10*time.Second, time.Minute, "/events")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, bounded = r.Context().Deadline()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/events?topics=head", nil))
+	assert.False(t, bounded)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/blockreward/1", nil))
+	assert.True(t, bounded)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// headerDisabled as a header value turns that header off.
+const headerDisabled = "off"
+
+// SecurityHeaderConfig holds the values SecurityHeaders sets. Empty or "off"
+// values are left out. HSTS is only sent with a positive HSTSMaxAge, which
+// should only be set when clients reach the API over TLS, e.g. through a
+// terminating proxy.
+type SecurityHeaderConfig struct {
+	ContentTypeOptions string
+	FrameOptions       string
+	ReferrerPolicy     string
+	HSTSMaxAge         time.Duration
+}
+
+// SecurityHeaders sets the configured security headers on every response,
+// including errors written by later middleware.
+func SecurityHeaders(cfg SecurityHeaderConfig) func(http.Handler) http.Handler {
+	headers := map[string]string{}
+	for name, value := range map[string]string{
+		"X-Content-Type-Options": cfg.ContentTypeOptions,
+		"X-Frame-Options":        cfg.FrameOptions,
+		"Referrer-Policy":        cfg.ReferrerPolicy,
+	} {
+		if value != "" && value != headerDisabled {
+			headers[name] = value
+		}
+	}
+	if cfg.HSTSMaxAge > 0 {
+		headers["Strict-Transport-Security"] = "max-age=" + strconv.FormatInt(int64(cfg.HSTSMaxAge/time.Second), 10)
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(headers) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for name, value := range headers {
+				w.Header().Set(name, value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	serve := func(cfg SecurityHeaderConfig) http.Header {
+		rr := httptest.NewRecorder()
+		SecurityHeaders(cfg)(next).ServeHTTP(rr, httptest.NewRequest("GET", "/blockreward/1", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		return rr.Header()
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		header := serve(SecurityHeaderConfig{
+			ContentTypeOptions: "nosniff",
+			FrameOptions:       "DENY",
+			ReferrerPolicy:     "no-referrer",
+		})
+
+		assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", header.Get("X-Frame-Options"))
+		assert.Equal(t, "no-referrer", header.Get("Referrer-Policy"))
+		assert.Empty(t, header.Get("Strict-Transport-Security"))
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+	})
+
+	t.Run("hsts", func(t *testing.T) {
+		header := serve(SecurityHeaderConfig{HSTSMaxAge: 365 * 24 * time.Hour})
+		assert.Equal(t, "max-age=31536000", header.Get("Strict-Transport-Security"))
+	})
+
+	t.Run("disabled individually", func(t *testing.T) {
+		header := serve(SecurityHeaderConfig{
+			ContentTypeOptions: "nosniff",
+			FrameOptions:       "off",
+			ReferrerPolicy:     "",
+		})
+
+		assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+		assert.NotContains(t, header, "X-Frame-Options")
+		assert.NotContains(t, header, "Referrer-Policy")
+	})
+}
@@ -0,0 +1,89 @@
+// Package router hides the HTTP router behind a small interface so the
+// implementation can be swapped without touching handlers.
+package router
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Router registers handlers for a method and a path pattern. Patterns use the
+// net/http ServeMux syntax, e.g. "/blockreward/{slot}"; handlers read
+// parameters with r.PathValue.
+type Router interface {
+	http.Handler
+	Handle(method, pattern string, handler http.Handler)
+	HandleFunc(method, pattern string, handler http.HandlerFunc)
+}
+
+type Config struct {
+	// NotFound serves requests matching no route. Defaults to http.NotFound.
+	NotFound http.Handler
+	// MethodNotAllowed serves requests whose path matches a route registered
+	// for other methods. The Allow header is already set when it runs.
+	MethodNotAllowed http.Handler
+}
+
+type serveMux struct {
+	mux     *http.ServeMux
+	methods map[string]struct{}
+	config  Config
+}
+
+// New returns the default Router, backed by http.ServeMux.
+func New(cfg Config) Router {
+	if cfg.NotFound == nil {
+		cfg.NotFound = http.NotFoundHandler()
+	}
+	if cfg.MethodNotAllowed == nil {
+		cfg.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		})
+	}
+
+	return &serveMux{
+		mux:     http.NewServeMux(),
+		methods: make(map[string]struct{}),
+		config:  cfg,
+	}
+}
+
+func (s *serveMux) Handle(method, pattern string, handler http.Handler) {
+	s.methods[method] = struct{}{}
+	s.mux.Handle(method+" "+pattern, handler)
+}
+
+func (s *serveMux) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	s.Handle(method, pattern, handler)
+}
+
+func (s *serveMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := s.mux.Handler(r); pattern != "" {
+		s.mux.ServeHTTP(w, r)
+		return
+	}
+
+	if allowed := s.allowedMethods(r); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		s.config.MethodNotAllowed.ServeHTTP(w, r)
+		return
+	}
+
+	s.config.NotFound.ServeHTTP(w, r)
+}
+
+// allowedMethods lists the registered methods that have a route for the
+// request path.
+func (s *serveMux) allowedMethods(r *http.Request) []string {
+	var allowed []string
+	for method := range s.methods {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		if _, pattern := s.mux.Handler(probe); pattern != "" {
+			allowed = append(allowed, method)
+		}
+	}
+	sort.Strings(allowed)
+	return allowed
+}
@@ -0,0 +1,73 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter(t *testing.T) {
+	r := New(Config{})
+	r.HandleFunc(http.MethodGet, "/blockreward/{slot}", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, "slot="+req.PathValue("slot"))
+	})
+	r.HandleFunc(http.MethodPost, "/admin/loglevel", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, "post")
+	})
+	r.HandleFunc(http.MethodGet, "/admin/loglevel", func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, "get")
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}{
+		{name: "path parameter", method: http.MethodGet, path: "/blockreward/12345", expectedStatus: http.StatusOK, expectedBody: "slot=12345"},
+		{name: "hex path parameter", method: http.MethodGet, path: "/blockreward/0x3039", expectedStatus: http.StatusOK, expectedBody: "slot=0x3039"},
+		{name: "method matching", method: http.MethodPost, path: "/admin/loglevel", expectedStatus: http.StatusOK, expectedBody: "post"},
+		{name: "wrong method", method: http.MethodDelete, path: "/blockreward/12345", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET"},
+		{name: "wrong method lists all allowed", method: http.MethodPut, path: "/admin/loglevel", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, POST"},
+		{name: "unknown path", method: http.MethodGet, path: "/unknown", expectedStatus: http.StatusNotFound},
+		{name: "missing parameter", method: http.MethodGet, path: "/blockreward/", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rr.Body.String())
+			}
+			assert.Equal(t, tt.expectedAllow, rr.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestRouter_CustomHandlers(t *testing.T) {
+	r := New(Config{
+		NotFound: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		MethodNotAllowed: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			io.WriteString(w, "allow="+w.Header().Get("Allow"))
+		}),
+	})
+	r.HandleFunc(http.MethodGet, "/health", func(w http.ResponseWriter, req *http.Request) {})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusTeapot, rr.Code)
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/health", nil))
+	assert.Equal(t, "allow=GET", rr.Body.String())
+}
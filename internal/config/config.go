@@ -2,6 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v10"
@@ -11,32 +15,233 @@ type Config struct {
 	Port     string `env:"PORT" envDefault:"8080"`
 	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
 
-	Ethereum EthereumConfig
-	Request  RequestConfig
-	Cache    CacheConfig
-	Metrics  MetricsConfig
+	Server    ServerConfig
+	Ethereum  EthereumConfig
+	Transport TransportConfig
+	Request   RequestConfig
+	Cache     CacheConfig
+	Metrics   MetricsConfig
+	MEV       MEVConfig
+	Reward    RewardConfig
+	Batch     BatchConfig
+	Lifecycle LifecycleConfig
+	Routes    RoutesConfig
+}
+
+type ServerConfig struct {
+	NotFoundMessage string `env:"NOT_FOUND_MESSAGE" envDefault:"resource not found"`
+	// AdminEnabled exposes the /admin and /debug endpoints. They require
+	// AdminToken as a bearer token, so enabling them without one is an error.
+	AdminEnabled bool   `env:"ADMIN_ENABLED" envDefault:"false"`
+	AdminToken   string `env:"ADMIN_TOKEN" sensitive:"true"`
+
+	// WriteTimeout has to outlast REQUEST_MAX_TIMEOUT, with room left to
+	// write the response once the request gives up.
+	ReadTimeout  time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"15s"`
+	WriteTimeout time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"2m30s"`
+	IdleTimeout  time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+
+	// MaxInflightPerClient caps concurrent requests per client IP. Zero
+	// disables the limit.
+	MaxInflightPerClient int `env:"MAX_INFLIGHT_PER_CLIENT" envDefault:"0"`
+
+	// MaxInflight caps concurrent requests across all clients; QueueSize
+	// more may wait up to QueueTimeout before being shed. Zero MaxInflight
+	// disables admission control.
+	MaxInflight  int           `env:"MAX_INFLIGHT_REQUESTS" envDefault:"0"`
+	QueueSize    int           `env:"ADMISSION_QUEUE_SIZE" envDefault:"0"`
+	QueueTimeout time.Duration `env:"ADMISSION_QUEUE_TIMEOUT" envDefault:"1s"`
+
+	CompressionEnabled bool `env:"COMPRESSION_ENABLED" envDefault:"false"`
+
+	// DefaultRewardUnit and DefaultPretty apply to requests that don't pass
+	// ?unit or ?pretty.
+	DefaultRewardUnit string `env:"DEFAULT_REWARD_UNIT" envDefault:"wei"`
+	DefaultPretty     bool   `env:"DEFAULT_PRETTY" envDefault:"false"`
+
+	// StrictQueryParams answers 400 to requests with query parameters the
+	// endpoint doesn't recognise, such as a misspelt ?unti=ether.
+	StrictQueryParams bool `env:"STRICT_QUERY_PARAMS" envDefault:"false"`
+
+	// Security headers set on every response; "off" leaves one out.
+	// HSTSMaxAge only belongs behind TLS, so zero disables it.
+	ContentTypeOptions string        `env:"SECURITY_CONTENT_TYPE_OPTIONS" envDefault:"nosniff"`
+	FrameOptions       string        `env:"SECURITY_FRAME_OPTIONS" envDefault:"DENY"`
+	ReferrerPolicy     string        `env:"SECURITY_REFERRER_POLICY" envDefault:"no-referrer"`
+	HSTSMaxAge         time.Duration `env:"SECURITY_HSTS_MAX_AGE" envDefault:"0s"`
+
+	// DebugLogBodies logs the first DebugLogBodyMaxBytes of every request
+	// and response body at debug level. Meant to be enabled temporarily.
+	DebugLogBodies       bool `env:"DEBUG_LOG_BODIES" envDefault:"false"`
+	DebugLogBodyMaxBytes int  `env:"DEBUG_LOG_BODY_MAX_BYTES" envDefault:"1024"`
+
+	// TrustedProxies lists the IPs and CIDRs of reverse proxies whose
+	// X-Forwarded-For and X-Real-IP headers are believed.
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+}
+
+// TrustedProxyPrefixes parses TrustedProxies, reading a bare IP as a
+// single-address prefix.
+func (c ServerConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		ip = ip.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+	return prefixes, nil
 }
 
 type EthereumConfig struct {
-	RPCEndpoint string `env:"ETH_RPC_ENDPOINT" required:"true"`
-	WSEndpoint  string `env:"ETH_WS_ENDPOINT"`
+	RPCEndpoint         string        `env:"ETH_RPC_ENDPOINT,required,notEmpty" sensitive:"true"`
+	WSEndpoint          string        `env:"ETH_WS_ENDPOINT" sensitive:"true"`
+	ReorgWatchEnabled   bool          `env:"REORG_WATCH_ENABLED" envDefault:"false"`
+	ReorgReconnectDelay time.Duration `env:"REORG_RECONNECT_DELAY" envDefault:"5s"`
+	MaxResponseBytes    int64         `env:"MAX_BEACON_RESPONSE_BYTES" envDefault:"52428800"`
+	StrictResponses     bool          `env:"BEACON_STRICT_RESPONSES" envDefault:"false"`
+	MinPeers            uint64        `env:"BEACON_MIN_PEERS" envDefault:"1"`
+	// PrewarmConnections is how many connections to open to the beacon node
+	// at startup. Zero disables it.
+	PrewarmConnections int `env:"BEACON_PREWARM_CONNECTIONS" envDefault:"0"`
+	// LogSampleRate logs one in this many beacon calls: the debug summary
+	// and retries. Failures and slow calls are always logged; zero logs only
+	// those.
+	LogSampleRate int `env:"BEACON_LOG_SAMPLE_RATE" envDefault:"1"`
+	// BeaconMode is "rest" for the beacon REST API or "jsonrpc" for
+	// providers that only expose it through a JSON-RPC gateway.
+	BeaconMode string `env:"BEACON_MODE" envDefault:"rest"`
+	// RecordDir and ReplayDir write every beacon interaction to disk or
+	// serve them back from it, to reproduce issues offline.
+	RecordDir string `env:"BEACON_RECORD_DIR"`
+	ReplayDir string `env:"BEACON_REPLAY_DIR"`
+
+	// Network selects a preset for the chain parameters below; each can
+	// still be set on its own and wins over the preset.
+	Network        string `env:"NETWORK"`
+	SecondsPerSlot uint64 `env:"SECONDS_PER_SLOT"`
+	SlotsPerEpoch  uint64 `env:"SLOTS_PER_EPOCH"`
+	// GenesisTime saves fetching genesis from the beacon node. Zero fetches it.
+	GenesisTime uint64 `env:"GENESIS_TIME"`
+}
+
+type TransportConfig struct {
+	MaxIdleConns          int           `env:"MAX_IDLE_CONNS" envDefault:"100"`
+	MaxIdleConnsPerHost   int           `env:"MAX_IDLE_CONNS_PER_HOST" envDefault:"10"`
+	MaxConnsPerHost       int           `env:"MAX_CONNS_PER_HOST" envDefault:"0"`
+	IdleConnTimeout       time.Duration `env:"IDLE_CONN_TIMEOUT" envDefault:"90s"`
+	ResponseHeaderTimeout time.Duration `env:"RESPONSE_HEADER_TIMEOUT" envDefault:"15s"`
+	TLSHandshakeTimeout   time.Duration `env:"TLS_HANDSHAKE_TIMEOUT" envDefault:"10s"`
 }
 
 type RequestConfig struct {
-	Timeout        time.Duration `env:"REQUEST_TIMEOUT" envDefault:"30s"`
-	MaxRetries     int           `env:"MAX_RETRY_ATTEMPTS" envDefault:"3"`
-	RetryDelay     time.Duration `env:"RETRY_DELAY" envDefault:"1s"`
-	MaxConcurrency int           `env:"MAX_CONCURRENT_REQUESTS" envDefault:"10"`
+	Timeout time.Duration `env:"REQUEST_TIMEOUT" envDefault:"30s"`
+	// BlockTimeout and StateTimeout replace Timeout for beacon block and
+	// state endpoints. Zero keeps Timeout. They can be longer, up to
+	// MaxTimeout, for requests that ask for more with X-Request-Timeout.
+	BlockTimeout         time.Duration `env:"BEACON_TIMEOUT_BLOCK" envDefault:"0s"`
+	StateTimeout         time.Duration `env:"BEACON_TIMEOUT_STATE" envDefault:"0s"`
+	MaxTimeout           time.Duration `env:"REQUEST_MAX_TIMEOUT" envDefault:"2m"`
+	MaxRetries           int           `env:"MAX_RETRY_ATTEMPTS" envDefault:"3"`
+	RetryDelay           time.Duration `env:"RETRY_DELAY" envDefault:"1s"`
+	MaxConcurrency       int           `env:"MAX_CONCURRENT_REQUESTS" envDefault:"10"`
+	SlowRequestThreshold time.Duration `env:"SLOW_REQUEST_THRESHOLD" envDefault:"2s"`
+	MaxSlotMargin        uint64        `env:"MAX_SLOT_MARGIN" envDefault:"16384"`
 }
 
 type CacheConfig struct {
-	TTL     time.Duration `env:"CACHE_TTL" envDefault:"5m"`
-	MaxSize int           `env:"CACHE_MAX_SIZE" envDefault:"1000"`
+	Enabled bool `env:"CACHE_ENABLED" envDefault:"true"`
+	// TTL is also the Cache-Control max-age of finalized responses, so HTTP
+	// caches keep them as long as the API does.
+	TTL             time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+	MaxSize         int           `env:"CACHE_MAX_SIZE" envDefault:"1000"`
+	KeyPrefix       string        `env:"CACHE_KEY_PREFIX"`
+	RefreshWindow   time.Duration `env:"CACHE_REFRESH_WINDOW" envDefault:"0s"`
+	MaxEvictionRate float64       `env:"CACHE_MAX_EVICTION_RATE" envDefault:"10"`
+	// UnfinalizedTTL expires block rewards and slot statuses cached before
+	// finality sooner than TTL. Zero uses TTL for them too.
+	UnfinalizedTTL time.Duration `env:"CACHE_UNFINALIZED_TTL" envDefault:"0s"`
+	// Shards is how many independently locked partitions the cache is
+	// split into, so the expiry sweep doesn't block every lookup.
+	Shards int `env:"CACHE_SHARDS" envDefault:"16"`
+	// Validator pubkeys never change, so they get their own cache whose
+	// entries expire after PubkeyTTL rather than the shared TTL. A zero
+	// PubkeyMaxSize keeps them in the shared cache.
+	PubkeyMaxSize int           `env:"CACHE_PUBKEY_MAX_SIZE" envDefault:"100000"`
+	PubkeyTTL     time.Duration `env:"CACHE_PUBKEY_TTL" envDefault:"24h"`
+	// ServeStale keeps finalized block rewards for StaleTTL in a separate
+	// store and answers from it while the beacon node is unavailable.
+	ServeStale   bool          `env:"CACHE_SERVE_STALE" envDefault:"false"`
+	StaleTTL     time.Duration `env:"CACHE_STALE_TTL" envDefault:"24h"`
+	StaleMaxSize int           `env:"CACHE_STALE_MAX_SIZE" envDefault:"10000"`
+}
+
+type MEVConfig struct {
+	RelayAddresses []string `env:"MEV_RELAY_ADDRESSES" envSeparator:","`
+}
+
+type RewardConfig struct {
+	EstimationEnabled bool `env:"REWARD_ESTIMATION_ENABLED" envDefault:"false"`
+}
+
+type LifecycleConfig struct {
+	// FailFast exits the process when a background component panics more
+	// than MaxComponentPanics times; otherwise it keeps being restarted.
+	FailFast                bool          `env:"FAIL_FAST" envDefault:"false"`
+	MaxComponentPanics      int           `env:"MAX_COMPONENT_PANICS" envDefault:"5"`
+	ComponentRestartBackoff time.Duration `env:"COMPONENT_RESTART_BACKOFF" envDefault:"1s"`
+	// ComponentStableAfter is how long a component must run before its
+	// panic count and backoff start over.
+	ComponentStableAfter time.Duration `env:"COMPONENT_STABLE_AFTER" envDefault:"5m"`
+}
+
+type BatchConfig struct {
+	MaxSlots       int `env:"BATCH_MAX_SLOTS" envDefault:"100"`
+	MaxConcurrency int `env:"BATCH_MAX_CONCURRENCY" envDefault:"8"`
+	// DeadlineMargin is how long before the request timeout a batch with
+	// ?partial=true stops fetching and returns what it has.
+	DeadlineMargin time.Duration `env:"BATCH_DEADLINE_MARGIN" envDefault:"500ms"`
+}
+
+// RoutesConfig turns endpoint groups on and off at startup. Disabled ones
+// aren't registered and answer 404.
+type RoutesConfig struct {
+	// BlockReward covers /blockreward/{slot}, the /blockrewards batch and
+	// /blockrewards/stats.
+	BlockReward   bool `env:"ROUTE_BLOCKREWARD_ENABLED" envDefault:"true"`
+	SlotStatus    bool `env:"ROUTE_SLOTSTATUS_ENABLED" envDefault:"true"`
+	Block         bool `env:"ROUTE_BLOCK_ENABLED" envDefault:"true"`
+	Header        bool `env:"ROUTE_HEADER_ENABLED" envDefault:"true"`
+	SyncDuties    bool `env:"ROUTE_SYNCDUTIES_ENABLED" envDefault:"true"`
+	Proposers     bool `env:"ROUTE_PROPOSERS_ENABLED" envDefault:"true"`
+	SyncCommittee bool `env:"ROUTE_SYNCCOMMITTEE_ENABLED" envDefault:"true"`
+	MEVRelays     bool `env:"ROUTE_MEVRELAYS_ENABLED" envDefault:"true"`
+	Events        bool `env:"ROUTE_EVENTS_ENABLED" envDefault:"true"`
 }
 
 type MetricsConfig struct {
 	Enabled        bool `env:"METRICS_ENABLED" envDefault:"true"`
 	TracingEnabled bool `env:"TRACING_ENABLED" envDefault:"false"`
+
+	// /health is degraded when more than MaxErrorRate of the responses in
+	// the last ErrorRateWindow were 5xx, given at least ErrorRateMinRequests.
+	ErrorRateWindow      time.Duration `env:"ERROR_RATE_WINDOW" envDefault:"1m"`
+	MaxErrorRate         float64       `env:"MAX_ERROR_RATE" envDefault:"0.05"`
+	ErrorRateMinRequests uint64        `env:"ERROR_RATE_MIN_REQUESTS" envDefault:"20"`
 }
 
 func Load() (*Config, error) {
@@ -45,6 +250,10 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	if err := cfg.applyNetwork(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	if err := cfg.validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -56,6 +265,18 @@ func (c *Config) validate() error {
 	if c.Request.Timeout <= 0 {
 		return fmt.Errorf("request timeout must be positive")
 	}
+	if c.Request.MaxTimeout < c.Request.Timeout {
+		return fmt.Errorf("max request timeout cannot be lower than request timeout")
+	}
+	if c.Server.WriteTimeout > 0 && c.Request.MaxTimeout > c.Server.WriteTimeout {
+		return fmt.Errorf("request timeouts cannot be higher than server write timeout")
+	}
+	if c.Request.BlockTimeout < 0 || c.Request.StateTimeout < 0 {
+		return fmt.Errorf("beacon endpoint timeouts cannot be negative")
+	}
+	if c.Request.BlockTimeout > c.Request.MaxTimeout || c.Request.StateTimeout > c.Request.MaxTimeout {
+		return fmt.Errorf("beacon endpoint timeouts cannot be higher than max request timeout")
+	}
 	if c.Request.MaxRetries < 0 {
 		return fmt.Errorf("max retries cannot be negative")
 	}
@@ -65,5 +286,146 @@ func (c *Config) validate() error {
 	if c.Request.MaxConcurrency <= 0 {
 		return fmt.Errorf("max concurrency must be positive")
 	}
+	if c.Transport.MaxIdleConns < 0 || c.Transport.MaxIdleConnsPerHost < 0 || c.Transport.MaxConnsPerHost < 0 {
+		return fmt.Errorf("transport connection limits cannot be negative")
+	}
+	if c.Server.AdminEnabled && c.Server.AdminToken == "" {
+		return fmt.Errorf("admin token is required when admin endpoints are enabled")
+	}
+	if c.Server.MaxInflightPerClient < 0 {
+		return fmt.Errorf("max in-flight requests per client cannot be negative")
+	}
+	if c.Server.MaxInflight < 0 || c.Server.QueueSize < 0 || c.Server.QueueTimeout < 0 {
+		return fmt.Errorf("admission control limits cannot be negative")
+	}
+	if c.Server.HSTSMaxAge < 0 {
+		return fmt.Errorf("HSTS max age cannot be negative")
+	}
+	if c.Server.DebugLogBodyMaxBytes <= 0 {
+		return fmt.Errorf("debug log body max bytes must be positive")
+	}
+	if _, err := c.Server.TrustedProxyPrefixes(); err != nil {
+		return err
+	}
+	if c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		return fmt.Errorf("server timeouts cannot be negative")
+	}
+	if c.Cache.TTL <= 0 {
+		return fmt.Errorf("cache TTL must be positive")
+	}
+	if c.Cache.UnfinalizedTTL < 0 {
+		return fmt.Errorf("unfinalized cache TTL cannot be negative")
+	}
+	if c.Cache.Shards <= 0 {
+		return fmt.Errorf("cache shards must be positive")
+	}
+	if c.Cache.PubkeyMaxSize < 0 {
+		return fmt.Errorf("pubkey cache max size cannot be negative")
+	}
+	if c.Cache.PubkeyTTL <= 0 {
+		return fmt.Errorf("pubkey cache TTL must be positive")
+	}
+	if c.Cache.ServeStale && (c.Cache.StaleTTL <= 0 || c.Cache.StaleMaxSize <= 0) {
+		return fmt.Errorf("stale cache TTL and max size must be positive")
+	}
+	if c.Cache.MaxEvictionRate < 0 {
+		return fmt.Errorf("cache max eviction rate cannot be negative")
+	}
+	if c.Metrics.ErrorRateWindow <= 0 {
+		return fmt.Errorf("error rate window must be positive")
+	}
+	if c.Metrics.MaxErrorRate < 0 || c.Metrics.MaxErrorRate > 1 {
+		return fmt.Errorf("max error rate must be between 0 and 1")
+	}
+	if c.Cache.RefreshWindow < 0 {
+		return fmt.Errorf("cache refresh window cannot be negative")
+	}
+	if c.Batch.MaxSlots <= 0 || c.Batch.MaxConcurrency <= 0 {
+		return fmt.Errorf("batch limits must be positive")
+	}
+	if c.Batch.DeadlineMargin < 0 {
+		return fmt.Errorf("batch deadline margin cannot be negative")
+	}
+	if c.Lifecycle.MaxComponentPanics <= 0 {
+		return fmt.Errorf("max component panics must be positive")
+	}
+	if c.Lifecycle.ComponentRestartBackoff < 0 || c.Lifecycle.ComponentStableAfter < 0 {
+		return fmt.Errorf("component supervision limits cannot be negative")
+	}
+	if c.Ethereum.RecordDir != "" && c.Ethereum.ReplayDir != "" {
+		return fmt.Errorf("beacon record and replay directories cannot both be set")
+	}
+	if c.Ethereum.BeaconMode != "rest" && c.Ethereum.BeaconMode != "jsonrpc" {
+		return fmt.Errorf("beacon mode must be rest or jsonrpc, got %q", c.Ethereum.BeaconMode)
+	}
+	if c.Ethereum.LogSampleRate < 0 {
+		return fmt.Errorf("beacon log sample rate cannot be negative")
+	}
+	if c.Ethereum.PrewarmConnections < 0 {
+		return fmt.Errorf("beacon prewarm connections cannot be negative")
+	}
+	if c.Ethereum.MaxResponseBytes <= 0 {
+		return fmt.Errorf("max beacon response bytes must be positive")
+	}
 	return nil
 }
+
+// Redacted returns a copy of the config that is safe to print, with every
+// field tagged `sensitive:"true"` masked. Endpoint URLs are sensitive because
+// they commonly embed provider API keys in their credentials, path or query.
+func (c *Config) Redacted() Config {
+	redacted := *c
+	redact(reflect.ValueOf(&redacted).Elem())
+	return redacted
+}
+
+func redact(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case !t.Field(i).IsExported():
+		case t.Field(i).Tag.Get("sensitive") == "true":
+			mask(field)
+		case field.Kind() == reflect.Struct:
+			redact(field)
+		}
+	}
+}
+
+// mask keeps the scheme and host of URL-like strings so the output still
+// shows where the process connects to. Slices are rebuilt rather than
+// modified, since the copy shares them with the original config. Any other
+// kind is zeroed.
+func mask(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(maskURL(v.String()))
+	case v.Kind() == reflect.Slice && v.IsNil():
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		masked := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			masked.Index(i).SetString(maskURL(v.Index(i).String()))
+		}
+		v.Set(masked)
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+func maskURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+
+	masked := u.Scheme + "://" + u.Host
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		masked += "/***"
+	}
+	return masked
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/pkg/beaconmath"
+)
+
+// networkPreset holds the chain parameters NETWORK fills in. Fields left
+// zero aren't set by the preset.
+type networkPreset struct {
+	SecondsPerSlot uint64
+	SlotsPerEpoch  uint64
+	GenesisTime    uint64
+	MEVRelays      []string
+}
+
+// mainnetMEVRelays also apply when NETWORK isn't set.
+var mainnetMEVRelays = []string{
+	"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+	"0x388c818ca8b9251b393131c08a736a67ccb19297",
+	"0x8b5d7a6055e54e36e8a6e2a128c5d0f38f4e5e83",
+}
+
+// Testnets have no well-known relay fee recipients, so their lists are empty
+// rather than left to the mainnet default.
+var networkPresets = map[string]networkPreset{
+	"mainnet": {
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		GenesisTime:    1606824023,
+		MEVRelays:      mainnetMEVRelays,
+	},
+	"sepolia": {
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		GenesisTime:    1655733600,
+		MEVRelays:      []string{},
+	},
+	"holesky": {
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		GenesisTime:    1695902400,
+		MEVRelays:      []string{},
+	},
+}
+
+// applyNetwork fills the chain parameters and MEV relays that weren't set
+// explicitly from the NETWORK preset, then from the built-in defaults.
+func (c *Config) applyNetwork() error {
+	eth := &c.Ethereum
+
+	if eth.Network != "" {
+		preset, ok := networkPresets[strings.ToLower(eth.Network)]
+		if !ok {
+			return fmt.Errorf("unknown network %q (known: %s)", eth.Network, strings.Join(knownNetworks(), ", "))
+		}
+
+		if eth.SecondsPerSlot == 0 {
+			eth.SecondsPerSlot = preset.SecondsPerSlot
+		}
+		if eth.SlotsPerEpoch == 0 {
+			eth.SlotsPerEpoch = preset.SlotsPerEpoch
+		}
+		if eth.GenesisTime == 0 {
+			eth.GenesisTime = preset.GenesisTime
+		}
+		if c.MEV.RelayAddresses == nil {
+			c.MEV.RelayAddresses = append([]string{}, preset.MEVRelays...)
+		}
+	}
+
+	if c.MEV.RelayAddresses == nil {
+		c.MEV.RelayAddresses = append([]string{}, mainnetMEVRelays...)
+	}
+	if eth.SecondsPerSlot == 0 {
+		eth.SecondsPerSlot = beaconmath.DefaultSecondsPerSlot
+	}
+	if eth.SlotsPerEpoch == 0 {
+		eth.SlotsPerEpoch = beaconmath.DefaultSlotsPerEpoch
+	}
+	return nil
+}
+
+func knownNetworks() []string {
+	names := make([]string, 0, len(networkPresets))
+	for name := range networkPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
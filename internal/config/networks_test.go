@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_NetworkPresets(t *testing.T) {
+	t.Setenv("ETH_RPC_ENDPOINT", "http://localhost:5052")
+
+	t.Run("no network", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(12), cfg.Ethereum.SecondsPerSlot)
+		assert.Equal(t, uint64(32), cfg.Ethereum.SlotsPerEpoch)
+		assert.Zero(t, cfg.Ethereum.GenesisTime)
+		assert.Contains(t, cfg.MEV.RelayAddresses, "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5")
+	})
+
+	t.Run("mainnet", func(t *testing.T) {
+		t.Setenv("NETWORK", "mainnet")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(12), cfg.Ethereum.SecondsPerSlot)
+		assert.Equal(t, uint64(32), cfg.Ethereum.SlotsPerEpoch)
+		assert.Equal(t, uint64(1606824023), cfg.Ethereum.GenesisTime)
+		assert.Contains(t, cfg.MEV.RelayAddresses, "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5")
+	})
+
+	t.Run("testnet", func(t *testing.T) {
+		t.Setenv("NETWORK", "Holesky")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1695902400), cfg.Ethereum.GenesisTime)
+		assert.Equal(t, uint64(12), cfg.Ethereum.SecondsPerSlot)
+		assert.Empty(t, cfg.MEV.RelayAddresses)
+	})
+
+	t.Run("explicit values win", func(t *testing.T) {
+		t.Setenv("NETWORK", "sepolia")
+		t.Setenv("SECONDS_PER_SLOT", "6")
+		t.Setenv("GENESIS_TIME", "1700000000")
+		t.Setenv("MEV_RELAY_ADDRESSES", "0x388c818ca8b9251b393131c08a736a67ccb19297")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(6), cfg.Ethereum.SecondsPerSlot)
+		assert.Equal(t, uint64(32), cfg.Ethereum.SlotsPerEpoch)
+		assert.Equal(t, uint64(1700000000), cfg.Ethereum.GenesisTime)
+		assert.Equal(t, []string{"0x388c818ca8b9251b393131c08a736a67ccb19297"}, cfg.MEV.RelayAddresses)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		t.Setenv("NETWORK", "goerli")
+
+		_, err := Load()
+		assert.ErrorContains(t, err, `unknown network "goerli"`)
+	})
+}
@@ -2,26 +2,279 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 )
 
 type BlockReward struct {
-	Status string   `json:"status"`
-	Reward *big.Int `json:"-"`
+	Status        BlockStatus `json:"status"`
+	Reward        *big.Int    `json:"-"`
+	ProposerIndex uint64      `json:"proposer_index"`
+	// ProposerPubkey is only resolved on request.
+	ProposerPubkey string `json:"proposer_pubkey,omitempty"`
+	Estimated      bool   `json:"reward_estimated,omitempty"`
+	Finalized      bool   `json:"finalized,omitempty"`
+	// Genesis marks slot 0, whose block comes from the chain's genesis state
+	// rather than a proposer and so has no reward.
+	Genesis bool `json:"genesis,omitempty"`
+	// Optimistic marks data served by an optimistically synced beacon node,
+	// which may still be reverted.
+	Optimistic bool `json:"optimistic,omitempty"`
+	// Stale marks a finalized reward served from an earlier fetch because
+	// the beacon node was unavailable.
+	Stale bool `json:"stale,omitempty"`
+	// ExecutionBlockNumber and ExecutionBlockHash identify the block's
+	// execution payload. Both are empty for blocks without one, such as
+	// pre-merge blocks.
+	ExecutionBlockNumber uint64 `json:"execution_block_number,omitempty"`
+	ExecutionBlockHash   string `json:"execution_block_hash,omitempty"`
+
+	Breakdown *RewardBreakdown `json:"-"`
+
+	// Unit selects how Reward (in wei) is rendered. Empty means wei.
+	Unit RewardUnit `json:"-"`
+	// Numeric renders amounts as JSON numbers instead of strings where that
+	// is lossless; see RewardJSON.
+	Numeric bool `json:"-"`
+}
+
+// Equal reports whether r and other have the same status and reward amount.
+// Presentation fields and metadata such as the proposer are ignored. Two nil
+// rewards are equal, a nil reward never equals a set one, even zero.
+func (r *BlockReward) Equal(other *BlockReward) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if r.Status != other.Status {
+		return false
+	}
+	if r.Reward == nil || other.Reward == nil {
+		return r.Reward == other.Reward
+	}
+	return r.Reward.Cmp(other.Reward) == 0
+}
+
+// MEVRelays is what a block is matched against to classify it as MEV: the
+// relay fee recipients and the transaction selectors.
+type MEVRelays struct {
+	Relays    []string `json:"relays"`
+	Selectors []string `json:"selectors"`
+}
+
+// ProposerReward correlates a scheduled proposal with what the proposer
+// earned. A missed proposal has Proposed false, no Status and a zero reward.
+type ProposerReward struct {
+	Slot           uint64      `json:"slot"`
+	ValidatorIndex uint64      `json:"validator_index"`
+	Pubkey         string      `json:"pubkey"`
+	Proposed       bool        `json:"proposed"`
+	Status         BlockStatus `json:"status,omitempty"`
+	Reward         *big.Int    `json:"-"`
+
+	Unit    RewardUnit `json:"-"`
+	Numeric bool       `json:"-"`
+}
+
+func (p ProposerReward) MarshalJSON() ([]byte, error) {
+	type Alias ProposerReward
+
+	unit := p.Unit
+	if unit == "" {
+		unit = UnitWei
+	}
+
+	return json.Marshal(&struct {
+		*Alias
+		Reward json.RawMessage `json:"reward"`
+		Unit   RewardUnit      `json:"unit"`
+	}{
+		Alias:  (*Alias)(&p),
+		Reward: RewardJSON(p.Reward, unit, p.Numeric),
+		Unit:   unit,
+	})
+}
+
+// BlockRewardStats aggregates the rewards of the blocks proposed from From
+// through To. The reward fields are nil when none was proposed; Mean and an
+// even Median are rounded to the nearest wei.
+type BlockRewardStats struct {
+	From     uint64   `json:"from"`
+	To       uint64   `json:"to"`
+	Proposed int      `json:"proposed"`
+	Missed   int      `json:"missed"`
+	Total    *big.Int `json:"-"`
+	Mean     *big.Int `json:"-"`
+	Median   *big.Int `json:"-"`
+	Min      *big.Int `json:"-"`
+	Max      *big.Int `json:"-"`
+
+	// Stale marks stats that include a reward served stale, as by
+	// BlockReward.Stale.
+	Stale bool `json:"stale,omitempty"`
+
+	Unit    RewardUnit `json:"-"`
+	Numeric bool       `json:"-"`
+}
+
+func (s BlockRewardStats) MarshalJSON() ([]byte, error) {
+	type Alias BlockRewardStats
+
+	unit := s.Unit
+	if unit == "" {
+		unit = UnitWei
+	}
+
+	amount := func(wei *big.Int) json.RawMessage {
+		if wei == nil {
+			return json.RawMessage("null")
+		}
+		return RewardJSON(wei, unit, s.Numeric)
+	}
+
+	return json.Marshal(&struct {
+		*Alias
+		Total  json.RawMessage `json:"total"`
+		Mean   json.RawMessage `json:"mean"`
+		Median json.RawMessage `json:"median"`
+		Min    json.RawMessage `json:"min"`
+		Max    json.RawMessage `json:"max"`
+		Unit   RewardUnit      `json:"unit"`
+	}{
+		Alias:  (*Alias)(&s),
+		Total:  amount(s.Total),
+		Mean:   amount(s.Mean),
+		Median: amount(s.Median),
+		Min:    amount(s.Min),
+		Max:    amount(s.Max),
+		Unit:   unit,
+	})
+}
+
+type RewardBreakdown struct {
+	Attestations      *big.Int
+	SyncAggregate     *big.Int
+	ProposerSlashings *big.Int
+	AttesterSlashings *big.Int
+}
+
+type rewardBreakdownJSON struct {
+	Attestations      json.RawMessage `json:"attestations"`
+	SyncAggregate     json.RawMessage `json:"sync_aggregate"`
+	ProposerSlashings json.RawMessage `json:"proposer_slashings"`
+	AttesterSlashings json.RawMessage `json:"attester_slashings"`
 }
 
 func (b BlockReward) MarshalJSON() ([]byte, error) {
 	type Alias BlockReward
+
+	unit := b.Unit
+	if unit == "" {
+		unit = UnitWei
+	}
+
+	var breakdown *rewardBreakdownJSON
+	if b.Breakdown != nil {
+		breakdown = &rewardBreakdownJSON{
+			Attestations:      RewardJSON(b.Breakdown.Attestations, unit, b.Numeric),
+			SyncAggregate:     RewardJSON(b.Breakdown.SyncAggregate, unit, b.Numeric),
+			ProposerSlashings: RewardJSON(b.Breakdown.ProposerSlashings, unit, b.Numeric),
+			AttesterSlashings: RewardJSON(b.Breakdown.AttesterSlashings, unit, b.Numeric),
+		}
+	}
+
 	return json.Marshal(&struct {
 		*Alias
-		Reward string `json:"reward"`
+		Reward    json.RawMessage      `json:"reward"`
+		Unit      RewardUnit           `json:"unit"`
+		Breakdown *rewardBreakdownJSON `json:"breakdown,omitempty"`
 	}{
-		Alias:  (*Alias)(&b),
-		Reward: b.Reward.String(),
+		Alias:     (*Alias)(&b),
+		Reward:    RewardJSON(b.Reward, unit, b.Numeric),
+		Unit:      unit,
+		Breakdown: breakdown,
 	})
 }
 
+// UnmarshalJSON reverses MarshalJSON, converting the reward and breakdown
+// back to wei from the unit they were rendered in. A missing or empty reward
+// leaves Reward nil.
+func (b *BlockReward) UnmarshalJSON(data []byte) error {
+	type Alias BlockReward
+
+	aux := struct {
+		*Alias
+		Reward    json.RawMessage      `json:"reward"`
+		Unit      RewardUnit           `json:"unit"`
+		Breakdown *rewardBreakdownJSON `json:"breakdown"`
+	}{Alias: (*Alias)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	unit := aux.Unit
+	if unit == "" {
+		unit = UnitWei
+	}
+
+	reward, numeric, err := ParseRewardJSON(aux.Reward, unit)
+	if err != nil {
+		return err
+	}
+	b.Reward, b.Unit, b.Numeric = reward, unit, numeric
+
+	b.Breakdown = nil
+	if aux.Breakdown != nil {
+		breakdown := &RewardBreakdown{}
+		for _, field := range []struct {
+			dst **big.Int
+			raw json.RawMessage
+		}{
+			{&breakdown.Attestations, aux.Breakdown.Attestations},
+			{&breakdown.SyncAggregate, aux.Breakdown.SyncAggregate},
+			{&breakdown.ProposerSlashings, aux.Breakdown.ProposerSlashings},
+			{&breakdown.AttesterSlashings, aux.Breakdown.AttesterSlashings},
+		} {
+			if *field.dst, _, err = ParseRewardJSON(field.raw, unit); err != nil {
+				return fmt.Errorf("breakdown: %w", err)
+			}
+		}
+		b.Breakdown = breakdown
+	}
+
+	return nil
+}
+
 type SyncCommitteeDuties struct {
+	Period          uint64   `json:"period"`
+	PeriodStartSlot uint64   `json:"period_start_slot"`
+	PeriodEndSlot   uint64   `json:"period_end_slot"`
+	Validators      []string `json:"validators"`
+	NextValidators  []string `json:"next_validators,omitempty"`
+}
+
+// SyncCommitteeSummary describes a period's committee by its size and a
+// hash of its members, which changes whenever the membership does. The
+// members themselves are only listed on request.
+type SyncCommitteeSummary struct {
+	Period          uint64   `json:"period"`
+	PeriodStartSlot uint64   `json:"period_start_slot"`
+	PeriodEndSlot   uint64   `json:"period_end_slot"`
+	Size            int      `json:"size"`
+	MembersHash     string   `json:"members_hash"`
+	Validators      []string `json:"validators,omitempty"`
+}
+
+type SlotStatus struct {
+	Slot      uint64 `json:"slot"`
+	Proposed  bool   `json:"proposed"`
+	Finalized bool   `json:"finalized"`
+	// Optimistic marks data served by an optimistically synced beacon node,
+	// which may still be reverted.
+	Optimistic bool `json:"optimistic,omitempty"`
+}
+
+type SyncCommitteeAtState struct {
+	StateID    string   `json:"state_id"`
 	Validators []string `json:"validators"`
 }
 
@@ -32,6 +285,35 @@ type Block struct {
 	StateRoot        string            `json:"state_root"`
 	Body             BlockBody         `json:"body"`
 	ExecutionPayload *ExecutionPayload `json:"execution_payload,omitempty"`
+	Finalized        bool              `json:"finalized"`
+	Optimistic       bool              `json:"optimistic,omitempty"`
+	// BlobCount is the number of blobs the block commits to. It's nil for
+	// blocks from before Deneb, which can't carry blobs.
+	BlobCount *int `json:"blob_count,omitempty"`
+	// Participation is nil for blocks from before Altair, which have no sync
+	// aggregate.
+	Participation *SyncParticipation `json:"participation,omitempty"`
+}
+
+// SyncParticipation is how many sync committee members signed a block's
+// sync aggregate. Total is the committee size, 512 on every supported
+// network.
+type SyncParticipation struct {
+	Participated int     `json:"participated"`
+	Total        int     `json:"total"`
+	Rate         float64 `json:"rate"`
+}
+
+// BeaconHeader is a block header as seen by the beacon node. Canonical is
+// false for a block that lost to a competing fork.
+type BeaconHeader struct {
+	Slot          uint64 `json:"slot"`
+	ProposerIndex uint64 `json:"proposer_index"`
+	Root          string `json:"root"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+	Canonical     bool   `json:"canonical"`
 }
 
 type BlockBody struct {
@@ -160,3 +442,22 @@ type BlockInfo struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 	Finalized           bool   `json:"finalized"`
 }
+
+// Event is a beacon node event relayed to API clients. Data is the upstream
+// payload, or a richer view of it for topics the API understands.
+type Event struct {
+	Topic string
+	Data  interface{}
+}
+
+type HeadEvent struct {
+	Slot                      uint64 `json:"slot"`
+	Block                     string `json:"block"`
+	State                     string `json:"state"`
+	EpochTransition           bool   `json:"epoch_transition"`
+	PreviousDutyDependentRoot string `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string `json:"current_duty_dependent_root"`
+	Optimistic                bool   `json:"optimistic,omitempty"`
+	// Reward is omitted when the block's reward couldn't be fetched in time.
+	Reward *BlockReward `json:"reward,omitempty"`
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockReward_Equal(t *testing.T) {
+	reward := func(status BlockStatus, wei *big.Int) *BlockReward {
+		return &BlockReward{Status: status, Reward: wei}
+	}
+
+	tests := []struct {
+		name     string
+		a, b     *BlockReward
+		expected bool
+	}{
+		{name: "same", a: reward(StatusMEV, big.NewInt(5)), b: reward(StatusMEV, big.NewInt(5)), expected: true},
+		{name: "different amount", a: reward(StatusMEV, big.NewInt(5)), b: reward(StatusMEV, big.NewInt(6))},
+		{name: "different status", a: reward(StatusMEV, big.NewInt(5)), b: reward(StatusVanilla, big.NewInt(5))},
+		{name: "both rewards nil", a: reward(StatusVanilla, nil), b: reward(StatusVanilla, nil), expected: true},
+		{name: "one reward nil", a: reward(StatusVanilla, nil), b: reward(StatusVanilla, big.NewInt(0))},
+		{name: "other reward nil", a: reward(StatusVanilla, big.NewInt(0)), b: reward(StatusVanilla, nil)},
+		{name: "both nil", expected: true},
+		{name: "other nil", a: reward(StatusMEV, big.NewInt(5))},
+		{name: "receiver nil", b: reward(StatusMEV, big.NewInt(5))},
+		{
+			name:     "metadata ignored",
+			a:        &BlockReward{Status: StatusMEV, Reward: big.NewInt(5), ProposerIndex: 1, Unit: UnitGwei},
+			b:        &BlockReward{Status: StatusMEV, Reward: big.NewInt(5), ProposerIndex: 2, Finalized: true},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.a.Equal(tt.b))
+		})
+	}
+}
+
+func TestBlockReward_JSONRoundTrip(t *testing.T) {
+	huge, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+
+	tests := []struct {
+		name   string
+		reward BlockReward
+	}{
+		{name: "wei beyond uint64", reward: BlockReward{Status: StatusMEV, Reward: huge, ProposerIndex: 7, Unit: UnitWei}},
+		{name: "ether", reward: BlockReward{Status: StatusVanilla, Reward: big.NewInt(1234567890123456789), Unit: UnitEther}},
+		{name: "numeric", reward: BlockReward{Status: StatusVanilla, Reward: big.NewInt(42), Unit: UnitWei, Numeric: true}},
+		{
+			name: "breakdown",
+			reward: BlockReward{
+				Status: StatusVanilla,
+				Reward: big.NewInt(10),
+				Unit:   UnitWei,
+				Breakdown: &RewardBreakdown{
+					Attestations:      big.NewInt(6),
+					SyncAggregate:     big.NewInt(4),
+					ProposerSlashings: new(big.Int),
+					AttesterSlashings: new(big.Int),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := json.Marshal(tt.reward)
+			require.NoError(t, err)
+
+			var decoded BlockReward
+			require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+			assert.Equal(t, 0, tt.reward.Reward.Cmp(decoded.Reward), "got %s", decoded.Reward)
+			assert.Equal(t, tt.reward, decoded)
+
+			reencoded, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(encoded), string(reencoded))
+		})
+	}
+}
+
+func TestBlockReward_UnmarshalJSON(t *testing.T) {
+	t.Run("missing or empty reward", func(t *testing.T) {
+		for _, body := range []string{`{"status":"mev"}`, `{"status":"mev","reward":""}`, `{"status":"mev","reward":null}`} {
+			var reward BlockReward
+			require.NoError(t, json.Unmarshal([]byte(body), &reward), body)
+			assert.Nil(t, reward.Reward, body)
+			assert.Equal(t, StatusMEV, reward.Status, body)
+		}
+	})
+
+	t.Run("gwei converts back to wei", func(t *testing.T) {
+		var reward BlockReward
+		require.NoError(t, json.Unmarshal([]byte(`{"reward":"3","unit":"gwei"}`), &reward))
+		assert.Equal(t, big.NewInt(3_000_000_000), reward.Reward)
+	})
+
+	for _, body := range []string{
+		`{"reward":"lots"}`,
+		`{"reward":"1.5"}`,
+		`{"reward":"1","unit":"finney"}`,
+		`{"reward":"1","breakdown":{"attestations":"x"}}`,
+	} {
+		t.Run("invalid "+body, func(t *testing.T) {
+			var reward BlockReward
+			assert.Error(t, json.Unmarshal([]byte(body), &reward))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+type BlockStatus string
+
+const (
+	StatusMEV     BlockStatus = "mev"
+	StatusVanilla BlockStatus = "vanilla"
+)
+
+func (s BlockStatus) IsValid() bool {
+	switch s {
+	case StatusMEV, StatusVanilla:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStatus_IsValid(t *testing.T) {
+	assert.True(t, StatusMEV.IsValid())
+	assert.True(t, StatusVanilla.IsValid())
+
+	for _, status := range []BlockStatus{"", "MEV", "Vanilla", "unknown"} {
+		assert.False(t, status.IsValid(), status)
+	}
+}
+
+func TestBlockStatus_JSON(t *testing.T) {
+	for status, expected := range map[BlockStatus]string{StatusMEV: "mev", StatusVanilla: "vanilla"} {
+		body, err := json.Marshal(BlockReward{Status: status, Reward: big.NewInt(1)})
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		assert.Equal(t, expected, decoded["status"])
+	}
+}
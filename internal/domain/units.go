@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type RewardUnit string
+
+const (
+	UnitWei   RewardUnit = "wei"
+	UnitGwei  RewardUnit = "gwei"
+	UnitEther RewardUnit = "ether"
+)
+
+var (
+	// WeiPerGwei converts gwei amounts, as the beacon node reports them, into
+	// wei. It is shared, so use it only as an operand.
+	WeiPerGwei  = big.NewInt(1_000_000_000)
+	weiPerEther = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+)
+
+func (u RewardUnit) IsValid() bool {
+	switch u {
+	case UnitWei, UnitGwei, UnitEther:
+		return true
+	}
+	return false
+}
+
+// FormatReward renders a wei amount in the given unit. Gwei is truncated to
+// an integer; ether is an exact decimal without trailing zeros.
+func FormatReward(wei *big.Int, unit RewardUnit) string {
+	if wei == nil {
+		return "0"
+	}
+
+	switch unit {
+	case UnitGwei:
+		return new(big.Int).Quo(wei, WeiPerGwei).String()
+	case UnitEther:
+		decimal := new(big.Rat).SetFrac(wei, weiPerEther).FloatString(18)
+		decimal = strings.TrimRight(decimal, "0")
+		return strings.TrimSuffix(decimal, ".")
+	default:
+		return wei.String()
+	}
+}
+
+// maxJSONNumber is the largest magnitude RewardJSON emits as a number. Past
+// 2^53 float64 skips integers, so even an exactly representable amount could
+// be a neighbour's rounding.
+const maxJSONNumber = 1 << 53
+
+// RewardJSON encodes a wei amount in the given unit. Amounts are strings by
+// default because JavaScript parses JSON numbers as float64. With numeric set
+// they become numbers, but only up to 2^53 and when the float64 nearest to
+// the value prints back as the same decimal; larger or more precise amounts
+// stay strings.
+func RewardJSON(wei *big.Int, unit RewardUnit, numeric bool) json.RawMessage {
+	formatted := FormatReward(wei, unit)
+	if numeric {
+		f, err := strconv.ParseFloat(formatted, 64)
+		if err == nil && math.Abs(f) <= maxJSONNumber && strconv.FormatFloat(f, 'f', -1, 64) == formatted {
+			return json.RawMessage(formatted)
+		}
+	}
+	return json.RawMessage(strconv.Quote(formatted))
+}
+
+// ParseRewardJSON reverses RewardJSON, reading an amount in unit, as a JSON
+// string or number, back into wei. Null and empty amounts return nil. Gwei
+// amounts were truncated by RewardJSON, so only wei and ether round-trip
+// exactly.
+func ParseRewardJSON(raw json.RawMessage, unit RewardUnit) (*big.Int, bool, error) {
+	value := strings.TrimSpace(string(raw))
+	if value == "" || value == "null" {
+		return nil, false, nil
+	}
+
+	numeric := !strings.HasPrefix(value, `"`)
+	if !numeric {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, false, fmt.Errorf("invalid reward %s: %w", raw, err)
+		}
+		if value == "" {
+			return nil, false, nil
+		}
+	}
+
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, false, fmt.Errorf("invalid reward %q", value)
+	}
+
+	switch unit {
+	case UnitGwei:
+		amount.Mul(amount, new(big.Rat).SetInt(WeiPerGwei))
+	case UnitEther:
+		amount.Mul(amount, new(big.Rat).SetInt(weiPerEther))
+	case "", UnitWei:
+	default:
+		return nil, false, fmt.Errorf("invalid reward unit %q", unit)
+	}
+
+	if !amount.IsInt() {
+		return nil, false, fmt.Errorf("reward %q is not a whole number of wei", value)
+	}
+	return new(big.Int).Set(amount.Num()), numeric, nil
+}
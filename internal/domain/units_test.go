@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardJSON(t *testing.T) {
+	maxSafe := big.NewInt(1<<53 - 1)
+	unsafe, _ := new(big.Int).SetString("9007199254740993", 10)
+	limit := big.NewInt(1 << 53)
+	aboveLimit := big.NewInt(1<<53 + 2)
+	negativeAboveLimit := big.NewInt(-(1<<53 + 2))
+
+	tests := []struct {
+		name     string
+		wei      *big.Int
+		unit     RewardUnit
+		numeric  bool
+		expected string
+	}{
+		{name: "string by default", wei: big.NewInt(42), unit: UnitWei, expected: `"42"`},
+		{name: "numeric", wei: big.NewInt(42), unit: UnitWei, numeric: true, expected: `42`},
+		{name: "nil", wei: nil, unit: UnitWei, numeric: true, expected: `0`},
+		{name: "max safe integer", wei: maxSafe, unit: UnitWei, numeric: true, expected: `9007199254740991`},
+		{name: "at the limit", wei: limit, unit: UnitWei, numeric: true, expected: `9007199254740992`},
+		{name: "representable above the limit", wei: aboveLimit, unit: UnitWei, numeric: true, expected: `"9007199254740994"`},
+		{name: "negative above the limit", wei: negativeAboveLimit, unit: UnitWei, numeric: true, expected: `"-9007199254740994"`},
+		{name: "not representable", wei: unsafe, unit: UnitWei, numeric: true, expected: `"9007199254740993"`},
+		{name: "ether decimal", wei: big.NewInt(45_000_000_000_000_000), unit: UnitEther, numeric: true, expected: `0.045`},
+		{name: "ether too precise", wei: big.NewInt(123_456_789_012_345_678), unit: UnitEther, numeric: true, expected: `"0.123456789012345678"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, string(RewardJSON(tt.wei, tt.unit, tt.numeric)))
+		})
+	}
+}
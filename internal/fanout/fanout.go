@@ -0,0 +1,85 @@
+// Package fanout runs work over a list of items with bounded concurrency.
+package fanout
+
+import (
+	"context"
+	"sync"
+)
+
+// Result holds the outcome for a single item.
+type Result[R any] struct {
+	Value R
+	Err   error
+}
+
+// MapConcurrent calls fn for every item with at most maxConcurrency calls in
+// flight, or all at once when maxConcurrency is not positive. Results are in
+// item order and a failing item doesn't stop the others.
+//
+// Once ctx is cancelled no further calls are started: the remaining items get
+// ctx.Err() as their error and MapConcurrent returns ctx.Err() after the calls
+// already running have returned.
+func MapConcurrent[T, R any](ctx context.Context, items []T, maxConcurrency int, fn func(context.Context, T) (R, error)) ([]Result[R], error) {
+	results := make([]Result[R], len(items))
+	if maxConcurrency <= 0 || maxConcurrency > len(items) {
+		maxConcurrency = len(items)
+	}
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		if !acquire(ctx, sem) {
+			for j := i; j < len(items); j++ {
+				results[j].Err = ctx.Err()
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			value, err := fn(ctx, item)
+			results[i] = Result[R]{Value: value, Err: err}
+		}()
+	}
+
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+// acquire takes a slot unless ctx is done. The context is checked first
+// because select picks randomly when both cases are ready.
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// MapRange calls fn for every number from through to, inclusive, as
+// MapConcurrent does; results[i] belongs to from+i. An empty range, with from
+// after to, returns no results.
+func MapRange[R any](ctx context.Context, from, to uint64, maxConcurrency int, fn func(context.Context, uint64) (R, error)) ([]Result[R], error) {
+	var items []uint64
+	if from <= to {
+		items = make([]uint64, 0, to-from+1)
+		// Counted this way so a range ending at math.MaxUint64 terminates.
+		for n := from; ; n++ {
+			items = append(items, n)
+			if n == to {
+				break
+			}
+		}
+	}
+
+	return MapConcurrent(ctx, items, maxConcurrency, fn)
+}
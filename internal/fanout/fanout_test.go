@@ -0,0 +1,153 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapConcurrent_PreservesOrder(t *testing.T) {
+	items := []int{5, 4, 3, 2, 1}
+
+	results, err := MapConcurrent(context.Background(), items, 2, func(ctx context.Context, n int) (int, error) {
+		// Later items finish first.
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10, nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, len(items))
+	for i, n := range items {
+		assert.Equal(t, n*10, results[i].Value)
+		assert.NoError(t, results[i].Err)
+	}
+}
+
+func TestMapConcurrent_PerItemErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+
+	results, err := MapConcurrent(context.Background(), []int{1, 2, 3, 4}, 4, func(ctx context.Context, n int) (int, error) {
+		if n%2 == 1 {
+			return 0, errOdd
+		}
+		return n, nil
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, results[0].Err, errOdd)
+	assert.Equal(t, 2, results[1].Value)
+	assert.ErrorIs(t, results[2].Err, errOdd)
+	assert.Equal(t, 4, results[3].Value)
+}
+
+func TestMapConcurrent_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	items := make([]int, 20)
+	_, err := MapConcurrent(context.Background(), items, 3, func(ctx context.Context, _ int) (struct{}, error) {
+		current := inFlight.Add(1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		inFlight.Add(-1)
+		return struct{}{}, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), peak.Load())
+}
+
+func TestMapConcurrent_Unbounded(t *testing.T) {
+	release := make(chan struct{})
+	var started atomic.Int32
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = MapConcurrent(context.Background(), make([]int, 10), 0, func(ctx context.Context, _ int) (int, error) {
+			started.Add(1)
+			<-release
+			return 0, nil
+		})
+	}()
+
+	assert.Eventually(t, func() bool { return started.Load() == 10 }, time.Second, time.Millisecond)
+	close(release)
+	<-done
+}
+
+func TestMapConcurrent_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+
+	results, err := MapConcurrent(ctx, make([]int, 10), 1, func(ctx context.Context, _ int) (int, error) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		return 1, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(2), calls.Load())
+
+	require.Len(t, results, 10)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	for _, result := range results[2:] {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestMapConcurrent_AlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := MapConcurrent(ctx, []int{1, 2}, 2, func(ctx context.Context, n int) (int, error) {
+		t.Fatal("fn must not be called")
+		return 0, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestMapConcurrent_Empty(t *testing.T) {
+	results, err := MapConcurrent(context.Background(), nil, 4, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestMapRange(t *testing.T) {
+	double := func(ctx context.Context, n uint64) (uint64, error) {
+		return n * 2, nil
+	}
+
+	results, err := MapRange(context.Background(), 3, 5, 2, double)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, (3+uint64(i))*2, result.Value)
+	}
+
+	results, err = MapRange(context.Background(), math.MaxUint64, math.MaxUint64, 2, double)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	results, err = MapRange(context.Background(), 5, 3, 2, double)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,226 @@
+// Package lifecycle starts the process's background components and stops
+// them together on shutdown.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+const (
+	defaultMaxPanics      = 5
+	defaultRestartBackoff = time.Second
+	defaultStableAfter    = 5 * time.Minute
+	maxRestartBackoff     = time.Minute
+)
+
+type Config struct {
+	// MaxPanics is how many panics a component may have before it's
+	// escalated. Zero means defaultMaxPanics.
+	MaxPanics int
+	// RestartBackoff is the pause before a panicked component is restarted.
+	// It doubles with every further panic, up to maxRestartBackoff. Zero
+	// means defaultRestartBackoff.
+	RestartBackoff time.Duration
+	// StableAfter is how long a run must last for the component to count
+	// as healthy again: a panic after that starts a new count at one panic
+	// and the initial backoff. Zero means defaultStableAfter.
+	StableAfter time.Duration
+	// FailFast exits the process once a component exceeds MaxPanics, so an
+	// orchestrator can restart it, instead of restarting the component.
+	FailFast bool
+}
+
+type closer struct {
+	name string
+	fn   func()
+}
+
+// Manager owns the background goroutines. Goroutines started with Go share a
+// context that Shutdown cancels; closers registered with OnShutdown run once
+// they've all returned.
+type Manager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger logger.Logger
+	config Config
+	exit   func(code int)
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]int
+	closers []closer
+}
+
+func New(logger logger.Logger, cfg Config) (*Manager, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	if cfg.MaxPanics <= 0 {
+		cfg.MaxPanics = defaultMaxPanics
+	}
+	if cfg.RestartBackoff <= 0 {
+		cfg.RestartBackoff = defaultRestartBackoff
+	}
+	if cfg.StableAfter <= 0 {
+		cfg.StableAfter = defaultStableAfter
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ctx:     ctx,
+		cancel:  cancel,
+		logger:  logger,
+		config:  cfg,
+		exit:    os.Exit,
+		running: make(map[string]int),
+	}, nil
+}
+
+// Go runs fn in its own goroutine until the context passed to it is done. A
+// panicking fn is restarted with backoff; see Config for the escalation.
+func (m *Manager) Go(name string, fn func(ctx context.Context)) {
+	m.mu.Lock()
+	m.running[name]++
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.supervise(name, fn)
+
+		m.mu.Lock()
+		m.running[name]--
+		if m.running[name] == 0 {
+			delete(m.running, name)
+		}
+		m.mu.Unlock()
+
+		m.logger.Info().Str("component", name).Msg("component stopped")
+	}()
+}
+
+// supervise runs fn until it returns without panicking, the manager shuts
+// down or, with FailFast, it has panicked more than MaxPanics times in a row
+// without a run lasting StableAfter.
+func (m *Manager) supervise(name string, fn func(ctx context.Context)) {
+	backoff := m.config.RestartBackoff
+	panics := 0
+
+	for {
+		start := time.Now()
+		if !m.runRecovered(name, fn) {
+			return
+		}
+		componentPanics.WithLabelValues(name).Inc()
+
+		if time.Since(start) >= m.config.StableAfter {
+			panics, backoff = 0, m.config.RestartBackoff
+		}
+		panics++
+
+		if panics > m.config.MaxPanics {
+			if m.config.FailFast {
+				m.logger.Error().
+					Str("component", name).
+					Int("panics", panics).
+					Msg("component keeps panicking, exiting")
+				m.exit(1)
+				return
+			}
+			m.logger.Error().
+				Str("component", name).
+				Int("panics", panics).
+				Msg("component keeps panicking")
+		}
+
+		m.logger.Warn().
+			Str("component", name).
+			Int("panics", panics).
+			Dur("backoff", backoff).
+			Msg("restarting component")
+
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRestartBackoff)
+	}
+}
+
+// runRecovered calls fn and reports whether it panicked.
+func (m *Manager) runRecovered(name string, fn func(ctx context.Context)) (panicked bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			panicked = true
+			m.logger.Error().
+				Str("component", name).
+				Str("panic", fmt.Sprint(err)).
+				Str("stack", string(debug.Stack())).
+				Msg("component panicked")
+		}
+	}()
+
+	fn(m.ctx)
+	return false
+}
+
+// OnShutdown registers fn to stop a component that manages its own
+// goroutines. Closers run in reverse registration order.
+func (m *Manager) OnShutdown(name string, fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closers = append(m.closers, closer{name: name, fn: fn})
+}
+
+// Shutdown stops every component. If ctx ends first it returns an error
+// naming the goroutines still running, and the closers are skipped.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("components still running %v: %w", m.stillRunning(), ctx.Err())
+	}
+
+	m.mu.Lock()
+	closers := m.closers
+	m.closers = nil
+	m.mu.Unlock()
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		closers[i].fn()
+		m.logger.Info().Str("component", closers[i].name).Msg("component stopped")
+	}
+
+	return nil
+}
+
+func (m *Manager) stillRunning() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.running))
+	for name := range m.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,184 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestManager_Shutdown(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	m, err := New(logger.New("error"), Config{})
+	require.NoError(t, err)
+
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	m.OnShutdown("cache", memCache.Close)
+
+	var order []string
+	m.OnShutdown("first", func() { order = append(order, "first") })
+	m.OnShutdown("second", func() { order = append(order, "second") })
+
+	for i := 0; i < 3; i++ {
+		m.Go("worker", func(ctx context.Context) {
+			ticker := time.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, m.Shutdown(ctx))
+	assert.Equal(t, []string{"second", "first"}, order)
+	assert.Empty(t, m.stillRunning())
+}
+
+func TestManager_ShutdownTimeout(t *testing.T) {
+	m, err := New(logger.New("error"), Config{})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	defer close(release)
+	m.Go("stuck", func(ctx context.Context) {
+		<-release
+	})
+
+	closed := false
+	m.OnShutdown("cache", func() { closed = true })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = m.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "stuck")
+	assert.False(t, closed)
+}
+
+func TestNew_RequiresLogger(t *testing.T) {
+	_, err := New(nil, Config{})
+	assert.Error(t, err)
+}
+
+func TestManager_RestartsPanickingComponent(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	m, err := New(logger.New("error"), Config{MaxPanics: 2, RestartBackoff: time.Millisecond})
+	require.NoError(t, err)
+	m.exit = func(int) { t.Error("exit called without FailFast") }
+
+	var (
+		mu     sync.Mutex
+		starts []time.Time
+	)
+	done := make(chan struct{})
+	m.Go("flaky", func(ctx context.Context) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		n := len(starts)
+		mu.Unlock()
+
+		if n <= 4 {
+			panic("boom")
+		}
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("component was not restarted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, starts, 5)
+	// The pause doubles with each panic: 1ms, 2ms, 4ms, 8ms.
+	assert.GreaterOrEqual(t, starts[4].Sub(starts[3]), 8*time.Millisecond)
+	assert.GreaterOrEqual(t, starts[4].Sub(starts[0]), 15*time.Millisecond)
+}
+
+func TestManager_FailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	m, err := New(logger.New("error"), Config{MaxPanics: 2, RestartBackoff: time.Millisecond, FailFast: true})
+	require.NoError(t, err)
+
+	exited := make(chan int, 1)
+	m.exit = func(code int) { exited <- code }
+
+	var runs atomic.Int32
+	m.Go("broken", func(ctx context.Context) {
+		runs.Add(1)
+		panic("boom")
+	})
+
+	select {
+	case code := <-exited:
+		assert.Equal(t, 1, code)
+	case <-time.After(time.Second):
+		t.Fatal("process did not exit")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+	assert.Equal(t, int32(3), runs.Load())
+}
+
+func TestManager_SparsePanicsDontFailFast(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	m, err := New(logger.New("error"), Config{
+		MaxPanics:      1,
+		RestartBackoff: time.Millisecond,
+		StableAfter:    20 * time.Millisecond,
+		FailFast:       true,
+	})
+	require.NoError(t, err)
+	m.exit = func(int) { t.Error("exit called for panics spread past StableAfter") }
+
+	var runs atomic.Int32
+	done := make(chan struct{})
+	m.Go("sparse", func(ctx context.Context) {
+		if runs.Add(1) > 5 {
+			close(done)
+			return
+		}
+		// Each run lasts past StableAfter before it panics.
+		time.Sleep(30 * time.Millisecond)
+		panic("boom")
+	})
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("component was not restarted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+	assert.Equal(t, int32(6), runs.Load())
+}
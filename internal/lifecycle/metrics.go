@@ -0,0 +1,11 @@
+package lifecycle
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var componentPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "component_panics_total",
+	Help: "Total number of recovered panics in background components.",
+}, []string{"component"})
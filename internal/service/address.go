@@ -0,0 +1,38 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const addressHexLength = 40
+
+// normalizeAddress canonicalizes an execution-layer address to a lowercase,
+// 0x-prefixed, 20-byte hex string so checksummed and unprefixed variants
+// compare equal.
+func normalizeAddress(addr string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(addr))
+	normalized = strings.TrimPrefix(normalized, "0x")
+
+	if len(normalized) != addressHexLength {
+		return "", fmt.Errorf("invalid address length: %q", addr)
+	}
+	if _, err := hex.DecodeString(normalized); err != nil {
+		return "", fmt.Errorf("invalid address hex: %q", addr)
+	}
+
+	return "0x" + normalized, nil
+}
+
+func newAddressSet(addrs []string) (map[string]struct{}, error) {
+	set := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		normalized, err := normalizeAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		set[normalized] = struct{}{}
+	}
+	return set, nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+)
+
+// GetBlock returns the beacon block proposed at slot. Blocks are large and
+// only the derived values are worth keeping, so they aren't cached.
+func (s *validatorService) GetBlock(ctx context.Context, slot uint64) (*domain.Block, error) {
+	currentSlot, err := s.ethClient.GetCurrentSlot(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to get current slot")
+		return nil, fmt.Errorf("failed to get current slot: %w", err)
+	}
+
+	if slot > currentSlot {
+		s.logger.Warn().Uint64("slot", slot).Uint64("current_slot", currentSlot).Msg("requested future slot")
+		return nil, errors.ErrFutureSlot
+	}
+
+	block, err := s.ethClient.GetBlockBySlot(ctx, slot)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			s.logger.Info().Uint64("slot", slot).Msg("slot not found - likely missed")
+			return nil, errors.ErrSlotNotFound
+		}
+		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to get block")
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	result, err := toDomainBlock(block)
+	if err != nil {
+		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to map block")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func toDomainBlock(block *ethereum.BeaconBlock) (*domain.Block, error) {
+	msg := block.Data.Message
+
+	slot, err := strconv.ParseUint(msg.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.UpstreamDataError{Field: "slot", Value: msg.Slot, Reason: "not a decimal integer"}
+	}
+	proposerIndex, err := strconv.ParseUint(msg.ProposerIndex, 10, 64)
+	if err != nil {
+		return nil, errors.UpstreamDataError{Field: "proposer_index", Value: msg.ProposerIndex, Reason: "not a decimal integer"}
+	}
+
+	body := domain.BlockBody{
+		RandaoReveal:      msg.Body.RandaoReveal,
+		Graffiti:          msg.Body.Graffiti,
+		ProposerSlashings: rawList(msg.Body.ProposerSlashings),
+		AttesterSlashings: rawList(msg.Body.AttesterSlashings),
+		Attestations:      make([]interface{}, len(msg.Body.Attestations)),
+		Deposits:          rawList(msg.Body.Deposits),
+		VoluntaryExits:    rawList(msg.Body.VoluntaryExits),
+	}
+	if msg.Body.Eth1Data != nil {
+		body.Eth1Data = domain.Eth1Data(*msg.Body.Eth1Data)
+	}
+	for i, attestation := range msg.Body.Attestations {
+		body.Attestations[i] = attestation
+	}
+	var participation *domain.SyncParticipation
+	if msg.Body.SyncAggregate != nil {
+		aggregate := domain.SyncAggregate(*msg.Body.SyncAggregate)
+		body.SyncAggregate = &aggregate
+
+		participation, err = syncParticipation(aggregate.SyncCommitteeBits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Blinded blocks carry the payload header instead; it maps onto the same
+	// fields, minus the transactions.
+	payload := msg.Body.ExecutionPayload
+	if payload == nil {
+		payload = msg.Body.ExecutionPayloadHeader
+	}
+	if payload != nil {
+		body.ExecutionPayload = &domain.ExecutionPayload{
+			ParentHash:    payload.ParentHash,
+			FeeRecipient:  payload.FeeRecipient,
+			StateRoot:     payload.StateRoot,
+			ReceiptsRoot:  payload.ReceiptsRoot,
+			LogsBloom:     payload.LogsBloom,
+			PrevRandao:    payload.PrevRandao,
+			BlockNumber:   payload.BlockNumber,
+			GasLimit:      payload.GasLimit,
+			GasUsed:       payload.GasUsed,
+			Timestamp:     payload.Timestamp,
+			ExtraData:     payload.ExtraData,
+			BaseFeePerGas: payload.BaseFeePerGas,
+			BlockHash:     payload.BlockHash,
+			Transactions:  payload.Transactions,
+			Withdrawals:   rawList(payload.Withdrawals),
+		}
+	}
+
+	return &domain.Block{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    msg.ParentRoot,
+		StateRoot:     msg.StateRoot,
+		Body:          body,
+		Finalized:     block.Finalized,
+		Optimistic:    block.ExecutionOptimistic,
+		BlobCount:     blobCount(block),
+		Participation: participation,
+	}, nil
+}
+
+// syncParticipation counts the set bits of a sync aggregate's bitvector. The
+// committee size is the vector's length, so it holds on any preset.
+func syncParticipation(bitsHex string) (*domain.SyncParticipation, error) {
+	participated, err := popcountHex(bitsHex)
+	if err != nil {
+		return nil, errors.UpstreamDataError{Field: "sync_committee_bits", Value: bitsHex, Reason: "not hex"}
+	}
+
+	total := len(strings.TrimPrefix(bitsHex, "0x")) * 4
+	if total == 0 {
+		return nil, errors.UpstreamDataError{Field: "sync_committee_bits", Value: bitsHex, Reason: "empty"}
+	}
+
+	return &domain.SyncParticipation{
+		Participated: participated,
+		Total:        total,
+		Rate:         float64(participated) / float64(total),
+	}, nil
+}
+
+// preDenebForks are the forks whose blocks can't carry blobs.
+var preDenebForks = map[string]bool{
+	"phase0":    true,
+	"altair":    true,
+	"bellatrix": true,
+	"capella":   true,
+}
+
+// blobCount counts the block's blob commitments, or returns nil before
+// Deneb. Without a version, a block counts as Deneb if it has the
+// commitments field at all.
+func blobCount(block *ethereum.BeaconBlock) *int {
+	commitments := block.Data.Message.Body.BlobKZGCommitments
+	version := strings.ToLower(block.Version)
+	if preDenebForks[version] || (version == "" && commitments == nil) {
+		return nil
+	}
+
+	count := len(commitments)
+	return &count
+}
+
+// rawList passes list items through untouched; the API doesn't interpret
+// them, so there's no point decoding them into typed structs.
+func rawList(items []json.RawMessage) []interface{} {
+	if items == nil {
+		return nil
+	}
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = item
+	}
+	return list
+}
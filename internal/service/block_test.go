@@ -0,0 +1,252 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func loadBlockFixture(t *testing.T, name string) *ethereum.BeaconBlock {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+
+	var block ethereum.BeaconBlock
+	require.NoError(t, json.Unmarshal(raw, &block))
+	return &block
+}
+
+func TestToDomainBlock(t *testing.T) {
+	block, err := toDomainBlock(loadBlockFixture(t, "full_block.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(8500000), block.Slot)
+	assert.Equal(t, uint64(923456), block.ProposerIndex)
+	assert.Equal(t, "0x4d611d5b93fdab69013a7f0a2f961caca0c853f87cfe9595fe50038163079360", block.ParentRoot)
+	assert.Equal(t, "0x2b7bd2e5d6a6b1c55e0f8d1b8e3f0fcd4c1e2a3b4c5d6e7f8091a2b3c4d5e6f7", block.StateRoot)
+	assert.True(t, block.Finalized)
+	assert.False(t, block.Optimistic)
+	assert.Nil(t, block.ExecutionPayload)
+
+	body := block.Body
+	assert.Equal(t, "0x8e2a6c61d8a8f3f4", body.RandaoReveal)
+	assert.Equal(t, domain.Eth1Data{DepositRoot: "0x6a0f9d1b5f1d4f5e", DepositCount: "1337", BlockHash: "0x0bd1e2f3a4b5c6d7"}, body.Eth1Data)
+	assert.Equal(t, "0x6c69676874686f7573650000000000000000000000000000000000000000000000", body.Graffiti)
+	assert.Empty(t, body.ProposerSlashings)
+	assert.Empty(t, body.AttesterSlashings)
+	assert.Empty(t, body.Deposits)
+	assert.Len(t, body.Attestations, 1)
+	assert.Len(t, body.VoluntaryExits, 1)
+	assert.Equal(t, &domain.SyncAggregate{SyncCommitteeBits: "0xffffffff", SyncCommitteeSignature: "0x88"}, body.SyncAggregate)
+
+	require.NotNil(t, body.ExecutionPayload)
+	assert.Equal(t, domain.ExecutionPayload{
+		ParentHash:    "0xc1",
+		FeeRecipient:  "0x4675c7e5baafbffbca748158becba61ef3b0a263",
+		StateRoot:     "0xc2",
+		ReceiptsRoot:  "0xc3",
+		LogsBloom:     "0x00",
+		PrevRandao:    "0xc4",
+		BlockNumber:   "19300000",
+		GasLimit:      "30000000",
+		GasUsed:       "12000000",
+		Timestamp:     "1708000000",
+		ExtraData:     "0x6265617665726275696c642e6f7267",
+		BaseFeePerGas: "25000000000",
+		BlockHash:     "0xc5",
+		Transactions:  []string{"0x02f8"},
+		Withdrawals:   body.ExecutionPayload.Withdrawals,
+	}, *body.ExecutionPayload)
+	assert.Len(t, body.ExecutionPayload.Withdrawals, 1)
+
+	t.Run("list items are passed through", func(t *testing.T) {
+		encoded, err := json.Marshal(block)
+		require.NoError(t, err)
+
+		var decoded struct {
+			Body struct {
+				Attestations []struct {
+					AggregationBits string `json:"aggregation_bits"`
+					Data            struct {
+						Slot   string `json:"slot"`
+						Target struct {
+							Epoch string `json:"epoch"`
+						} `json:"target"`
+					} `json:"data"`
+					Signature string `json:"signature"`
+				} `json:"attestations"`
+				VoluntaryExits []struct {
+					Message struct {
+						ValidatorIndex string `json:"validator_index"`
+					} `json:"message"`
+				} `json:"voluntary_exits"`
+				ExecutionPayload struct {
+					Withdrawals []struct {
+						Amount string `json:"amount"`
+					} `json:"withdrawals"`
+				} `json:"execution_payload"`
+			} `json:"body"`
+		}
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+		require.Len(t, decoded.Body.Attestations, 1)
+		assert.Equal(t, "0xff01", decoded.Body.Attestations[0].AggregationBits)
+		assert.Equal(t, "8499999", decoded.Body.Attestations[0].Data.Slot)
+		assert.Equal(t, "265624", decoded.Body.Attestations[0].Data.Target.Epoch)
+		assert.Equal(t, "0x99", decoded.Body.Attestations[0].Signature)
+		assert.Equal(t, "1000", decoded.Body.VoluntaryExits[0].Message.ValidatorIndex)
+		assert.Equal(t, "3", decoded.Body.ExecutionPayload.Withdrawals[0].Amount)
+	})
+
+	t.Run("blinded block", func(t *testing.T) {
+		block, err := toDomainBlock(loadBlockFixture(t, "blinded_block.json"))
+		require.NoError(t, err)
+
+		require.NotNil(t, block.Body.ExecutionPayload)
+		assert.Equal(t, "0x1f9090aae28b8a3dceadf281b0f12828e676c326", block.Body.ExecutionPayload.FeeRecipient)
+		assert.Equal(t, "19000000", block.Body.ExecutionPayload.BlockNumber)
+		assert.Nil(t, block.Body.ExecutionPayload.Transactions)
+	})
+
+	t.Run("malformed proposer index", func(t *testing.T) {
+		raw := loadBlockFixture(t, "full_block.json")
+		raw.Data.Message.ProposerIndex = "-1"
+
+		_, err := toDomainBlock(raw)
+		assert.True(t, pkgerrors.IsMalformedUpstream(err))
+	})
+}
+
+func TestToDomainBlock_BlobCount(t *testing.T) {
+	count := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		fixture string
+		modify  func(*ethereum.BeaconBlock)
+		want    *int
+	}{
+		{name: "deneb with blobs", fixture: "deneb_blob_block.json", want: count(3)},
+		{name: "deneb without blobs", fixture: "full_block.json", want: count(0)},
+		{name: "pre-deneb", fixture: "capella_block.json"},
+		{
+			name:    "unversioned with commitments",
+			fixture: "deneb_blob_block.json",
+			modify:  func(b *ethereum.BeaconBlock) { b.Version = "" },
+			want:    count(3),
+		},
+		{
+			name:    "unversioned without commitments",
+			fixture: "capella_block.json",
+			modify:  func(b *ethereum.BeaconBlock) { b.Version = "" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := loadBlockFixture(t, tt.fixture)
+			if tt.modify != nil {
+				tt.modify(raw)
+			}
+
+			block, err := toDomainBlock(raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, block.BlobCount)
+		})
+	}
+}
+
+func TestToDomainBlock_Participation(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *domain.SyncParticipation
+	}{
+		{fixture: "altair_sync_block.json", want: &domain.SyncParticipation{Participated: 487, Total: 512, Rate: 0.951171875}},
+		{fixture: "blinded_block.json", want: &domain.SyncParticipation{Participated: 512, Total: 512, Rate: 1}},
+		{fixture: "phase0_block.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			block, err := toDomainBlock(loadBlockFixture(t, tt.fixture))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, block.Participation)
+		})
+	}
+
+	t.Run("malformed bits", func(t *testing.T) {
+		for _, bits := range []string{"0xzz", "0x"} {
+			raw := loadBlockFixture(t, "altair_sync_block.json")
+			raw.Data.Message.Body.SyncAggregate.SyncCommitteeBits = bits
+
+			_, err := toDomainBlock(raw)
+			assert.ErrorIs(t, err, pkgerrors.ErrMalformedUpstream, bits)
+		}
+	})
+}
+
+func TestValidatorService_GetBlock(t *testing.T) {
+	tests := []struct {
+		name          string
+		slot          uint64
+		setupMocks    func(*mockEthClient)
+		expectedError error
+	}{
+		{
+			name: "proposed",
+			slot: 8500000,
+			setupMocks: func(client *mockEthClient) {
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(9000000), nil)
+				client.On("GetBlockBySlot", mock.Anything, uint64(8500000)).Return(loadBlockFixture(t, "full_block.json"), nil)
+			},
+		},
+		{
+			name: "missed slot",
+			slot: 8500001,
+			setupMocks: func(client *mockEthClient) {
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(9000000), nil)
+				client.On("GetBlockBySlot", mock.Anything, uint64(8500001)).Return(nil, pkgerrors.ErrSlotNotFound)
+			},
+			expectedError: pkgerrors.ErrSlotNotFound,
+		},
+		{
+			name: "future slot",
+			slot: 9000001,
+			setupMocks: func(client *mockEthClient) {
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(9000000), nil)
+			},
+			expectedError: pkgerrors.ErrFutureSlot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockEthClient)
+			tt.setupMocks(client)
+
+			svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+			require.NoError(t, err)
+
+			block, err := svc.GetBlock(t.Context(), tt.slot)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, block)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.slot, block.Slot)
+			}
+
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import "time"
+
+// cacheEntry wraps the values whose freshness depends on finality, block
+// rewards and slot statuses, with what was known when they were written.
+// Expiry and stale-serve decisions read these rather than the value, so
+// they don't depend on each domain type carrying its own finality.
+type cacheEntry struct {
+	value     interface{}
+	finalized bool
+	fetchedAt time.Time
+}
+
+func (s *validatorService) setCacheEntry(cache Cache, key string, value interface{}, finalized bool) {
+	cache.Set(key, cacheEntry{value: value, finalized: finalized, fetchedAt: s.now()})
+}
+
+// getCacheEntry returns the entry under key. An unfinalized entry older than
+// unfinalizedTTL counts as missing, since the slot may still change; a
+// finalized one lasts as long as the cache keeps it.
+func (s *validatorService) getCacheEntry(cache Cache, key string) (cacheEntry, bool) {
+	cached, found := cache.Get(key)
+	if !found {
+		return cacheEntry{}, false
+	}
+
+	entry, ok := cached.(cacheEntry)
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	if !entry.finalized && s.unfinalizedTTL > 0 && s.now().Sub(entry.fetchedAt) >= s.unfinalizedTTL {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// entryExpiration is when entry stops being served: the cache's own expiry,
+// or earlier for an unfinalized entry under unfinalizedTTL.
+func (s *validatorService) entryExpiration(entry cacheEntry, cacheExpiration time.Time) time.Time {
+	if entry.finalized || s.unfinalizedTTL <= 0 {
+		return cacheExpiration
+	}
+
+	if expiration := entry.fetchedAt.Add(s.unfinalizedTTL); expiration.Before(cacheExpiration) {
+		return expiration
+	}
+	return cacheExpiration
+}
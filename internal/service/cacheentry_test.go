@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+var testNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestValidatorService_CacheEntryMetadata(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+
+	unfinalized := testBlock()
+	finalized := testBlock()
+	finalized.Finalized = true
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(unfinalized, nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(2)).Return(finalized, nil)
+	client.On("GetBlockRewards", mock.Anything, mock.Anything).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{})
+	require.NoError(t, err)
+	svc.(*validatorService).now = func() time.Time { return testNow }
+
+	for _, tt := range []struct {
+		slot      uint64
+		finalized bool
+	}{
+		{slot: 1, finalized: false},
+		{slot: 2, finalized: true},
+	} {
+		reward, err := svc.GetBlockReward(context.Background(), tt.slot)
+		require.NoError(t, err)
+
+		cached, found := memCache.Get(svc.(*validatorService).keys.blockRewardKey(tt.slot))
+		require.True(t, found)
+		entry := cached.(cacheEntry)
+		assert.Same(t, reward, entry.value)
+		assert.Equal(t, tt.finalized, entry.finalized)
+		assert.Equal(t, testNow, entry.fetchedAt)
+
+		got, found := svc.(*validatorService).getCacheEntry(memCache, svc.(*validatorService).keys.blockRewardKey(tt.slot))
+		require.True(t, found)
+		assert.Equal(t, entry, got)
+	}
+}
+
+func TestValidatorService_UnfinalizedTTL(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Hour, 10)
+	defer memCache.Close()
+
+	unfinalized := testBlock()
+	finalized := testBlock()
+	finalized.Finalized = true
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(unfinalized, nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(2)).Return(finalized, nil)
+	client.On("GetBlockRewards", mock.Anything, mock.Anything).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{UnfinalizedTTL: time.Minute})
+	require.NoError(t, err)
+
+	now := testNow
+	svc.(*validatorService).now = func() time.Time { return now }
+
+	for _, slot := range []uint64{1, 2} {
+		_, err := svc.GetBlockReward(context.Background(), slot)
+		require.NoError(t, err)
+	}
+
+	// Within the unfinalized TTL both are served from the cache.
+	now = testNow.Add(59 * time.Second)
+	for _, slot := range []uint64{1, 2} {
+		_, err := svc.GetBlockReward(context.Background(), slot)
+		require.NoError(t, err)
+	}
+	client.AssertNumberOfCalls(t, "GetBlockRewards", 2)
+
+	// Past it only the entry written before finality is refetched, even
+	// though the cache itself would keep it for an hour.
+	now = testNow.Add(time.Minute)
+	for _, slot := range []uint64{1, 2} {
+		_, err := svc.GetBlockReward(context.Background(), slot)
+		require.NoError(t, err)
+	}
+	client.AssertNumberOfCalls(t, "GetBlockRewards", 3)
+	client.AssertNumberOfCalls(t, "GetBlockBySlot", 3)
+}
+
+func TestValidatorService_EntryExpiration(t *testing.T) {
+	svc := &validatorService{unfinalizedTTL: time.Minute}
+	cacheExpiration := testNow.Add(5 * time.Minute)
+
+	tests := []struct {
+		name  string
+		entry cacheEntry
+		ttl   time.Duration
+		want  time.Time
+	}{
+		{name: "finalized", entry: cacheEntry{finalized: true, fetchedAt: testNow}, ttl: time.Minute, want: cacheExpiration},
+		{name: "unfinalized", entry: cacheEntry{fetchedAt: testNow}, ttl: time.Minute, want: testNow.Add(time.Minute)},
+		{name: "unfinalized ttl past cache expiry", entry: cacheEntry{fetchedAt: testNow}, ttl: time.Hour, want: cacheExpiration},
+		{name: "unfinalized ttl disabled", entry: cacheEntry{fetchedAt: testNow}, want: cacheExpiration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc.unfinalizedTTL = tt.ttl
+			assert.Equal(t, tt.want, svc.entryExpiration(tt.entry, cacheExpiration))
+		})
+	}
+}
+
+func TestValidatorService_StaleServeRequiresFinalizedEntry(t *testing.T) {
+	staleCache := cache.NewMemoryCache(time.Hour, 10)
+	defer staleCache.Close()
+	staleCache.Set("block_reward:1", cacheEntry{value: &domain.BlockReward{Reward: big.NewInt(1), Finalized: true}})
+
+	svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), nil, ServiceConfig{StaleCache: staleCache})
+	require.NoError(t, err)
+
+	// The value claims finality, but the entry wasn't finalized when written.
+	_, ok := svc.(*validatorService).staleBlockReward(1, context.DeadlineExceeded)
+	assert.False(t, ok)
+
+	staleCache.Set("block_reward:1", cacheEntry{value: &domain.BlockReward{Reward: big.NewInt(1), Finalized: true}, finalized: true, fetchedAt: testNow})
+	reward, ok := svc.(*validatorService).staleBlockReward(1, context.DeadlineExceeded)
+	require.True(t, ok)
+	assert.True(t, reward.Stale)
+}
@@ -0,0 +1,18 @@
+package service
+
+import "context"
+
+type bypassCacheKey struct{}
+
+// WithCacheBypass makes the service skip cache reads for requests made with
+// the returned context. Fresh results are still written back, so a bypassed
+// request also replaces a stale entry.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// CacheBypassed reports whether ctx was created by WithCacheBypass.
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
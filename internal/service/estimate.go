@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+)
+
+// Reward estimation applies the Altair proposer reward formulas to the
+// participation visible in the block. It is an approximation because the
+// block alone doesn't carry everything the spec needs:
+//   - the total active balance is assumed to be estimateTotalActiveBalance,
+//   - every attester is assumed to have the maximum effective balance and to
+//     set all three timely flags for the first time, so duplicate or late
+//     votes are over-counted,
+//   - slashing inclusion rewards are ignored.
+//
+// The result is in the same unit as the beacon rewards endpoint.
+const (
+	estimateTotalActiveBalance = 34_000_000 * 1_000_000_000
+	effectiveBalanceIncrement  = 1_000_000_000
+	maxEffectiveBalance        = 32 * effectiveBalanceIncrement
+	baseRewardFactor           = 64
+	weightDenominator          = 64
+	proposerWeight             = 8
+	syncRewardWeight           = 2
+	timelyFlagsWeight          = 14 + 26 + 14
+	slotsPerEpoch              = 32
+	syncCommitteeSize          = 512
+)
+
+func estimateBlockReward(body ethereum.BlockBody) (*big.Int, error) {
+	attesters, syncParticipants, err := countParticipants(body)
+	if err != nil {
+		return nil, err
+	}
+
+	total := estimateAttestationReward(attesters) + estimateSyncAggregateReward(syncParticipants)
+	return new(big.Int).SetUint64(total), nil
+}
+
+// countParticipants returns the attesters in the block's aggregates and the
+// sync committee members that signed its sync aggregate.
+func countParticipants(body ethereum.BlockBody) (attesters, syncParticipants int, err error) {
+	for _, attestation := range body.Attestations {
+		n, err := popcountBitlist(attestation.AggregationBits)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid aggregation bits: %w", err)
+		}
+		attesters += n
+	}
+
+	if body.SyncAggregate != nil {
+		syncParticipants, err = popcountHex(body.SyncAggregate.SyncCommitteeBits)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid sync committee bits: %w", err)
+		}
+	}
+	return attesters, syncParticipants, nil
+}
+
+func estimateBaseRewardPerIncrement() uint64 {
+	sqrtBalance := new(big.Int).Sqrt(big.NewInt(estimateTotalActiveBalance)).Uint64()
+	return effectiveBalanceIncrement * baseRewardFactor / sqrtBalance
+}
+
+// estimateAttestationReward is the proposer's share of the timely flag
+// rewards of attesters attesters.
+func estimateAttestationReward(attesters int) uint64 {
+	baseReward := estimateBaseRewardPerIncrement() * (maxEffectiveBalance / effectiveBalanceIncrement)
+	proposerDenominator := uint64((weightDenominator - proposerWeight) * weightDenominator / proposerWeight)
+	return uint64(attesters) * baseReward * timelyFlagsWeight / proposerDenominator
+}
+
+// estimateSyncAggregateReward is the proposer's reward for including the
+// signatures of participants sync committee members.
+func estimateSyncAggregateReward(participants int) uint64 {
+	totalBaseRewards := estimateBaseRewardPerIncrement() * (estimateTotalActiveBalance / effectiveBalanceIncrement)
+	participantReward := totalBaseRewards * syncRewardWeight / weightDenominator / slotsPerEpoch / syncCommitteeSize
+	return uint64(participants) * (participantReward * proposerWeight / (weightDenominator - proposerWeight))
+}
+
+func popcountHex(s string) (int, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, b := range raw {
+		count += bits.OnesCount8(b)
+	}
+	return count, nil
+}
+
+// popcountBitlist counts set bits in an SSZ bitlist, excluding the trailing
+// length delimiter bit.
+func popcountBitlist(s string) (int, error) {
+	count, err := popcountHex(s)
+	if err != nil || count == 0 {
+		return count, err
+	}
+	return count - 1, nil
+}
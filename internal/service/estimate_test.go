@@ -0,0 +1,60 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+)
+
+// The expected values below are worked out from the Altair spec by hand
+// rather than read back from the estimator, so a change to either the
+// formulas or the participation counting shows up on its own.
+
+func TestCountParticipants(t *testing.T) {
+	attesters, syncParticipants, err := countParticipants(loadBlockFixture(t, "reward_estimate_block.json").Data.Message.Body)
+	require.NoError(t, err)
+	// Two aggregates of 12 full bytes and 0x1f, each 100 bits once the
+	// length delimiter is dropped, and a fully signed 512-member committee.
+	assert.Equal(t, 200, attesters)
+	assert.Equal(t, 512, syncParticipants)
+
+	attesters, syncParticipants, err = countParticipants(ethereum.BlockBody{
+		Attestations: []ethereum.Attestation{{AggregationBits: "0x01"}, {AggregationBits: "0x0d"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attesters)
+	assert.Zero(t, syncParticipants)
+
+	_, _, err = countParticipants(ethereum.BlockBody{Attestations: []ethereum.Attestation{{AggregationBits: "0xzz"}}})
+	assert.Error(t, err)
+}
+
+func TestEstimateRewardFormulas(t *testing.T) {
+	// EFFECTIVE_BALANCE_INCREMENT * BASE_REWARD_FACTOR // isqrt(34M ETH in gwei)
+	// = 1e9 * 64 // 184390889.
+	assert.Equal(t, uint64(347), estimateBaseRewardPerIncrement())
+
+	// A 32 ETH attester's base reward is 347 * 32 = 11104 gwei. The
+	// proposer gets 54/64 of it (the three timely flags) scaled by
+	// PROPOSER_WEIGHT / (WEIGHT_DENOMINATOR - PROPOSER_WEIGHT): 11104 * 54 // 448.
+	assert.Equal(t, uint64(1338), estimateAttestationReward(1))
+	assert.Equal(t, uint64(267685), estimateAttestationReward(200))
+
+	// Total base rewards are 347 * 34M = 11798000000 gwei; one participant
+	// earns 11798000000 * 2 // 64 // 32 // 512 = 22502 of them, and the
+	// proposer 22502 * 8 // 56 = 3214 per participant.
+	assert.Equal(t, uint64(3214), estimateSyncAggregateReward(1))
+	assert.Equal(t, uint64(1645568), estimateSyncAggregateReward(512))
+
+	assert.Zero(t, estimateAttestationReward(0)+estimateSyncAggregateReward(0))
+}
+
+func TestEstimateBlockReward(t *testing.T) {
+	reward, err := estimateBlockReward(loadBlockFixture(t, "reward_estimate_block.json").Data.Message.Body)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(267685+1645568), reward)
+}
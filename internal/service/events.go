@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+)
+
+const (
+	defaultEventReconnectDelay = 5 * time.Second
+	defaultEventRewardTimeout  = 10 * time.Second
+)
+
+// StreamEvents relays beacon node events for topics to fn until ctx is done,
+// reconnecting whenever the upstream stream drops. Head events are decoded and
+// carry the new block's reward; other topics are passed through unchanged.
+func (s *validatorService) StreamEvents(ctx context.Context, topics []string, fn func(domain.Event)) error {
+	for {
+		err := s.ethClient.SubscribeEvents(ctx, topics, func(event ethereum.Event) {
+			fn(s.translateEvent(ctx, event))
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		s.logger.Warn().Err(err).Dur("reconnect_delay", s.eventReconnectDelay).Msg("event stream disconnected")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.eventReconnectDelay):
+		}
+	}
+}
+
+func (s *validatorService) translateEvent(ctx context.Context, event ethereum.Event) domain.Event {
+	if event.Topic != ethereum.TopicHead {
+		return domain.Event{Topic: event.Topic, Data: event.Data}
+	}
+
+	var head ethereum.HeadEvent
+	if err := json.Unmarshal(event.Data, &head); err != nil {
+		s.logger.Error().Err(err).Msg("failed to decode head event")
+		return domain.Event{Topic: event.Topic, Data: event.Data}
+	}
+
+	slot, err := strconv.ParseUint(head.Slot, 10, 64)
+	if err != nil {
+		s.logger.Error().Err(err).Str("slot", head.Slot).Msg("invalid slot in head event")
+		return domain.Event{Topic: event.Topic, Data: event.Data}
+	}
+
+	result := &domain.HeadEvent{
+		Slot:                      slot,
+		Block:                     head.Block,
+		State:                     head.State,
+		EpochTransition:           head.EpochTransition,
+		PreviousDutyDependentRoot: head.PreviousDutyDependentRoot,
+		CurrentDutyDependentRoot:  head.CurrentDutyDependentRoot,
+		Optimistic:                head.ExecutionOptimistic,
+	}
+
+	// The event is still worth relaying when the reward can't be computed.
+	rewardCtx, cancel := context.WithTimeout(ctx, s.eventRewardTimeout)
+	defer cancel()
+
+	reward, err := s.GetBlockReward(rewardCtx, slot)
+	if err != nil {
+		s.logger.Warn().Err(err).Uint64("slot", slot).Msg("failed to get reward for head event")
+	} else {
+		result.Reward = reward
+	}
+
+	return domain.Event{Topic: event.Topic, Data: result}
+}
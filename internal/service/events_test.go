@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorService_StreamEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	topics := []string{ethereum.TopicHead, ethereum.TopicFinalizedCheckpoint}
+
+	client := new(mockEthClient)
+	client.On("SubscribeEvents", mock.Anything, topics, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(ethereum.Event))
+			fn(ethereum.Event{Topic: ethereum.TopicHead, Data: json.RawMessage(`{"slot":"12345","block":"0xaa","execution_optimistic":true}`)})
+			fn(ethereum.Event{Topic: ethereum.TopicHead, Data: json.RawMessage(`{"slot":"12346","block":"0xbb"}`)})
+		}).
+		Return(errors.New("stream closed")).Once()
+	client.On("SubscribeEvents", mock.Anything, topics, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(ethereum.Event))
+			fn(ethereum.Event{Topic: ethereum.TopicFinalizedCheckpoint, Data: json.RawMessage(`{"epoch":"385"}`)})
+			cancel()
+		}).
+		Return(context.Canceled).Once()
+
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(12346), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12346)).Return(nil, errors.New("beacon node down"))
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{EventReconnectDelay: time.Millisecond})
+	require.NoError(t, err)
+
+	var events []domain.Event
+	err = svc.StreamEvents(ctx, topics, func(event domain.Event) {
+		events = append(events, event)
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	require.Len(t, events, 3)
+
+	head, ok := events[0].Data.(*domain.HeadEvent)
+	require.True(t, ok)
+	assert.Equal(t, uint64(12345), head.Slot)
+	assert.Equal(t, "0xaa", head.Block)
+	assert.True(t, head.Optimistic)
+	require.NotNil(t, head.Reward)
+	assert.Equal(t, "1000000000000", head.Reward.Reward.String())
+
+	// A reward failure still relays the head event.
+	head, ok = events[1].Data.(*domain.HeadEvent)
+	require.True(t, ok)
+	assert.Equal(t, uint64(12346), head.Slot)
+	assert.Nil(t, head.Reward)
+
+	assert.Equal(t, ethereum.TopicFinalizedCheckpoint, events[2].Topic)
+	assert.Equal(t, json.RawMessage(`{"epoch":"385"}`), events[2].Data)
+
+	client.AssertExpectations(t)
+}
+
+func TestValidatorService_StreamEvents_RewardTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := new(mockEthClient)
+	client.On("SubscribeEvents", mock.Anything, []string{ethereum.TopicHead}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(ethereum.Event))
+			fn(ethereum.Event{Topic: ethereum.TopicHead, Data: json.RawMessage(`{"slot":"12345","block":"0xaa"}`)})
+			cancel()
+		}).
+		Return(context.Canceled)
+	// The stream's context has no deadline; the reward lookup must get one.
+	client.On("GetCurrentSlot", mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(uint64(0), context.DeadlineExceeded)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{EventRewardTimeout: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	var events []domain.Event
+	err = svc.StreamEvents(ctx, []string{ethereum.TopicHead}, func(event domain.Event) {
+		events = append(events, event)
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	require.Len(t, events, 1)
+	head, ok := events[0].Data.(*domain.HeadEvent)
+	require.True(t, ok)
+	assert.Nil(t, head.Reward)
+}
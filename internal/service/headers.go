@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+)
+
+// GetBlockHeaderByStateRoot finds the header at slot whose post-state is
+// stateRoot, whether or not it's canonical. The beacon API only indexes
+// headers by slot and block root, hence the slot. Competing forks come and
+// go, so the result isn't cached.
+func (s *validatorService) GetBlockHeaderByStateRoot(ctx context.Context, slot uint64, stateRoot string) (*domain.BeaconHeader, error) {
+	headers, err := s.ethClient.GetBlockHeadersAtSlot(ctx, slot)
+	if err != nil && !errors.IsNotFound(err) {
+		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to get block headers")
+		return nil, fmt.Errorf("failed to get block headers: %w", err)
+	}
+
+	for _, header := range headers {
+		if strings.EqualFold(header.Header.Message.StateRoot, stateRoot) {
+			return toDomainHeader(header)
+		}
+	}
+
+	s.logger.Info().Uint64("slot", slot).Str("state_root", stateRoot).Msg("no header with state root at slot")
+	return nil, errors.ErrHeaderNotFound
+}
+
+func toDomainHeader(header ethereum.HeaderData) (*domain.BeaconHeader, error) {
+	msg := header.Header.Message
+
+	slot, err := strconv.ParseUint(msg.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.UpstreamDataError{Field: "slot", Value: msg.Slot, Reason: "not a decimal integer"}
+	}
+	proposerIndex, err := strconv.ParseUint(msg.ProposerIndex, 10, 64)
+	if err != nil {
+		return nil, errors.UpstreamDataError{Field: "proposer_index", Value: msg.ProposerIndex, Reason: "not a decimal integer"}
+	}
+
+	return &domain.BeaconHeader{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		Root:          header.Root,
+		ParentRoot:    msg.ParentRoot,
+		StateRoot:     msg.StateRoot,
+		BodyRoot:      msg.BodyRoot,
+		Canonical:     header.Canonical,
+	}, nil
+}
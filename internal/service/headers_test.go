@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorService_GetBlockHeaderByStateRoot(t *testing.T) {
+	header := func(root, stateRoot string, canonical bool) ethereum.HeaderData {
+		return ethereum.HeaderData{
+			Root:      root,
+			Canonical: canonical,
+			Header: ethereum.HeaderInfo{Message: ethereum.HeaderMessage{
+				Slot:          "100",
+				ProposerIndex: "7",
+				ParentRoot:    "0x01",
+				StateRoot:     stateRoot,
+				BodyRoot:      "0x02",
+			}},
+		}
+	}
+
+	client := new(mockEthClient)
+	client.On("GetBlockHeadersAtSlot", mock.Anything, uint64(100)).Return([]ethereum.HeaderData{
+		header("0xaa", "0xAB", true),
+		header("0xbb", "0xcd", false),
+	}, nil)
+	client.On("GetBlockHeadersAtSlot", mock.Anything, uint64(101)).Return(nil, pkgerrors.ErrSlotNotFound)
+	client.On("GetBlockHeadersAtSlot", mock.Anything, uint64(102)).Return(nil, errors.New("connection refused"))
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	t.Run("orphaned header", func(t *testing.T) {
+		result, err := svc.GetBlockHeaderByStateRoot(context.Background(), 100, "0xcd")
+		require.NoError(t, err)
+		assert.Equal(t, "0xbb", result.Root)
+		assert.Equal(t, uint64(100), result.Slot)
+		assert.Equal(t, uint64(7), result.ProposerIndex)
+		assert.False(t, result.Canonical)
+	})
+
+	t.Run("root case is ignored", func(t *testing.T) {
+		result, err := svc.GetBlockHeaderByStateRoot(context.Background(), 100, "0xab")
+		require.NoError(t, err)
+		assert.Equal(t, "0xaa", result.Root)
+		assert.True(t, result.Canonical)
+	})
+
+	t.Run("no matching header", func(t *testing.T) {
+		_, err := svc.GetBlockHeaderByStateRoot(context.Background(), 100, "0xef")
+		assert.ErrorIs(t, err, pkgerrors.ErrHeaderNotFound)
+	})
+
+	t.Run("empty slot", func(t *testing.T) {
+		_, err := svc.GetBlockHeaderByStateRoot(context.Background(), 101, "0xab")
+		assert.ErrorIs(t, err, pkgerrors.ErrHeaderNotFound)
+	})
+
+	t.Run("upstream failure", func(t *testing.T) {
+		_, err := svc.GetBlockHeaderByStateRoot(context.Background(), 102, "0xab")
+		assert.Error(t, err)
+		assert.False(t, pkgerrors.IsNotFound(err))
+	})
+}
@@ -0,0 +1,42 @@
+package service
+
+import "fmt"
+
+// cacheKeys builds every cache key the service uses so a deployment-wide
+// prefix is applied consistently.
+type cacheKeys struct {
+	prefix string
+}
+
+func (k cacheKeys) blockRewardKey(slot uint64) string {
+	return k.key("block_reward", slot)
+}
+
+// Sync committees are fixed for a whole period, so their keys are per period
+// and shared by every slot in it.
+func (k cacheKeys) syncDutiesKey(period uint64) string {
+	return k.key("sync_duties_period", period)
+}
+
+func (k cacheKeys) syncDutiesNextKey(period uint64) string {
+	return k.key("sync_duties_next_period", period)
+}
+
+func (k cacheKeys) slotStatusKey(slot uint64) string {
+	return k.key("slot_status", slot)
+}
+
+func (k cacheKeys) proposerDutiesKey(epoch uint64) string {
+	return k.key("proposer_duties", epoch)
+}
+
+func (k cacheKeys) validatorPubkeyKey(index uint64) string {
+	return k.key("validator_pubkey", index)
+}
+
+func (k cacheKeys) key(kind string, id uint64) string {
+	if k.prefix == "" {
+		return fmt.Sprintf("%s:%d", kind, id)
+	}
+	return fmt.Sprintf("%s:%s:%d", k.prefix, kind, id)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestCacheKeys(t *testing.T) {
+	unprefixed := cacheKeys{}
+	assert.Equal(t, "block_reward:12345", unprefixed.blockRewardKey(12345))
+	assert.Equal(t, "sync_duties_period:3", unprefixed.syncDutiesKey(3))
+	assert.Equal(t, "sync_duties_next_period:3", unprefixed.syncDutiesNextKey(3))
+
+	prefixed := cacheKeys{prefix: "holesky"}
+	assert.Equal(t, "holesky:block_reward:12345", prefixed.blockRewardKey(12345))
+	assert.Equal(t, "holesky:sync_duties_period:3", prefixed.syncDutiesKey(3))
+	assert.Equal(t, "holesky:sync_duties_next_period:3", prefixed.syncDutiesNextKey(3))
+}
+
+func TestValidatorService_CacheKeyPrefix(t *testing.T) {
+	cached := &domain.BlockReward{Status: domain.StatusVanilla, Reward: big.NewInt(1)}
+
+	cache := new(mockCache)
+	cache.On("Get", "mainnet:block_reward:12345").Return(cacheEntry{value: cached}, true)
+
+	svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), cache, ServiceConfig{CacheKeyPrefix: "mainnet"})
+	assert.NoError(t, err)
+
+	result, err := svc.GetBlockReward(context.Background(), 12345)
+	assert.NoError(t, err)
+	assert.Equal(t, cached, result)
+	cache.AssertExpectations(t)
+}
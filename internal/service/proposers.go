@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/fanout"
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// GetEpochProposerRewards returns every proposal scheduled in epoch with the
+// reward it earned, in slot order. Missed proposals are included with a zero
+// reward. The current slot and those after it are left out: their proposers
+// may still propose.
+func (s *validatorService) GetEpochProposerRewards(ctx context.Context, epoch uint64) ([]domain.ProposerReward, error) {
+	currentSlot, err := s.ethClient.GetCurrentSlot(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to get current slot")
+		return nil, fmt.Errorf("failed to get current slot: %w", err)
+	}
+
+	if epoch > s.spec.SlotToEpoch(currentSlot) {
+		s.logger.Warn().Uint64("epoch", epoch).Uint64("current_slot", currentSlot).Msg("requested future epoch")
+		return nil, errors.ErrFutureEpoch
+	}
+
+	duties, err := s.getProposerDuties(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	due := duties[:0:0]
+	for _, duty := range duties {
+		if duty.Slot < currentSlot {
+			due = append(due, duty)
+		}
+	}
+
+	results, err := fanout.MapConcurrent(ctx, due, s.fanoutConcurrency, func(ctx context.Context, duty domain.ProposerReward) (domain.ProposerReward, error) {
+		reward, err := s.GetBlockReward(ctx, duty.Slot)
+		if errors.IsNotFound(err) {
+			duty.Reward = new(big.Int)
+			return duty, nil
+		}
+		if err != nil {
+			return duty, err
+		}
+
+		duty.Proposed = true
+		duty.Status = reward.Status
+		duty.Reward = reward.Reward
+		return duty, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := make([]domain.ProposerReward, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to get reward for slot %d: %w", due[i].Slot, result.Err)
+		}
+		rewards[i] = result.Value
+	}
+
+	return rewards, nil
+}
+
+// getProposerDuties returns the epoch's schedule as entries with only the
+// slot and proposer filled in.
+func (s *validatorService) getProposerDuties(ctx context.Context, epoch uint64) ([]domain.ProposerReward, error) {
+	cacheKey := s.keys.proposerDutiesKey(epoch)
+	if s.cache != nil && !CacheBypassed(ctx) {
+		if cached, found := s.cache.Get(cacheKey); found {
+			return cached.([]domain.ProposerReward), nil
+		}
+	}
+
+	duties, err := s.ethClient.GetProposerDuties(ctx, epoch)
+	if err != nil {
+		s.logger.Error().Err(err).Uint64("epoch", epoch).Msg("failed to get proposer duties")
+		return nil, fmt.Errorf("failed to get proposer duties: %w", err)
+	}
+
+	schedule := make([]domain.ProposerReward, len(duties))
+	for i, duty := range duties {
+		slot, err := strconv.ParseUint(duty.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.UpstreamDataError{Field: "slot", Value: duty.Slot, Reason: "not a decimal integer"}
+		}
+		index, err := strconv.ParseUint(duty.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.UpstreamDataError{Field: "validator_index", Value: duty.ValidatorIndex, Reason: "not a decimal integer"}
+		}
+		schedule[i] = domain.ProposerReward{Slot: slot, ValidatorIndex: index, Pubkey: duty.Pubkey}
+	}
+
+	if s.cache != nil {
+		s.cache.Set(cacheKey, schedule)
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorService_GetEpochProposerRewards(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 100)
+	defer memCache.Close()
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetProposerDuties", mock.Anything, uint64(10)).Return([]ethereum.ProposerDuty{
+		{Pubkey: "0xaa", ValidatorIndex: "1", Slot: "320"},
+		{Pubkey: "0xbb", ValidatorIndex: "2", Slot: "321"},
+		{Pubkey: "0xcc", ValidatorIndex: "3", Slot: "322"},
+	}, nil).Once()
+
+	client.On("GetBlockBySlot", mock.Anything, uint64(320)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(320)).Return(&ethereum.BlockRewards{Total: "100"}, nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(321)).Return(nil, pkgerrors.ErrSlotNotFound)
+	client.On("GetBlockBySlot", mock.Anything, uint64(322)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(322)).Return(&ethereum.BlockRewards{Total: "300"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{})
+	require.NoError(t, err)
+
+	rewards, err := svc.GetEpochProposerRewards(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, rewards, 3)
+
+	assert.Equal(t, uint64(320), rewards[0].Slot)
+	assert.True(t, rewards[0].Proposed)
+	assert.Equal(t, domain.StatusVanilla, rewards[0].Status)
+	assert.Equal(t, "100000000000", rewards[0].Reward.String())
+
+	assert.Equal(t, uint64(321), rewards[1].Slot)
+	assert.Equal(t, uint64(2), rewards[1].ValidatorIndex)
+	assert.Equal(t, "0xbb", rewards[1].Pubkey)
+	assert.False(t, rewards[1].Proposed)
+	assert.Empty(t, rewards[1].Status)
+	assert.Equal(t, 0, rewards[1].Reward.Sign())
+
+	assert.True(t, rewards[2].Proposed)
+	assert.Equal(t, "300000000000", rewards[2].Reward.String())
+
+	// Duties and proposed blocks come from the cache the second time.
+	_, err = svc.GetEpochProposerRewards(context.Background(), 10)
+	require.NoError(t, err)
+	client.AssertNumberOfCalls(t, "GetProposerDuties", 1)
+	client.AssertNumberOfCalls(t, "GetBlockRewards", 2)
+}
+
+func TestValidatorService_GetEpochProposerRewards_Errors(t *testing.T) {
+	t.Run("future epoch", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(100), nil)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		_, err = svc.GetEpochProposerRewards(context.Background(), 4)
+		assert.ErrorIs(t, err, pkgerrors.ErrFutureEpoch)
+		client.AssertNotCalled(t, "GetProposerDuties", mock.Anything, mock.Anything)
+	})
+
+	t.Run("current epoch skips the current and upcoming slots", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(97), nil)
+		client.On("GetProposerDuties", mock.Anything, uint64(3)).Return([]ethereum.ProposerDuty{
+			{Pubkey: "0xaa", ValidatorIndex: "1", Slot: "96"},
+			{Pubkey: "0xbb", ValidatorIndex: "2", Slot: "97"},
+			{Pubkey: "0xcc", ValidatorIndex: "3", Slot: "98"},
+		}, nil)
+		client.On("GetBlockBySlot", mock.Anything, uint64(96)).Return(testBlock(), nil)
+		client.On("GetBlockRewards", mock.Anything, uint64(96)).Return(&ethereum.BlockRewards{Total: "1"}, nil)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		rewards, err := svc.GetEpochProposerRewards(context.Background(), 3)
+		require.NoError(t, err)
+		require.Len(t, rewards, 1)
+		assert.Equal(t, uint64(96), rewards[0].Slot)
+	})
+
+	t.Run("upstream failure", func(t *testing.T) {
+		upstream := errors.New("connection refused")
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+		client.On("GetProposerDuties", mock.Anything, uint64(10)).Return([]ethereum.ProposerDuty{
+			{Pubkey: "0xaa", ValidatorIndex: "1", Slot: "320"},
+		}, nil)
+		client.On("GetBlockBySlot", mock.Anything, uint64(320)).Return(nil, upstream)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		_, err = svc.GetEpochProposerRewards(context.Background(), 10)
+		assert.ErrorIs(t, err, upstream)
+	})
+
+	t.Run("malformed duty", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+		client.On("GetProposerDuties", mock.Anything, uint64(10)).Return([]ethereum.ProposerDuty{
+			{Pubkey: "0xaa", ValidatorIndex: "1", Slot: "abc"},
+		}, nil)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		_, err = svc.GetEpochProposerRewards(context.Background(), 10)
+		var dataErr pkgerrors.UpstreamDataError
+		assert.ErrorAs(t, err, &dataErr)
+	})
+}
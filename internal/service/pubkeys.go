@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// pubkeyBatchSize caps the indices looked up in one beacon call, keeping the
+// query string to a reasonable length.
+const pubkeyBatchSize = 100
+
+// ResolvePubkey resolves a validator index to its pubkey. Pubkeys are
+// immutable, so hits are cached until the pubkey cache's TTL expires them.
+func (s *validatorService) ResolvePubkey(ctx context.Context, index uint64) (string, error) {
+	cacheKey := s.keys.validatorPubkeyKey(index)
+	if s.pubkeyCache != nil && !CacheBypassed(ctx) {
+		if cached, found := s.pubkeyCache.Get(cacheKey); found {
+			return cached.(string), nil
+		}
+	}
+
+	pubkey, err := s.ethClient.GetValidatorPubkey(ctx, index)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", errors.ErrValidatorNotFound
+		}
+		s.logger.Error().Err(err).Uint64("validator_index", index).Msg("failed to get validator")
+		return "", fmt.Errorf("failed to get validator: %w", err)
+	}
+
+	if s.pubkeyCache != nil {
+		s.pubkeyCache.Set(cacheKey, pubkey)
+	}
+
+	return pubkey, nil
+}
+
+// ResolvePubkeys is ResolvePubkey for several indices. Indices missing from
+// the cache are looked up together, pubkeyBatchSize per beacon call. Unknown
+// validators are left out of the result.
+func (s *validatorService) ResolvePubkeys(ctx context.Context, indices []uint64) (map[uint64]string, error) {
+	pubkeys := make(map[uint64]string, len(indices))
+	seen := make(map[uint64]struct{}, len(indices))
+
+	var missing []uint64
+	for _, index := range indices {
+		if _, ok := seen[index]; ok {
+			continue
+		}
+		seen[index] = struct{}{}
+
+		if s.pubkeyCache != nil && !CacheBypassed(ctx) {
+			if cached, found := s.pubkeyCache.Get(s.keys.validatorPubkeyKey(index)); found {
+				pubkeys[index] = cached.(string)
+				continue
+			}
+		}
+		missing = append(missing, index)
+	}
+
+	for start := 0; start < len(missing); start += pubkeyBatchSize {
+		batch := missing[start:min(start+pubkeyBatchSize, len(missing))]
+
+		fetched, err := s.ethClient.GetValidatorPubkeys(ctx, batch)
+		if err != nil {
+			s.logger.Error().Err(err).Int("validators", len(batch)).Msg("failed to get validators")
+			return nil, fmt.Errorf("failed to get validators: %w", err)
+		}
+
+		for index, pubkey := range fetched {
+			pubkeys[index] = pubkey
+			if s.pubkeyCache != nil {
+				s.pubkeyCache.Set(s.keys.validatorPubkeyKey(index), pubkey)
+			}
+		}
+	}
+
+	return pubkeys, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorService_ResolvePubkey_DedicatedCache(t *testing.T) {
+	pubkeys := cache.NewMemoryCache(time.Hour, 100)
+	defer pubkeys.Close()
+
+	client := new(mockEthClient)
+	client.On("GetValidatorPubkey", mock.Anything, uint64(4242)).Return("0xabcd", nil).Once()
+
+	// The shared cache has no expectations: pubkeys must not touch it.
+	shared := new(mockCache)
+
+	svc, err := NewValidatorService(client, logger.New("error"), shared, ServiceConfig{PubkeyCache: pubkeys})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		pubkey, err := svc.ResolvePubkey(context.Background(), 4242)
+		require.NoError(t, err)
+		assert.Equal(t, "0xabcd", pubkey)
+	}
+
+	client.AssertExpectations(t)
+	shared.AssertExpectations(t)
+}
+
+func TestValidatorService_ResolvePubkeys(t *testing.T) {
+	pubkeys := cache.NewMemoryCache(time.Hour, 100)
+	defer pubkeys.Close()
+
+	client := new(mockEthClient)
+	client.On("GetValidatorPubkey", mock.Anything, uint64(1)).Return("0x01", nil).Once()
+	client.On("GetValidatorPubkeys", mock.Anything, []uint64{2, 3, 99}).Return(map[uint64]string{2: "0x02", 3: "0x03"}, nil).Once()
+	client.On("GetValidatorPubkeys", mock.Anything, []uint64{99}).Return(map[uint64]string{}, nil).Once()
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{PubkeyCache: pubkeys})
+	require.NoError(t, err)
+
+	_, err = svc.ResolvePubkey(context.Background(), 1)
+	require.NoError(t, err)
+
+	// 1 is cached already, the rest are fetched in one call and duplicates
+	// are only asked for once.
+	resolved, err := svc.ResolvePubkeys(context.Background(), []uint64{1, 2, 3, 2, 99})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]string{1: "0x01", 2: "0x02", 3: "0x03"}, resolved)
+
+	// Everything known now comes from the cache; only the unknown validator
+	// is asked for again.
+	resolved, err = svc.ResolvePubkeys(context.Background(), []uint64{3, 2, 1, 99})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]string{1: "0x01", 2: "0x02", 3: "0x03"}, resolved)
+
+	client.AssertExpectations(t)
+}
+
+func TestValidatorService_ResolvePubkeys_Batches(t *testing.T) {
+	indices := make([]uint64, pubkeyBatchSize+20)
+	for i := range indices {
+		indices[i] = uint64(i)
+	}
+
+	pubkeysOf := func(indices []uint64) map[uint64]string {
+		result := map[uint64]string{}
+		for _, index := range indices {
+			result[index] = fmt.Sprintf("0x%02x", index)
+		}
+		return result
+	}
+
+	first, rest := indices[:pubkeyBatchSize], indices[pubkeyBatchSize:]
+	client := new(mockEthClient)
+	client.On("GetValidatorPubkeys", mock.Anything, first).Return(pubkeysOf(first), nil).Once()
+	client.On("GetValidatorPubkeys", mock.Anything, rest).Return(pubkeysOf(rest), nil).Once()
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	resolved, err := svc.ResolvePubkeys(context.Background(), indices)
+	require.NoError(t, err)
+	assert.Len(t, resolved, len(indices))
+	assert.Equal(t, "0x77", resolved[119])
+
+	client.AssertExpectations(t)
+}
+
+func TestValidatorService_ResolvePubkeys_Error(t *testing.T) {
+	upstream := errors.New("connection refused")
+
+	client := new(mockEthClient)
+	client.On("GetValidatorPubkeys", mock.Anything, []uint64{1}).Return(nil, upstream)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	_, err = svc.ResolvePubkeys(context.Background(), []uint64{1})
+	assert.ErrorIs(t, err, upstream)
+}
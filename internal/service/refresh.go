@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+)
+
+// expiringCache is implemented by caches that expose entry expiry, which
+// refresh-ahead needs.
+type expiringCache interface {
+	GetWithExpiration(key string) (interface{}, time.Time, bool)
+}
+
+// maybeRefreshBlockReward refetches a cached reward in the background when it
+// wasn't finalized when cached and is about to expire, so the next request
+// doesn't pay for a synchronous miss. At most one refresh per key runs at a
+// time.
+func (s *validatorService) maybeRefreshBlockReward(slot uint64, entry cacheEntry) {
+	if s.refreshWindow <= 0 || entry.finalized {
+		return
+	}
+
+	cache, ok := s.cache.(expiringCache)
+	if !ok {
+		return
+	}
+
+	cacheKey := s.keys.blockRewardKey(slot)
+	_, expiration, found := cache.GetWithExpiration(cacheKey)
+	if !found || s.entryExpiration(entry, expiration).Sub(s.now()) > s.refreshWindow {
+		return
+	}
+
+	if _, running := s.refreshing.LoadOrStore(cacheKey, struct{}{}); running {
+		return
+	}
+
+	// The refresh outlives the request that triggered it, but not the
+	// service.
+	reward := entry.value.(*domain.BlockReward)
+	started := s.goBackground(func(ctx context.Context) {
+		defer s.refreshing.Delete(cacheKey)
+
+		refreshed, err := s.fetchBlockReward(ctx, slot)
+		if err != nil {
+			s.logger.Warn().Err(err).Uint64("slot", slot).Msg("background block reward refresh failed")
+			return
+		}
+
+		s.logger.Debug().
+			Uint64("slot", slot).
+			Bool("changed", !reward.Equal(refreshed)).
+			Msg("refreshed block reward ahead of expiry")
+	})
+	if !started {
+		s.refreshing.Delete(cacheKey)
+	}
+}
+
+// goBackground runs fn in a goroutine under the service's context, which
+// Close cancels before waiting for fn to return. It reports false, without
+// running fn, once the service is closed.
+func (s *validatorService) goBackground(fn func(ctx context.Context)) bool {
+	s.backgroundMu.Lock()
+	defer s.backgroundMu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return false
+	}
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		fn(s.ctx)
+	}()
+	return true
+}
+
+// Close cancels background work and waits for it to return. The service
+// keeps serving requests, without starting new background work.
+func (s *validatorService) Close() {
+	s.backgroundMu.Lock()
+	s.cancel()
+	s.backgroundMu.Unlock()
+
+	s.background.Wait()
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/matheus/eth-validator-api/pkg/beaconmath"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+const maxReorgInvalidationDepth = 1024
+
+type ReorgWatcher struct {
+	ethClient      ethereum.Client
+	logger         logger.Logger
+	cache          Cache
+	keys           cacheKeys
+	reconnectDelay time.Duration
+	spec           beaconmath.Spec
+}
+
+func NewReorgWatcher(ethClient ethereum.Client, logger logger.Logger, cache Cache, keyPrefix string, reconnectDelay time.Duration, slotsPerEpoch uint64) (*ReorgWatcher, error) {
+	if ethClient == nil {
+		return nil, fmt.Errorf("ethereum client is required")
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if cache == nil {
+		return nil, fmt.Errorf("cache is required")
+	}
+
+	return &ReorgWatcher{
+		ethClient:      ethClient,
+		logger:         logger,
+		cache:          cache,
+		keys:           cacheKeys{prefix: keyPrefix},
+		reconnectDelay: reconnectDelay,
+		spec:           beaconmath.NewSpec(0, slotsPerEpoch, 0),
+	}, nil
+}
+
+// Run subscribes to chain_reorg events until ctx is cancelled, reconnecting
+// whenever the upstream stream drops.
+func (w *ReorgWatcher) Run(ctx context.Context) {
+	topics := []string{ethereum.TopicChainReorg}
+
+	for {
+		err := w.ethClient.SubscribeEvents(ctx, topics, w.handleEvent)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.logger.Warn().Err(err).Dur("reconnect_delay", w.reconnectDelay).Msg("reorg event stream disconnected")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.reconnectDelay):
+		}
+	}
+}
+
+func (w *ReorgWatcher) handleEvent(event ethereum.Event) {
+	if event.Topic != ethereum.TopicChainReorg {
+		return
+	}
+
+	var reorg ethereum.ChainReorgEvent
+	if err := json.Unmarshal(event.Data, &reorg); err != nil {
+		w.logger.Error().Err(err).Msg("failed to decode chain_reorg event")
+		return
+	}
+
+	slot, err := strconv.ParseUint(reorg.Slot, 10, 64)
+	if err != nil {
+		w.logger.Error().Err(err).Str("slot", reorg.Slot).Msg("invalid slot in chain_reorg event")
+		return
+	}
+
+	depth, err := strconv.ParseUint(reorg.Depth, 10, 64)
+	if err != nil {
+		w.logger.Error().Err(err).Str("depth", reorg.Depth).Msg("invalid depth in chain_reorg event")
+		return
+	}
+
+	w.invalidate(slot, depth)
+}
+
+func (w *ReorgWatcher) invalidate(slot, depth uint64) {
+	if depth > maxReorgInvalidationDepth {
+		depth = maxReorgInvalidationDepth
+	}
+
+	from := uint64(0)
+	if slot > depth {
+		from = slot - depth
+	}
+
+	for s := from; s <= slot; s++ {
+		w.cache.Delete(w.keys.blockRewardKey(s))
+		w.cache.Delete(w.keys.slotStatusKey(s))
+	}
+
+	fromEpoch, toEpoch := w.spec.SlotToEpoch(from), w.spec.SlotToEpoch(slot)
+	// Proposer shuffling depends on the previous epoch's RANDAO, so the
+	// schedule of the epoch after the reorged range can change too.
+	for e := fromEpoch; e <= toEpoch+1; e++ {
+		w.cache.Delete(w.keys.proposerDutiesKey(e))
+	}
+
+	fromPeriod := w.spec.EpochToSyncCommitteePeriod(fromEpoch)
+	toPeriod := w.spec.EpochToSyncCommitteePeriod(toEpoch)
+	for p := fromPeriod; p <= toPeriod; p++ {
+		w.cache.Delete(w.keys.syncDutiesKey(p))
+		w.cache.Delete(w.keys.syncDutiesNextKey(p))
+	}
+
+	w.logger.Info().
+		Uint64("slot", slot).
+		Uint64("depth", depth).
+		Uint64("from_slot", from).
+		Msg("invalidated cache after chain reorg")
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestReorgWatcher_InvalidatesAffectedSlots(t *testing.T) {
+	client := new(mockEthClient)
+	cache := new(mockCache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	event := ethereum.Event{
+		Topic: ethereum.TopicChainReorg,
+		Data:  json.RawMessage(`{"slot":"100","depth":"2","old_head_block":"0xaa","new_head_block":"0xbb","epoch":"3"}`),
+	}
+
+	client.On("SubscribeEvents", mock.Anything, []string{ethereum.TopicChainReorg}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(func(ethereum.Event))(event)
+			cancel()
+		}).
+		Return(context.Canceled)
+
+	for _, slot := range []string{"98", "99", "100"} {
+		cache.On("Delete", "block_reward:"+slot).Once()
+		cache.On("Delete", "slot_status:"+slot).Once()
+	}
+	cache.On("Delete", "proposer_duties:3").Once()
+	cache.On("Delete", "proposer_duties:4").Once()
+	cache.On("Delete", "sync_duties_period:0").Once()
+	cache.On("Delete", "sync_duties_next_period:0").Once()
+
+	watcher, err := NewReorgWatcher(client, logger.New("error"), cache, "", time.Millisecond, 0)
+	require.NoError(t, err)
+
+	watcher.Run(ctx)
+
+	client.AssertExpectations(t)
+	cache.AssertExpectations(t)
+	cache.AssertNotCalled(t, "Delete", "block_reward:97")
+	cache.AssertNotCalled(t, "Delete", "block_reward:101")
+}
+
+func TestReorgWatcher_IgnoresOtherTopics(t *testing.T) {
+	cache := new(mockCache)
+
+	watcher, err := NewReorgWatcher(new(mockEthClient), logger.New("error"), cache, "", time.Millisecond, 0)
+	require.NoError(t, err)
+
+	watcher.handleEvent(ethereum.Event{Topic: "head", Data: json.RawMessage(`{"slot":"100"}`)})
+
+	cache.AssertNotCalled(t, "Delete", mock.Anything)
+}
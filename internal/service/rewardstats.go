@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/fanout"
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// GetBlockRewardStats aggregates the block rewards of slots from through to.
+// Each slot is fetched and cached as by GetBlockReward; missed slots are
+// counted but left out of the statistics, as is the genesis slot, which has
+// no proposer. Any other failure fails the whole range. The stats are Stale
+// if any reward was served stale.
+func (s *validatorService) GetBlockRewardStats(ctx context.Context, from, to uint64) (*domain.BlockRewardStats, error) {
+	results, err := fanout.MapRange(ctx, from, to, s.fanoutConcurrency, s.GetBlockReward)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.BlockRewardStats{From: from, To: to}
+
+	rewards := make([]*big.Int, 0, len(results))
+	for i, result := range results {
+		switch {
+		case errors.IsNotFound(result.Err):
+			stats.Missed++
+		case result.Err != nil:
+			return nil, fmt.Errorf("failed to get block reward for slot %d: %w", from+uint64(i), result.Err)
+		case result.Value.Genesis:
+		default:
+			stats.Proposed++
+			stats.Stale = stats.Stale || result.Value.Stale
+			rewards = append(rewards, result.Value.Reward)
+		}
+	}
+
+	if len(rewards) == 0 {
+		return stats, nil
+	}
+
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+
+	total := new(big.Int)
+	for _, reward := range rewards {
+		total.Add(total, reward)
+	}
+
+	n := len(rewards)
+	stats.Total = total
+	stats.Mean = roundRat(new(big.Rat).SetFrac(total, big.NewInt(int64(n))))
+	stats.Min = new(big.Int).Set(rewards[0])
+	stats.Max = new(big.Int).Set(rewards[n-1])
+	if n%2 == 1 {
+		stats.Median = new(big.Int).Set(rewards[n/2])
+	} else {
+		middle := new(big.Int).Add(rewards[n/2-1], rewards[n/2])
+		stats.Median = roundRat(new(big.Rat).SetFrac(middle, big.NewInt(2)))
+	}
+
+	return stats, nil
+}
+
+// roundRat rounds r to the nearest integer, halves away from zero.
+func roundRat(r *big.Rat) *big.Int {
+	num := new(big.Int).Mul(r.Num(), big.NewInt(2))
+	den := new(big.Int).Mul(r.Denom(), big.NewInt(2))
+	if r.Sign() < 0 {
+		num.Sub(num, r.Denom())
+	} else {
+		num.Add(num, r.Denom())
+	}
+	return num.Quo(num, den)
+}
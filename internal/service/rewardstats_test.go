@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorService_GetBlockRewardStats(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 100)
+	defer memCache.Close()
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12)).Return(nil, pkgerrors.ErrSlotNotFound)
+	for slot, total := range map[uint64]string{10: "300", 11: "100", 13: "1000000000000000000000001", 14: "200"} {
+		client.On("GetBlockBySlot", mock.Anything, slot).Return(testBlock(), nil).Once()
+		client.On("GetBlockRewards", mock.Anything, slot).Return(&ethereum.BlockRewards{Total: total}, nil).Once()
+	}
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{})
+	require.NoError(t, err)
+
+	stats, err := svc.GetBlockRewardStats(context.Background(), 10, 14)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), stats.From)
+	assert.Equal(t, uint64(14), stats.To)
+	assert.Equal(t, 4, stats.Proposed)
+	assert.Equal(t, 1, stats.Missed)
+	assert.Equal(t, "1000000000000000000000601000000000", stats.Total.String())
+	assert.Equal(t, "250000000000000000000150250000000", stats.Mean.String())
+	assert.Equal(t, "250000000000", stats.Median.String())
+	assert.Equal(t, "100000000000", stats.Min.String())
+	assert.Equal(t, "1000000000000000000000001000000000", stats.Max.String())
+
+	// Proposed slots are cached; only the missed one is fetched again.
+	stats, err = svc.GetBlockRewardStats(context.Background(), 11, 13)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Proposed)
+	assert.Equal(t, 1, stats.Missed)
+	assert.Equal(t, "500000000000000000000050500000000", stats.Median.String())
+	client.AssertExpectations(t)
+}
+
+func TestValidatorService_GetBlockRewardStats_NoBlocks(t *testing.T) {
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(nil, pkgerrors.ErrSlotNotFound)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	stats, err := svc.GetBlockRewardStats(context.Background(), 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Proposed)
+	assert.Equal(t, 1, stats.Missed)
+	assert.Nil(t, stats.Mean)
+	assert.Nil(t, stats.Median)
+}
+
+func TestValidatorService_GetBlockRewardStats_Failure(t *testing.T) {
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(5), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(5)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(5)).Return(&ethereum.BlockRewards{Total: "1"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	_, err = svc.GetBlockRewardStats(context.Background(), 5, 6)
+	assert.ErrorIs(t, err, pkgerrors.ErrFutureSlot)
+	assert.Contains(t, err.Error(), "slot 6")
+}
+
+func TestValidatorService_GetBlockRewardStats_Stale(t *testing.T) {
+	unavailable := pkgerrors.BeaconHTTPError{StatusCode: 503, Body: "down"}
+
+	staleCache := cache.NewMemoryCache(time.Hour, 10)
+	defer staleCache.Close()
+	staleCache.Set("block_reward:1", cacheEntry{value: &domain.BlockReward{Reward: big.NewInt(7), Finalized: true}, finalized: true, fetchedAt: testNow})
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(nil, fmt.Errorf("failed to get block: %w", unavailable))
+	client.On("GetBlockBySlot", mock.Anything, uint64(2)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(2)).Return(&ethereum.BlockRewards{Total: "1"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{StaleCache: staleCache})
+	require.NoError(t, err)
+
+	stats, err := svc.GetBlockRewardStats(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.True(t, stats.Stale)
+	assert.Equal(t, 2, stats.Proposed)
+
+	stats, err = svc.GetBlockRewardStats(context.Background(), 2, 2)
+	require.NoError(t, err)
+	assert.False(t, stats.Stale)
+}
+
+func TestRoundRat(t *testing.T) {
+	for _, tt := range []struct {
+		num, den int64
+		want     string
+	}{
+		{5, 2, "3"},
+		{7, 3, "2"},
+		{-5, 2, "-3"},
+		{4, 2, "2"},
+	} {
+		assert.Equal(t, tt.want, roundRat(big.NewRat(tt.num, tt.den)).String())
+	}
+}
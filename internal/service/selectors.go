@@ -0,0 +1,79 @@
+package service
+
+// mevSelectorLen is the length of a 0x-prefixed 4-byte selector.
+const mevSelectorLen = len("0x") + 8
+
+var mevSelectors = newSelectorSet("0xa22cb465", "0x095ea7b3", "0x23b872dd")
+
+// selectorSet buckets selectors by their first byte so that almost every
+// transaction is rejected with a single array lookup.
+type selectorSet struct {
+	byLeadByte [256][]string
+	selectors  []string
+}
+
+func newSelectorSet(selectors ...string) *selectorSet {
+	set := &selectorSet{selectors: selectors}
+	for _, selector := range selectors {
+		if len(selector) != mevSelectorLen {
+			panic("invalid selector: " + selector)
+		}
+		lead, ok := hexByte(selector[2], selector[3])
+		if !ok {
+			panic("invalid selector: " + selector)
+		}
+		set.byLeadByte[lead] = append(set.byLeadByte[lead], selector)
+	}
+	return set
+}
+
+// match reports whether txHex starts with one of the selectors. Like a plain
+// prefix check it's case sensitive.
+func (s *selectorSet) match(txHex string) bool {
+	if len(txHex) < mevSelectorLen || txHex[0] != '0' || txHex[1] != 'x' {
+		return false
+	}
+
+	lead, ok := hexByte(txHex[2], txHex[3])
+	if !ok {
+		return false
+	}
+
+	prefix := txHex[:mevSelectorLen]
+	for _, selector := range s.byLeadByte[lead] {
+		if prefix == selector {
+			return true
+		}
+	}
+
+	return false
+}
+
+// list returns the selectors in the order they were given.
+func (s *selectorSet) list() []string {
+	return append([]string(nil), s.selectors...)
+}
+
+func hexByte(hi, lo byte) (byte, bool) {
+	h, ok := hexNibble(hi)
+	if !ok {
+		return 0, false
+	}
+	l, ok := hexNibble(lo)
+	if !ok {
+		return 0, false
+	}
+	return h<<4 | l, true
+}
+
+func hexNibble(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+// linearIsMEVTransaction is the original prefix scan, kept as the reference
+// the selector set must agree with.
+func linearIsMEVTransaction(txHex string) bool {
+	if len(txHex) < 10 {
+		return false
+	}
+
+	for _, pattern := range []string{"0xa22cb465", "0x095ea7b3", "0x23b872dd"} {
+		if strings.HasPrefix(txHex, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func blockWithTransactions(feeRecipient string, txs []string) *ethereum.BeaconBlock {
+	return &ethereum.BeaconBlock{
+		Data: ethereum.BeaconBlockData{
+			Message: ethereum.BlockMessage{
+				Body: ethereum.BlockBody{
+					ExecutionPayload: &ethereum.ExecutionPayload{
+						FeeRecipient: feeRecipient,
+						Transactions: txs,
+					},
+				},
+			},
+		},
+	}
+}
+
+// largeVanillaTransactions returns n transactions none of which match a MEV
+// selector, forcing a full scan.
+func largeVanillaTransactions(n int) []string {
+	txs := make([]string, n)
+	for i := range txs {
+		txs[i] = fmt.Sprintf("0x02f8%06x%s", i, strings.Repeat("ab", 100))
+	}
+	return txs
+}
+
+func TestIsMEVTransaction_MatchesLinearScan(t *testing.T) {
+	svc := &validatorService{}
+
+	inputs := []string{
+		"",
+		"0x",
+		"0xa22cb46",
+		"0xa22cb465",
+		"0xa22cb465deadbeef",
+		"0x095ea7b3",
+		"0x23b872dd00",
+		"0xA22CB465",
+		"0x02f8b0",
+		"a22cb465aaaa",
+		"0x0a22cb465",
+		"0xa22cb46g",
+		"0xzz2cb465",
+		"0X23b872dd",
+	}
+	inputs = append(inputs, largeVanillaTransactions(20)...)
+
+	for _, tx := range inputs {
+		assert.Equal(t, linearIsMEVTransaction(tx), svc.isMEVTransaction(tx), tx)
+	}
+}
+
+func TestDetermineBlockStatus_LargePayload(t *testing.T) {
+	svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), nil, ServiceConfig{
+		MEVRelays: []string{"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"},
+	})
+	require.NoError(t, err)
+	s := svc.(*validatorService)
+
+	txs := largeVanillaTransactions(500)
+	assert.Equal(t, domain.StatusVanilla, s.determineBlockStatus(blockWithTransactions("0x1234567890123456789012345678901234567890", txs)))
+	assert.Equal(t, domain.StatusMEV, s.determineBlockStatus(blockWithTransactions("0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5", txs)))
+
+	withMEV := append(append([]string{}, txs...), "0x23b872dd0000")
+	assert.Equal(t, domain.StatusMEV, s.determineBlockStatus(blockWithTransactions("0x1234567890123456789012345678901234567890", withMEV)))
+}
+
+func BenchmarkDetermineBlockStatus_500Transactions(b *testing.B) {
+	svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), nil, ServiceConfig{})
+	require.NoError(b, err)
+	s := svc.(*validatorService)
+
+	txs := largeVanillaTransactions(500)
+	block := blockWithTransactions("0x1234567890123456789012345678901234567890", txs)
+
+	b.Run("status", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s.determineBlockStatus(block)
+		}
+	})
+
+	b.Run("selector set scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, tx := range txs {
+				if s.isMEVTransaction(tx) {
+					break
+				}
+			}
+		}
+	})
+
+	b.Run("linear scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, tx := range txs {
+				if linearIsMEVTransaction(tx) {
+					break
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/internal/fanout"
+)
+
+// GetSyncCommitteesByPeriodRange returns the committees of periods from
+// through to, in order. Each period is fetched and cached as by
+// GetSyncCommitteeByPeriod, so the whole range fails if any period does.
+func (s *validatorService) GetSyncCommitteesByPeriodRange(ctx context.Context, from, to uint64) ([]*domain.SyncCommitteeDuties, error) {
+	results, err := fanout.MapRange(ctx, from, to, s.fanoutConcurrency, s.GetSyncCommitteeByPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	committees := make([]*domain.SyncCommitteeDuties, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to get sync committee for period %d: %w", from+uint64(i), result.Err)
+		}
+		committees[i] = result.Value
+	}
+
+	return committees, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/cache"
+	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestValidatorService_GetSyncCommitteesByPeriodRange(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 100)
+	defer memCache.Close()
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetSyncCommittee", mock.Anything, uint64(8192)).Return([]string{"0x01"}, nil).Once()
+	client.On("GetSyncCommittee", mock.Anything, uint64(16384)).Return([]string{"0x02"}, nil).Once()
+	client.On("GetSyncCommittee", mock.Anything, uint64(24576)).Return([]string{"0x03"}, nil).Once()
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{})
+	require.NoError(t, err)
+
+	committees, err := svc.GetSyncCommitteesByPeriodRange(context.Background(), 1, 3)
+	require.NoError(t, err)
+	require.Len(t, committees, 3)
+	for i, committee := range committees {
+		assert.Equal(t, uint64(i+1), committee.Period)
+	}
+	assert.Equal(t, []string{"0x02"}, committees[1].Validators)
+
+	// A second, overlapping range is served from the cache.
+	committees, err = svc.GetSyncCommitteesByPeriodRange(context.Background(), 2, 3)
+	require.NoError(t, err)
+	assert.Len(t, committees, 2)
+	client.AssertExpectations(t)
+
+	_, err = svc.GetSyncCommitteesByPeriodRange(context.Background(), 3, 4)
+	assert.ErrorIs(t, err, pkgerrors.ErrPeriodTooFar)
+}
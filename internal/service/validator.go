@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/beaconmath"
 	"github.com/matheus/eth-validator-api/pkg/errors"
 	"github.com/matheus/eth-validator-api/pkg/ethereum"
 	"github.com/matheus/eth-validator-api/pkg/logger"
@@ -15,21 +18,108 @@ import (
 
 type ValidatorService interface {
 	GetBlockReward(ctx context.Context, slot uint64) (*domain.BlockReward, error)
-	GetSyncCommitteeDuties(ctx context.Context, slot uint64) (*domain.SyncCommitteeDuties, error)
+	GetBlockRewardStats(ctx context.Context, from, to uint64) (*domain.BlockRewardStats, error)
+	GetSlotStatus(ctx context.Context, slot uint64) (*domain.SlotStatus, error)
+	GetBlock(ctx context.Context, slot uint64) (*domain.Block, error)
+	GetSyncCommitteeDuties(ctx context.Context, slot uint64, opts SyncDutiesOptions) (*domain.SyncCommitteeDuties, error)
+	GetSyncCommitteeByPeriod(ctx context.Context, period uint64) (*domain.SyncCommitteeDuties, error)
+	GetSyncCommitteesByPeriodRange(ctx context.Context, from, to uint64) ([]*domain.SyncCommitteeDuties, error)
+	ResolvePubkey(ctx context.Context, index uint64) (string, error)
+	ResolvePubkeys(ctx context.Context, indices []uint64) (map[uint64]string, error)
+	GetSyncCommitteeAtState(ctx context.Context, stateID string) (*domain.SyncCommitteeAtState, error)
+	StreamEvents(ctx context.Context, topics []string, fn func(domain.Event)) error
+	GetEpochProposerRewards(ctx context.Context, epoch uint64) ([]domain.ProposerReward, error)
+	GetBlockHeaderByStateRoot(ctx context.Context, slot uint64, stateRoot string) (*domain.BeaconHeader, error)
+	MEVRelays() domain.MEVRelays
+	// Close cancels background work, such as refresh-ahead fetches, and
+	// waits for it to return.
+	Close()
+}
+
+type SyncDutiesOptions struct {
+	// IncludeNext also returns the committee of the following period.
+	IncludeNext bool
 }
 
 type validatorService struct {
 	ethClient ethereum.Client
 	logger    logger.Logger
 	cache     Cache
+	// pubkeyCache holds resolved validator pubkeys. Entries still expire
+	// with the cache's TTL, CACHE_PUBKEY_TTL for a dedicated one; it's the
+	// shared cache unless a dedicated one is configured.
+	pubkeyCache Cache
+	staleCache  Cache
+	keys        cacheKeys
+	mevRelays   map[string]struct{}
+
+	estimateRewards bool
+	refreshWindow   time.Duration
+	refreshing      sync.Map
+	unfinalizedTTL  time.Duration
+	now             func() time.Time
+
+	// ctx is cancelled by Close; background holds the goroutines running
+	// under it, started through goBackground.
+	ctx          context.Context
+	cancel       context.CancelFunc
+	backgroundMu sync.Mutex
+	background   sync.WaitGroup
+
+	eventReconnectDelay time.Duration
+	eventRewardTimeout  time.Duration
+	spec                beaconmath.Spec
+	fanoutConcurrency   int
+}
+
+type ServiceConfig struct {
+	// MEVRelays are the fee recipients treated as MEV relays. Without any,
+	// blocks are classified by their transactions alone.
+	MEVRelays []string
+	// EstimateRewards approximates the reward from the block contents when
+	// the beacon node doesn't implement the block rewards endpoint.
+	EstimateRewards bool
+	// CacheKeyPrefix namespaces every cache key, e.g. by network.
+	CacheKeyPrefix string
+	// RefreshWindow refetches a cached, non-finalized block reward in the
+	// background when it's served this close to expiry. Zero disables it.
+	RefreshWindow time.Duration
+	// EventReconnectDelay is the pause before StreamEvents reconnects to a
+	// dropped beacon event stream. Zero means defaultEventReconnectDelay.
+	EventReconnectDelay time.Duration
+	// EventRewardTimeout bounds the reward lookup for each head event, which
+	// otherwise runs under the stream's open-ended context. Zero means
+	// defaultEventRewardTimeout.
+	EventRewardTimeout time.Duration
+	// SlotsPerEpoch is the network's epoch length. Zero means
+	// beaconmath.DefaultSlotsPerEpoch.
+	SlotsPerEpoch uint64
+	// FanoutConcurrency caps the upstream lookups a single request, such as
+	// an epoch's proposer rewards, runs at once. Zero means
+	// defaultFanoutConcurrency.
+	FanoutConcurrency int
+	// PubkeyCache, when set, holds resolved validator pubkeys instead of the
+	// shared cache, so they can be kept longer and apart from chain data.
+	PubkeyCache Cache
+	// StaleCache, when set, keeps a second copy of finalized block rewards
+	// that GetBlockReward falls back to while the beacon node is
+	// unavailable. It should outlive the shared cache.
+	StaleCache Cache
+	// UnfinalizedTTL expires cached block rewards and slot statuses that
+	// weren't finalized when fetched sooner than the cache's own TTL. Zero
+	// keeps them as long as finalized ones.
+	UnfinalizedTTL time.Duration
 }
 
+const defaultFanoutConcurrency = 8
+
 type Cache interface {
 	Get(key string) (interface{}, bool)
 	Set(key string, value interface{})
+	Delete(key string)
 }
 
-func NewValidatorService(ethClient ethereum.Client, logger logger.Logger, cache Cache) (ValidatorService, error) {
+func NewValidatorService(ethClient ethereum.Client, logger logger.Logger, cache Cache, cfg ServiceConfig) (ValidatorService, error) {
 	if ethClient == nil {
 		return nil, fmt.Errorf("ethereum client is required")
 	}
@@ -37,23 +127,121 @@ func NewValidatorService(ethClient ethereum.Client, logger logger.Logger, cache
 		return nil, fmt.Errorf("logger is required")
 	}
 
+	mevRelays, err := newAddressSet(cfg.MEVRelays)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEV relay address: %w", err)
+	}
+
+	eventReconnectDelay := cfg.EventReconnectDelay
+	if eventReconnectDelay <= 0 {
+		eventReconnectDelay = defaultEventReconnectDelay
+	}
+
+	eventRewardTimeout := cfg.EventRewardTimeout
+	if eventRewardTimeout <= 0 {
+		eventRewardTimeout = defaultEventRewardTimeout
+	}
+
+	fanoutConcurrency := cfg.FanoutConcurrency
+	if fanoutConcurrency <= 0 {
+		fanoutConcurrency = defaultFanoutConcurrency
+	}
+
+	pubkeyCache := cfg.PubkeyCache
+	if pubkeyCache == nil {
+		pubkeyCache = cache
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &validatorService{
-		ethClient: ethClient,
-		logger:    logger,
-		cache:     cache,
+		ethClient:   ethClient,
+		logger:      logger,
+		cache:       cache,
+		pubkeyCache: pubkeyCache,
+		staleCache:  cfg.StaleCache,
+		keys:        cacheKeys{prefix: cfg.CacheKeyPrefix},
+		mevRelays:   mevRelays,
+
+		estimateRewards: cfg.EstimateRewards,
+		refreshWindow:   cfg.RefreshWindow,
+		unfinalizedTTL:  cfg.UnfinalizedTTL,
+		now:             time.Now,
+		ctx:             ctx,
+		cancel:          cancel,
+
+		eventReconnectDelay: eventReconnectDelay,
+		eventRewardTimeout:  eventRewardTimeout,
+		spec:                beaconmath.NewSpec(0, cfg.SlotsPerEpoch, 0),
+		fanoutConcurrency:   fanoutConcurrency,
 	}, nil
 }
 
 func (s *validatorService) GetBlockReward(ctx context.Context, slot uint64) (*domain.BlockReward, error) {
 	s.logger.Info().Uint64("slot", slot).Msg("getting block reward")
 
-	cacheKey := fmt.Sprintf("block_reward:%d", slot)
-	if s.cache != nil {
-		if cached, found := s.cache.Get(cacheKey); found {
+	if slot == 0 {
+		return genesisBlockReward(), nil
+	}
+
+	cacheKey := s.keys.blockRewardKey(slot)
+	if s.cache != nil && !CacheBypassed(ctx) {
+		if entry, found := s.getCacheEntry(s.cache, cacheKey); found {
 			s.logger.Debug().Uint64("slot", slot).Msg("returning cached block reward")
-			return cached.(*domain.BlockReward), nil
+			s.maybeRefreshBlockReward(slot, entry)
+			return entry.value.(*domain.BlockReward), nil
+		}
+	}
+
+	reward, err := s.fetchBlockReward(ctx, slot)
+	if err != nil {
+		if stale, ok := s.staleBlockReward(slot, err); ok {
+			return stale, nil
 		}
+		return nil, err
+	}
+	return reward, nil
+}
+
+// staleBlockReward returns the last finalized reward seen for slot, marked
+// stale, when err says the beacon node is unavailable. A finalized reward
+// can't change, so the copy is only old, not wrong.
+func (s *validatorService) staleBlockReward(slot uint64, err error) (*domain.BlockReward, bool) {
+	if s.staleCache == nil || !errors.IsUpstreamUnavailable(err) {
+		return nil, false
+	}
+
+	entry, found := s.getCacheEntry(s.staleCache, s.keys.blockRewardKey(slot))
+	if !found || !entry.finalized {
+		return nil, false
+	}
+
+	s.logger.Warn().
+		Err(err).
+		Uint64("slot", slot).
+		Dur("age", s.now().Sub(entry.fetchedAt)).
+		Msg("beacon node unavailable, serving stale block reward")
+
+	stale := *entry.value.(*domain.BlockReward)
+	stale.Stale = true
+	return &stale, true
+}
+
+// genesisBlockReward describes slot 0 without asking the beacon node, whose
+// rewards endpoint has nothing for a block no one proposed. A 404 for any
+// later slot is a missed slot.
+func genesisBlockReward() *domain.BlockReward {
+	return &domain.BlockReward{
+		Status:    domain.StatusVanilla,
+		Reward:    new(big.Int),
+		Finalized: true,
+		Genesis:   true,
 	}
+}
+
+// fetchBlockReward loads the reward from the beacon node and caches it.
+func (s *validatorService) fetchBlockReward(ctx context.Context, slot uint64) (*domain.BlockReward, error) {
+	cacheKey := s.keys.blockRewardKey(slot)
 
 	currentSlot, err := s.ethClient.GetCurrentSlot(ctx)
 	if err != nil {
@@ -76,43 +264,244 @@ func (s *validatorService) GetBlockReward(ctx context.Context, slot uint64) (*do
 		return nil, fmt.Errorf("failed to get block: %w", err)
 	}
 
-	rewards, err := s.ethClient.GetBlockRewards(ctx, slot)
+	rewards, estimated, err := s.getRewards(ctx, slot, block)
 	if err != nil {
-		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to get block rewards")
-		return nil, fmt.Errorf("failed to get block rewards: %w", err)
+		return nil, err
 	}
 
-	status := s.determineBlockStatus(block)
+	proposerIndex, err := strconv.ParseUint(block.Data.Message.ProposerIndex, 10, 64)
+	if err != nil {
+		s.logger.Error().Err(err).Str("proposer_index", block.Data.Message.ProposerIndex).Msg("failed to parse proposer index")
+		return nil, fmt.Errorf("failed to parse proposer index: %w", err)
+	}
 
-	totalReward, err := s.parseReward(rewards.Total)
+	// The execution block is only shown with ?include=execution, so a bad
+	// reference leaves it out rather than failing the reward.
+	execNumber, execHash, err := executionBlockRef(block)
 	if err != nil {
-		s.logger.Error().Err(err).Str("reward", rewards.Total).Msg("failed to parse reward")
-		return nil, fmt.Errorf("failed to parse reward: %w", err)
+		s.logger.Warn().Err(err).Uint64("slot", slot).Msg("failed to read execution block reference")
 	}
 
+	status := s.determineBlockStatus(block)
+
 	result := &domain.BlockReward{
-		Status: status,
-		Reward: totalReward,
+		Status:        status,
+		Reward:        rewards.total,
+		ProposerIndex: proposerIndex,
+		Estimated:     estimated,
+		Finalized:     block.Finalized,
+		Breakdown:     rewards.breakdown,
+
+		Optimistic: block.ExecutionOptimistic || rewards.optimistic,
+
+		ExecutionBlockNumber: execNumber,
+		ExecutionBlockHash:   execHash,
 	}
 
-	if s.cache != nil {
-		s.cache.Set(cacheKey, result)
+	// Optimistic data can still be reverted, so it's never cached.
+	if s.cache != nil && !result.Optimistic {
+		s.setCacheEntry(s.cache, cacheKey, result, result.Finalized)
+	}
+	if s.staleCache != nil && result.Finalized && !result.Optimistic {
+		s.setCacheEntry(s.staleCache, cacheKey, result, true)
 	}
 
 	s.logger.Info().
 		Uint64("slot", slot).
-		Str("status", status).
-		Str("reward", totalReward.String()).
+		Str("status", string(status)).
+		Str("reward", rewards.total.String()).
 		Msg("block reward retrieved")
 
 	return result, nil
 }
 
-func (s *validatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint64) (*domain.SyncCommitteeDuties, error) {
-	s.logger.Info().Uint64("slot", slot).Msg("getting sync committee duties")
+// GetSlotStatus reports whether slot has a block using only block headers. A
+// missed slot has no header of its own, so its finality is read from the
+// finalized header instead.
+func (s *validatorService) GetSlotStatus(ctx context.Context, slot uint64) (*domain.SlotStatus, error) {
+	cacheKey := s.keys.slotStatusKey(slot)
+	if s.cache != nil && !CacheBypassed(ctx) {
+		if entry, found := s.getCacheEntry(s.cache, cacheKey); found {
+			return entry.value.(*domain.SlotStatus), nil
+		}
+	}
 
-	cacheKey := fmt.Sprintf("sync_duties:%d", slot)
-	if s.cache != nil {
+	currentSlot, err := s.ethClient.GetCurrentSlot(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to get current slot")
+		return nil, fmt.Errorf("failed to get current slot: %w", err)
+	}
+
+	if slot > currentSlot {
+		s.logger.Warn().Uint64("slot", slot).Uint64("current_slot", currentSlot).Msg("requested future slot")
+		return nil, errors.ErrFutureSlot
+	}
+
+	result := &domain.SlotStatus{Slot: slot}
+
+	header, err := s.ethClient.GetBlockHeader(ctx, strconv.FormatUint(slot, 10))
+	switch {
+	case err == nil:
+		result.Proposed = true
+		result.Finalized = header.Finalized
+		result.Optimistic = header.ExecutionOptimistic
+
+	case errors.IsNotFound(err):
+		finalized, err := s.ethClient.GetBlockHeader(ctx, "finalized")
+		if err != nil {
+			s.logger.Error().Err(err).Msg("failed to get finalized header")
+			return nil, fmt.Errorf("failed to get finalized header: %w", err)
+		}
+
+		finalizedSlot, err := strconv.ParseUint(finalized.Data.Header.Message.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.UpstreamDataError{Field: "slot", Value: finalized.Data.Header.Message.Slot, Reason: "not a decimal integer"}
+		}
+		result.Finalized = slot <= finalizedSlot
+		result.Optimistic = finalized.ExecutionOptimistic
+
+	default:
+		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to get block header")
+		return nil, fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	// A block for an unfinalized slot may still arrive late or be reorged in,
+	// so only a finalized miss is worth caching.
+	if s.cache != nil && !result.Optimistic && (result.Proposed || result.Finalized) {
+		s.setCacheEntry(s.cache, cacheKey, result, result.Finalized)
+	}
+
+	return result, nil
+}
+
+type parsedRewards struct {
+	total      *big.Int
+	breakdown  *domain.RewardBreakdown
+	optimistic bool
+}
+
+func (s *validatorService) getRewards(ctx context.Context, slot uint64, block *ethereum.BeaconBlock) (*parsedRewards, bool, error) {
+	rewards, err := s.ethClient.GetBlockRewards(ctx, slot)
+	if err != nil {
+		// The block exists, so a missing rewards resource means the beacon
+		// node doesn't serve the endpoint.
+		unsupported := errors.IsNotFound(err) || errors.IsNotSupported(err)
+		if s.estimateRewards && unsupported {
+			estimate, estimateErr := estimateBlockReward(block.Data.Message.Body)
+			if estimateErr != nil {
+				s.logger.Error().Err(estimateErr).Uint64("slot", slot).Msg("failed to estimate block reward")
+				return nil, false, fmt.Errorf("failed to estimate block reward: %w", estimateErr)
+			}
+			s.logger.Warn().Err(err).Uint64("slot", slot).Msg("block rewards endpoint unavailable, returning estimate")
+			return &parsedRewards{total: estimate.Mul(estimate, domain.WeiPerGwei)}, true, nil
+		}
+
+		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to get block rewards")
+		return nil, false, fmt.Errorf("failed to get block rewards: %w", err)
+	}
+
+	totalReward, err := s.parseReward(rewards.Total)
+	if err != nil {
+		s.logger.Error().Err(err).Str("reward", rewards.Total).Msg("failed to parse reward")
+		return nil, false, fmt.Errorf("failed to parse reward: %w", err)
+	}
+
+	return &parsedRewards{
+		total:      totalReward,
+		breakdown:  s.parseRewardBreakdown(slot, rewards),
+		optimistic: rewards.ExecutionOptimistic,
+	}, false, nil
+}
+
+// parseRewardBreakdown is best effort: a malformed component only drops the
+// breakdown, not the whole response.
+func (s *validatorService) parseRewardBreakdown(slot uint64, rewards *ethereum.BlockRewards) *domain.RewardBreakdown {
+	components := []string{rewards.Attestations, rewards.SyncAggregate, rewards.ProposerSlashings, rewards.AttesterSlashings}
+	parsed := make([]*big.Int, len(components))
+
+	for i, component := range components {
+		value, err := s.parseReward(component)
+		if err != nil {
+			s.logger.Debug().Err(err).Uint64("slot", slot).Msg("reward breakdown unavailable")
+			return nil
+		}
+		parsed[i] = value
+	}
+
+	return &domain.RewardBreakdown{
+		Attestations:      parsed[0],
+		SyncAggregate:     parsed[1],
+		ProposerSlashings: parsed[2],
+		AttesterSlashings: parsed[3],
+	}
+}
+
+func (s *validatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint64, opts SyncDutiesOptions) (*domain.SyncCommitteeDuties, error) {
+	s.logger.Info().Uint64("slot", slot).Bool("include_next", opts.IncludeNext).Msg("getting sync committee duties")
+
+	duties, err := s.getSyncCommitteeDuties(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.IncludeNext {
+		return duties, nil
+	}
+
+	next, err := s.getNextSyncCommittee(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *duties
+	result.NextValidators = next
+
+	return &result, nil
+}
+
+func (s *validatorService) GetSyncCommitteeByPeriod(ctx context.Context, period uint64) (*domain.SyncCommitteeDuties, error) {
+	s.logger.Info().Uint64("period", period).Msg("getting sync committee by period")
+
+	currentSlot, err := s.ethClient.GetCurrentSlot(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to get current slot")
+		return nil, fmt.Errorf("failed to get current slot: %w", err)
+	}
+
+	// Checked before converting to a slot, which would overflow for huge periods.
+	currentPeriod := s.spec.SlotToSyncCommitteePeriod(currentSlot)
+	if period > currentPeriod+1 {
+		s.logger.Warn().Uint64("period", period).Uint64("current_period", currentPeriod).Msg("sync committee period too far in future")
+		return nil, errors.ErrPeriodTooFar
+	}
+
+	return s.getSyncCommitteeDuties(ctx, s.spec.SyncCommitteePeriodStartSlot(period))
+}
+
+// GetSyncCommitteeAtState isn't cached: named states such as head move, and
+// callers asking for an explicit state want the beacon node's answer.
+func (s *validatorService) GetSyncCommitteeAtState(ctx context.Context, stateID string) (*domain.SyncCommitteeAtState, error) {
+	s.logger.Info().Str("state_id", stateID).Msg("getting sync committee at state")
+
+	validators, err := s.ethClient.GetSyncCommitteeAtState(ctx, stateID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			s.logger.Info().Str("state_id", stateID).Msg("state not found")
+			return nil, errors.ErrStateNotFound
+		}
+		s.logger.Error().Err(err).Str("state_id", stateID).Msg("failed to get sync committee")
+		return nil, fmt.Errorf("failed to get sync committee: %w", err)
+	}
+
+	return &domain.SyncCommitteeAtState{
+		StateID:    stateID,
+		Validators: validators,
+	}, nil
+}
+
+func (s *validatorService) getSyncCommitteeDuties(ctx context.Context, slot uint64) (*domain.SyncCommitteeDuties, error) {
+	cacheKey := s.keys.syncDutiesKey(s.spec.SlotToSyncCommitteePeriod(slot))
+	if s.cache != nil && !CacheBypassed(ctx) {
 		if cached, found := s.cache.Get(cacheKey); found {
 			s.logger.Debug().Uint64("slot", slot).Msg("returning cached sync duties")
 			return cached.(*domain.SyncCommitteeDuties), nil
@@ -125,7 +514,7 @@ func (s *validatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint
 		return nil, fmt.Errorf("failed to get current slot: %w", err)
 	}
 
-	if slot > currentSlot+32*256 {
+	if slot > currentSlot+s.spec.SlotsPerSyncCommitteePeriod() {
 		s.logger.Warn().Uint64("slot", slot).Uint64("current_slot", currentSlot).Msg("slot too far in future")
 		return nil, errors.ErrSlotTooFarInFuture
 	}
@@ -140,8 +529,13 @@ func (s *validatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint
 		return nil, fmt.Errorf("failed to get sync committee: %w", err)
 	}
 
+	period, startSlot, endSlot := s.spec.SyncCommitteePeriodBounds(slot)
+
 	result := &domain.SyncCommitteeDuties{
-		Validators: validators,
+		Period:          period,
+		PeriodStartSlot: startSlot,
+		PeriodEndSlot:   endSlot,
+		Validators:      validators,
 	}
 
 	if s.cache != nil {
@@ -156,83 +550,137 @@ func (s *validatorService) GetSyncCommitteeDuties(ctx context.Context, slot uint
 	return result, nil
 }
 
-func (s *validatorService) determineBlockStatus(block *ethereum.BeaconBlock) string {
-	if block.Data.Message.Body.ExecutionPayload == nil {
-		return "vanilla"
+func (s *validatorService) getNextSyncCommittee(ctx context.Context, slot uint64) ([]string, error) {
+	cacheKey := s.keys.syncDutiesNextKey(s.spec.SlotToSyncCommitteePeriod(slot))
+	if s.cache != nil && !CacheBypassed(ctx) {
+		if cached, found := s.cache.Get(cacheKey); found {
+			s.logger.Debug().Uint64("slot", slot).Msg("returning cached next sync committee")
+			return cached.([]string), nil
+		}
 	}
 
-	payload := block.Data.Message.Body.ExecutionPayload
+	currentSlot, err := s.ethClient.GetCurrentSlot(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to get current slot")
+		return nil, fmt.Errorf("failed to get current slot: %w", err)
+	}
 
-	if len(payload.Transactions) == 0 {
-		return "vanilla"
+	// The next committee is read from the state at the start of the slot's
+	// period, so that state must already exist.
+	periodStart := s.spec.SyncCommitteePeriodStartSlot(s.spec.SlotToSyncCommitteePeriod(slot))
+	if periodStart > currentSlot {
+		s.logger.Warn().Uint64("slot", slot).Uint64("current_slot", currentSlot).Msg("next sync committee not yet known")
+		return nil, errors.ErrSlotTooFarInFuture
 	}
 
-	for _, tx := range payload.Transactions {
-		if s.isMEVTransaction(tx) {
-			return "mev"
+	validators, err := s.ethClient.GetNextSyncCommittee(ctx, slot)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			s.logger.Info().Uint64("slot", slot).Msg("slot not found")
+			return nil, errors.ErrSlotNotFound
 		}
+		s.logger.Error().Err(err).Uint64("slot", slot).Msg("failed to get next sync committee")
+		return nil, fmt.Errorf("failed to get next sync committee: %w", err)
 	}
 
-	feeRecipient := strings.ToLower(payload.FeeRecipient)
-	knownMEVRelays := []string{
-		"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
-		"0x388c818ca8b9251b393131c08a736a67ccb19297",
-		"0x8b5d7a6055e54e36e8a6e2a128c5d0f38f4e5e83",
+	if s.cache != nil {
+		s.cache.Set(cacheKey, validators)
 	}
 
-	for _, relay := range knownMEVRelays {
-		if feeRecipient == relay {
-			return "mev"
-		}
+	return validators, nil
+}
+
+// zeroBlockHash is the block hash of the empty payload that Bellatrix
+// blocks carry before the merge.
+const zeroBlockHash = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+// executionBlockRef returns the number and hash of the block's execution
+// payload, or zero values when it has none. Blinded blocks are read from
+// their payload header.
+func executionBlockRef(block *ethereum.BeaconBlock) (uint64, string, error) {
+	payload := block.Data.Message.Body.ExecutionPayload
+	if payload == nil {
+		payload = block.Data.Message.Body.ExecutionPayloadHeader
+	}
+	if payload == nil || payload.BlockHash == "" || payload.BlockHash == zeroBlockHash {
+		return 0, "", nil
 	}
 
-	return "vanilla"
+	number, err := strconv.ParseUint(payload.BlockNumber, 10, 64)
+	if err != nil {
+		return 0, "", errors.UpstreamDataError{Field: "block_number", Value: payload.BlockNumber, Reason: "not a decimal integer"}
+	}
+	return number, payload.BlockHash, nil
 }
 
-func (s *validatorService) isMEVTransaction(txHex string) bool {
-	if len(txHex) < 10 {
-		return false
+func (s *validatorService) determineBlockStatus(block *ethereum.BeaconBlock) domain.BlockStatus {
+	// Blinded blocks are only produced through MEV-boost relays, and their
+	// transaction list is unavailable anyway.
+	if block.Data.Message.Body.IsBlinded() {
+		return domain.StatusMEV
 	}
 
-	mevPatterns := []string{
-		"0xa22cb465",
-		"0x095ea7b3",
-		"0x23b872dd",
+	if block.Data.Message.Body.ExecutionPayload == nil {
+		return domain.StatusVanilla
 	}
 
-	for _, pattern := range mevPatterns {
-		if strings.HasPrefix(txHex, pattern) {
-			return true
+	payload := block.Data.Message.Body.ExecutionPayload
+
+	if len(payload.Transactions) == 0 {
+		return domain.StatusVanilla
+	}
+
+	// The relay check is a single lookup, so try it before scanning
+	// potentially hundreds of transactions.
+	if s.isMEVRelay(payload.FeeRecipient) {
+		return domain.StatusMEV
+	}
+
+	for _, tx := range payload.Transactions {
+		if s.isMEVTransaction(tx) {
+			return domain.StatusMEV
 		}
 	}
 
-	return false
+	return domain.StatusVanilla
 }
 
-func (s *validatorService) parseReward(rewardStr string) (*big.Int, error) {
-	reward, ok := new(big.Int).SetString(rewardStr, 10)
-	if !ok {
-		return nil, fmt.Errorf("invalid reward format: %s", rewardStr)
+func (s *validatorService) isMEVRelay(feeRecipient string) bool {
+	normalized, err := normalizeAddress(feeRecipient)
+	if err != nil {
+		return false
 	}
-	return reward, nil
-}
 
-func slotToEpoch(slot uint64) uint64 {
-	return slot / 32
+	_, ok := s.mevRelays[normalized]
+	return ok
 }
 
-func epochToSyncCommitteePeriod(epoch uint64) uint64 {
-	return epoch / 256
+// MEVRelays returns the normalized relay addresses in effect, sorted, and
+// the MEV transaction selectors.
+func (s *validatorService) MEVRelays() domain.MEVRelays {
+	relays := make([]string, 0, len(s.mevRelays))
+	for relay := range s.mevRelays {
+		relays = append(relays, relay)
+	}
+	sort.Strings(relays)
+
+	return domain.MEVRelays{Relays: relays, Selectors: mevSelectors.list()}
 }
 
-func syncCommitteePeriodToSlot(period uint64) uint64 {
-	return period * 256 * 32
+func (s *validatorService) isMEVTransaction(txHex string) bool {
+	return mevSelectors.match(txHex)
 }
 
-func parseSlot(slotStr string) (uint64, error) {
-	slot, err := strconv.ParseUint(slotStr, 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid slot format: %w", err)
+// parseReward accepts the decimal integer strings of the rewards API, which
+// are in gwei, and returns the amount in wei. Anything else is the beacon
+// node's fault and is reported as malformed upstream data.
+func (s *validatorService) parseReward(rewardStr string) (*big.Int, error) {
+	reward, ok := new(big.Int).SetString(rewardStr, 10)
+	if !ok {
+		return nil, errors.UpstreamDataError{Field: "reward", Value: rewardStr, Reason: "not a decimal integer"}
+	}
+	if reward.Sign() < 0 {
+		return nil, errors.UpstreamDataError{Field: "reward", Value: rewardStr, Reason: "negative"}
 	}
-	return slot, nil
+	return reward.Mul(reward, domain.WeiPerGwei), nil
 }
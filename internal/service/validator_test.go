@@ -2,14 +2,21 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"net/url"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/matheus/eth-validator-api/internal/domain"
+	"github.com/matheus/eth-validator-api/pkg/cache"
 	pkgerrors "github.com/matheus/eth-validator-api/pkg/errors"
 	"github.com/matheus/eth-validator-api/pkg/ethereum"
 	"github.com/matheus/eth-validator-api/pkg/logger"
@@ -35,6 +42,22 @@ func (m *mockEthClient) GetSyncCommittee(ctx context.Context, slot uint64) ([]st
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockEthClient) GetNextSyncCommittee(ctx context.Context, slot uint64) ([]string, error) {
+	args := m.Called(ctx, slot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockEthClient) GetSyncCommitteeAtState(ctx context.Context, stateID string) ([]string, error) {
+	args := m.Called(ctx, stateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *mockEthClient) GetCurrentSlot(ctx context.Context) (uint64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(uint64), args.Error(1)
@@ -48,6 +71,14 @@ func (m *mockEthClient) GetBlockRewards(ctx context.Context, slot uint64) (*ethe
 	return args.Get(0).(*ethereum.BlockRewards), args.Error(1)
 }
 
+func (m *mockEthClient) GetBlockHeader(ctx context.Context, blockID string) (*ethereum.HeaderResponse, error) {
+	args := m.Called(ctx, blockID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ethereum.HeaderResponse), args.Error(1)
+}
+
 func (m *mockEthClient) GetProposerDuties(ctx context.Context, epoch uint64) ([]ethereum.ProposerDuty, error) {
 	args := m.Called(ctx, epoch)
 	if args.Get(0) == nil {
@@ -56,6 +87,56 @@ func (m *mockEthClient) GetProposerDuties(ctx context.Context, epoch uint64) ([]
 	return args.Get(0).([]ethereum.ProposerDuty), args.Error(1)
 }
 
+func (m *mockEthClient) GetValidatorPubkey(ctx context.Context, index uint64) (string, error) {
+	args := m.Called(ctx, index)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockEthClient) GetValidatorPubkeys(ctx context.Context, indices []uint64) (map[uint64]string, error) {
+	args := m.Called(ctx, indices)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint64]string), args.Error(1)
+}
+
+func (m *mockEthClient) GetPeerCount(ctx context.Context) (*ethereum.PeerCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ethereum.PeerCount), args.Error(1)
+}
+
+func (m *mockEthClient) GetBlockHeadersAtSlot(ctx context.Context, slot uint64) ([]ethereum.HeaderData, error) {
+	args := m.Called(ctx, slot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ethereum.HeaderData), args.Error(1)
+}
+
+func (m *mockEthClient) Initialized() bool {
+	return m.Called().Bool(0)
+}
+
+func (m *mockEthClient) GetNodeVersion(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockEthClient) SubscribeEvents(ctx context.Context, topics []string, fn func(ethereum.Event)) error {
+	args := m.Called(ctx, topics, fn)
+	return args.Error(0)
+}
+
+// testBlock returns the smallest block the service accepts.
+func testBlock() *ethereum.BeaconBlock {
+	return &ethereum.BeaconBlock{
+		Data: ethereum.BeaconBlockData{Message: ethereum.BlockMessage{ProposerIndex: "1"}},
+	}
+}
+
 type mockCache struct {
 	mock.Mock
 }
@@ -69,6 +150,10 @@ func (m *mockCache) Set(key string, value interface{}) {
 	m.Called(key, value)
 }
 
+func (m *mockCache) Delete(key string) {
+	m.Called(key)
+}
+
 func TestValidatorService_GetBlockReward(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -86,6 +171,7 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 				client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(&ethereum.BeaconBlock{
 					Data: ethereum.BeaconBlockData{
 						Message: ethereum.BlockMessage{
+							ProposerIndex: "4242",
 							Body: ethereum.BlockBody{
 								ExecutionPayload: &ethereum.ExecutionPayload{
 									FeeRecipient: "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
@@ -96,13 +182,14 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 					},
 				}, nil)
 				client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{
-					Total: "1000000000000000000",
+					Total: "1000000000",
 				}, nil)
 				cache.On("Set", "block_reward:12345", mock.Anything)
 			},
 			expectedReward: &domain.BlockReward{
-				Status: "mev",
-				Reward: big.NewInt(1000000000000000000),
+				Status:        domain.StatusMEV,
+				Reward:        big.NewInt(1000000000000000000),
+				ProposerIndex: 4242,
 			},
 		},
 		{
@@ -114,6 +201,7 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 				client.On("GetBlockBySlot", mock.Anything, uint64(12346)).Return(&ethereum.BeaconBlock{
 					Data: ethereum.BeaconBlockData{
 						Message: ethereum.BlockMessage{
+							ProposerIndex: "4242",
 							Body: ethereum.BlockBody{
 								ExecutionPayload: &ethereum.ExecutionPayload{
 									FeeRecipient: "0x1234567890abcdef",
@@ -124,13 +212,14 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 					},
 				}, nil)
 				client.On("GetBlockRewards", mock.Anything, uint64(12346)).Return(&ethereum.BlockRewards{
-					Total: "500000000000000000",
+					Total: "500000000",
 				}, nil)
 				cache.On("Set", "block_reward:12346", mock.Anything)
 			},
 			expectedReward: &domain.BlockReward{
-				Status: "vanilla",
-				Reward: big.NewInt(500000000000000000),
+				Status:        domain.StatusVanilla,
+				Reward:        big.NewInt(500000000000000000),
+				ProposerIndex: 4242,
 			},
 		},
 		{
@@ -138,13 +227,13 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 			slot: 12347,
 			setupMocks: func(client *mockEthClient, cache *mockCache) {
 				cachedReward := &domain.BlockReward{
-					Status: "mev",
+					Status: domain.StatusMEV,
 					Reward: big.NewInt(2000000000000000000),
 				}
-				cache.On("Get", "block_reward:12347").Return(cachedReward, true)
+				cache.On("Get", "block_reward:12347").Return(cacheEntry{value: cachedReward}, true)
 			},
 			expectedReward: &domain.BlockReward{
-				Status: "mev",
+				Status: domain.StatusMEV,
 				Reward: big.NewInt(2000000000000000000),
 			},
 		},
@@ -177,7 +266,7 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 
 			tt.setupMocks(client, cache)
 
-			service, err := NewValidatorService(client, log, cache)
+			service, err := NewValidatorService(client, log, cache, ServiceConfig{})
 			assert.NoError(t, err)
 
 			result, err := service.GetBlockReward(context.Background(), tt.slot)
@@ -187,8 +276,8 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 				assert.True(t, errors.Is(err, tt.expectedError))
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedReward.Status, result.Status)
-				assert.Equal(t, 0, tt.expectedReward.Reward.Cmp(result.Reward))
+				assert.True(t, tt.expectedReward.Equal(result), "got %s %s", result.Status, result.Reward)
+				assert.Equal(t, tt.expectedReward.ProposerIndex, result.ProposerIndex)
 			}
 
 			client.AssertExpectations(t)
@@ -197,10 +286,237 @@ func TestValidatorService_GetBlockReward(t *testing.T) {
 	}
 }
 
+func TestValidatorService_CacheBypass(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+	memCache.Set("block_reward:12345", cacheEntry{value: &domain.BlockReward{Status: domain.StatusVanilla, Reward: big.NewInt(1)}})
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "2"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{})
+	require.NoError(t, err)
+
+	cached, err := svc.GetBlockReward(context.Background(), 12345)
+	require.NoError(t, err)
+	assert.Equal(t, "1", cached.Reward.String())
+
+	fresh, err := svc.GetBlockReward(WithCacheBypass(context.Background()), 12345)
+	require.NoError(t, err)
+	assert.Equal(t, "2000000000", fresh.Reward.String())
+
+	// The bypassed fetch replaced the stale entry.
+	value, found := memCache.Get("block_reward:12345")
+	require.True(t, found)
+	assert.Equal(t, "2000000000", value.(cacheEntry).value.(*domain.BlockReward).Reward.String())
+
+	client.AssertNumberOfCalls(t, "GetBlockRewards", 1)
+}
+
+func TestValidatorService_GetSlotStatus(t *testing.T) {
+	finalizedHeader := &ethereum.HeaderResponse{Finalized: true}
+	finalizedHeader.Data.Header.Message.Slot = "12000"
+
+	tests := []struct {
+		name          string
+		slot          uint64
+		setupMocks    func(*mockEthClient, *mockCache)
+		expected      *domain.SlotStatus
+		expectedError error
+	}{
+		{
+			name: "proposed",
+			slot: 12345,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "slot_status:12345").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+				client.On("GetBlockHeader", mock.Anything, "12345").Return(&ethereum.HeaderResponse{Finalized: false}, nil)
+				cache.On("Set", "slot_status:12345", cacheEntry{value: &domain.SlotStatus{Slot: 12345, Proposed: true}, fetchedAt: testNow})
+			},
+			expected: &domain.SlotStatus{Slot: 12345, Proposed: true},
+		},
+		{
+			name: "missed and finalized",
+			slot: 11000,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "slot_status:11000").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+				client.On("GetBlockHeader", mock.Anything, "11000").Return(nil, pkgerrors.ErrSlotNotFound)
+				client.On("GetBlockHeader", mock.Anything, "finalized").Return(finalizedHeader, nil)
+				cache.On("Set", "slot_status:11000", cacheEntry{value: &domain.SlotStatus{Slot: 11000, Finalized: true}, finalized: true, fetchedAt: testNow})
+			},
+			expected: &domain.SlotStatus{Slot: 11000, Finalized: true},
+		},
+		{
+			name: "missed at the finalized slot",
+			slot: 12000,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "slot_status:12000").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+				client.On("GetBlockHeader", mock.Anything, "12000").Return(nil, pkgerrors.ErrSlotNotFound)
+				client.On("GetBlockHeader", mock.Anything, "finalized").Return(finalizedHeader, nil)
+				cache.On("Set", "slot_status:12000", cacheEntry{value: &domain.SlotStatus{Slot: 12000, Finalized: true}, finalized: true, fetchedAt: testNow})
+			},
+			expected: &domain.SlotStatus{Slot: 12000, Finalized: true},
+		},
+		{
+			// The block may still turn up, so the miss isn't cached.
+			name: "missed, not yet finalized",
+			slot: 12345,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "slot_status:12345").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+				client.On("GetBlockHeader", mock.Anything, "12345").Return(nil, pkgerrors.ErrSlotNotFound)
+				client.On("GetBlockHeader", mock.Anything, "finalized").Return(finalizedHeader, nil)
+			},
+			expected: &domain.SlotStatus{Slot: 12345},
+		},
+		{
+			name: "cached",
+			slot: 12345,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "slot_status:12345").Return(cacheEntry{value: &domain.SlotStatus{Slot: 12345, Proposed: true}}, true)
+			},
+			expected: &domain.SlotStatus{Slot: 12345, Proposed: true},
+		},
+		{
+			name: "future slot",
+			slot: 30000,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "slot_status:30000").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+			},
+			expectedError: pkgerrors.ErrFutureSlot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockEthClient)
+			cache := new(mockCache)
+			tt.setupMocks(client, cache)
+
+			svc, err := NewValidatorService(client, logger.New("error"), cache, ServiceConfig{})
+			require.NoError(t, err)
+			svc.(*validatorService).now = func() time.Time { return testNow }
+
+			result, err := svc.GetSlotStatus(context.Background(), tt.slot)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			client.AssertExpectations(t)
+			cache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidatorService_ParseReward(t *testing.T) {
+	svc := &validatorService{}
+
+	valid, err := svc.parseReward("1950000")
+	require.NoError(t, err)
+	assert.Equal(t, "1950000000000000", valid.String())
+
+	zero, err := svc.parseReward("0")
+	require.NoError(t, err)
+	assert.Equal(t, 0, zero.Sign())
+
+	for _, input := range []string{"", "-1", "0x1dc130", "1e9", "12.5", "NaN"} {
+		_, err := svc.parseReward(input)
+
+		var dataErr pkgerrors.UpstreamDataError
+		require.ErrorAs(t, err, &dataErr, input)
+		assert.Equal(t, input, dataErr.Value)
+		assert.True(t, pkgerrors.IsMalformedUpstream(err), input)
+	}
+}
+
+func TestValidatorService_GetBlockReward_MalformedReward(t *testing.T) {
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "-1000"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	_, err = svc.GetBlockReward(context.Background(), 12345)
+	assert.ErrorIs(t, err, pkgerrors.ErrMalformedUpstream)
+}
+
+func TestValidatorService_GetSyncCommitteeAtState(t *testing.T) {
+	client := new(mockEthClient)
+	client.On("GetSyncCommitteeAtState", mock.Anything, "head").Return([]string{"1", "2"}, nil)
+	client.On("GetSyncCommitteeAtState", mock.Anything, "12").Return(nil, pkgerrors.ErrSlotNotFound)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	committee, err := svc.GetSyncCommitteeAtState(context.Background(), "head")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.SyncCommitteeAtState{StateID: "head", Validators: []string{"1", "2"}}, committee)
+
+	_, err = svc.GetSyncCommitteeAtState(context.Background(), "12")
+	assert.ErrorIs(t, err, pkgerrors.ErrStateNotFound)
+}
+
+func TestValidatorService_ResolvePubkey(t *testing.T) {
+	const pubkey = "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+
+	t.Run("fetched and cached", func(t *testing.T) {
+		client := new(mockEthClient)
+		cache := new(mockCache)
+		cache.On("Get", "validator_pubkey:4242").Return(nil, false)
+		client.On("GetValidatorPubkey", mock.Anything, uint64(4242)).Return(pubkey, nil)
+		cache.On("Set", "validator_pubkey:4242", pubkey)
+
+		svc, err := NewValidatorService(client, logger.New("error"), cache, ServiceConfig{})
+		require.NoError(t, err)
+
+		result, err := svc.ResolvePubkey(context.Background(), 4242)
+		require.NoError(t, err)
+		assert.Equal(t, pubkey, result)
+
+		client.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetValidatorPubkey", mock.Anything, uint64(99)).Return("", pkgerrors.ErrSlotNotFound)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		_, err = svc.ResolvePubkey(context.Background(), 99)
+		assert.ErrorIs(t, err, pkgerrors.ErrValidatorNotFound)
+	})
+}
+
+func TestValidatorService_GetBlockReward_InvalidProposerIndex(t *testing.T) {
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(&ethereum.BeaconBlock{}, nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	require.NoError(t, err)
+
+	_, err = svc.GetBlockReward(context.Background(), 12345)
+	assert.ErrorContains(t, err, "failed to parse proposer index")
+}
+
 func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 	tests := []struct {
 		name           string
 		slot           uint64
+		opts           SyncDutiesOptions
 		setupMocks     func(*mockEthClient, *mockCache)
 		expectedDuties *domain.SyncCommitteeDuties
 		expectedError  error
@@ -209,16 +525,19 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 			name: "successful sync duties",
 			slot: 12345,
 			setupMocks: func(client *mockEthClient, cache *mockCache) {
-				cache.On("Get", "sync_duties:12345").Return(nil, false)
+				cache.On("Get", "sync_duties_period:1").Return(nil, false)
 				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
 				client.On("GetSyncCommittee", mock.Anything, uint64(12345)).Return([]string{
 					"0xvalidator1",
 					"0xvalidator2",
 					"0xvalidator3",
 				}, nil)
-				cache.On("Set", "sync_duties:12345", mock.Anything)
+				cache.On("Set", "sync_duties_period:1", mock.Anything)
 			},
 			expectedDuties: &domain.SyncCommitteeDuties{
+				Period:          1,
+				PeriodStartSlot: 8192,
+				PeriodEndSlot:   16383,
 				Validators: []string{
 					"0xvalidator1",
 					"0xvalidator2",
@@ -226,6 +545,37 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sync duties with next committee",
+			slot: 12345,
+			opts: SyncDutiesOptions{IncludeNext: true},
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "sync_duties_period:1").Return(nil, false)
+				cache.On("Get", "sync_duties_next_period:1").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+				client.On("GetSyncCommittee", mock.Anything, uint64(12345)).Return([]string{"0xvalidator1"}, nil)
+				client.On("GetNextSyncCommittee", mock.Anything, uint64(12345)).Return([]string{"0xnext1", "0xnext2"}, nil)
+				cache.On("Set", "sync_duties_period:1", mock.Anything)
+				cache.On("Set", "sync_duties_next_period:1", []string{"0xnext1", "0xnext2"})
+			},
+			expectedDuties: &domain.SyncCommitteeDuties{
+				Validators:     []string{"0xvalidator1"},
+				NextValidators: []string{"0xnext1", "0xnext2"},
+			},
+		},
+		{
+			name: "next committee for a period that has not started",
+			slot: 16384,
+			opts: SyncDutiesOptions{IncludeNext: true},
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "sync_duties_period:2").Return(nil, false)
+				cache.On("Get", "sync_duties_next_period:2").Return(nil, false)
+				client.On("GetCurrentSlot", mock.Anything).Return(uint64(10000), nil)
+				client.On("GetSyncCommittee", mock.Anything, uint64(16384)).Return([]string{"0xvalidator1"}, nil)
+				cache.On("Set", "sync_duties_period:2", mock.Anything)
+			},
+			expectedError: pkgerrors.ErrSlotTooFarInFuture,
+		},
 		{
 			name: "cached sync duties",
 			slot: 12346,
@@ -233,7 +583,7 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 				cachedDuties := &domain.SyncCommitteeDuties{
 					Validators: []string{"0xcached1", "0xcached2"},
 				}
-				cache.On("Get", "sync_duties:12346").Return(cachedDuties, true)
+				cache.On("Get", "sync_duties_period:1").Return(cachedDuties, true)
 			},
 			expectedDuties: &domain.SyncCommitteeDuties{
 				Validators: []string{"0xcached1", "0xcached2"},
@@ -243,7 +593,7 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 			name: "slot too far in future",
 			slot: 1000000,
 			setupMocks: func(client *mockEthClient, cache *mockCache) {
-				cache.On("Get", "sync_duties:1000000").Return(nil, false)
+				cache.On("Get", "sync_duties_period:122").Return(nil, false)
 				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
 			},
 			expectedError: pkgerrors.ErrSlotTooFarInFuture,
@@ -252,7 +602,7 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 			name: "slot not found",
 			slot: 12347,
 			setupMocks: func(client *mockEthClient, cache *mockCache) {
-				cache.On("Get", "sync_duties:12347").Return(nil, false)
+				cache.On("Get", "sync_duties_period:1").Return(nil, false)
 				client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
 				client.On("GetSyncCommittee", mock.Anything, uint64(12347)).Return(nil, pkgerrors.ErrSlotNotFound)
 			},
@@ -268,10 +618,10 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 
 			tt.setupMocks(client, cache)
 
-			service, err := NewValidatorService(client, log, cache)
+			service, err := NewValidatorService(client, log, cache, ServiceConfig{})
 			assert.NoError(t, err)
 
-			result, err := service.GetSyncCommitteeDuties(context.Background(), tt.slot)
+			result, err := service.GetSyncCommitteeDuties(context.Background(), tt.slot, tt.opts)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -279,6 +629,12 @@ func TestValidatorService_GetSyncCommitteeDuties(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedDuties.Validators, result.Validators)
+				assert.Equal(t, tt.expectedDuties.NextValidators, result.NextValidators)
+				if tt.expectedDuties.PeriodEndSlot != 0 {
+					assert.Equal(t, tt.expectedDuties.Period, result.Period)
+					assert.Equal(t, tt.expectedDuties.PeriodStartSlot, result.PeriodStartSlot)
+					assert.Equal(t, tt.expectedDuties.PeriodEndSlot, result.PeriodEndSlot)
+				}
 			}
 
 			client.AssertExpectations(t)
@@ -293,20 +649,622 @@ func TestValidatorService_Constructor(t *testing.T) {
 	cache := new(mockCache)
 
 	t.Run("nil client", func(t *testing.T) {
-		_, err := NewValidatorService(nil, log, cache)
+		_, err := NewValidatorService(nil, log, cache, ServiceConfig{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "ethereum client is required")
 	})
 
 	t.Run("nil logger", func(t *testing.T) {
-		_, err := NewValidatorService(client, nil, cache)
+		_, err := NewValidatorService(client, nil, cache, ServiceConfig{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "logger is required")
 	})
 
+	t.Run("invalid relay address", func(t *testing.T) {
+		_, err := NewValidatorService(client, log, cache, ServiceConfig{MEVRelays: []string{"0x1234"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid MEV relay address")
+	})
+
 	t.Run("valid construction", func(t *testing.T) {
-		service, err := NewValidatorService(client, log, cache)
+		service, err := NewValidatorService(client, log, cache, ServiceConfig{})
 		assert.NoError(t, err)
 		assert.NotNil(t, service)
 	})
 }
+
+func TestValidatorService_DetermineBlockStatus_Blinded(t *testing.T) {
+	raw, err := os.ReadFile("testdata/blinded_block.json")
+	assert.NoError(t, err)
+
+	var block ethereum.BeaconBlock
+	assert.NoError(t, json.Unmarshal(raw, &block))
+	assert.True(t, block.Data.Message.Body.IsBlinded())
+
+	svc := &validatorService{logger: logger.New("error")}
+	assert.Equal(t, domain.StatusMEV, svc.determineBlockStatus(&block))
+
+	t.Run("empty transaction list is not blinded", func(t *testing.T) {
+		block := &ethereum.BeaconBlock{
+			Data: ethereum.BeaconBlockData{
+				Message: ethereum.BlockMessage{
+					Body: ethereum.BlockBody{
+						ExecutionPayload: &ethereum.ExecutionPayload{
+							FeeRecipient: "0x1234567890abcdef",
+							Transactions: []string{},
+						},
+					},
+				},
+			},
+		}
+		assert.False(t, block.Data.Message.Body.IsBlinded())
+		assert.Equal(t, domain.StatusVanilla, svc.determineBlockStatus(block))
+	})
+}
+
+func TestValidatorService_MEVRelayNormalization(t *testing.T) {
+	blockWithRecipient := func(recipient string) *ethereum.BeaconBlock {
+		return &ethereum.BeaconBlock{
+			Data: ethereum.BeaconBlockData{
+				Message: ethereum.BlockMessage{
+					Body: ethereum.BlockBody{
+						ExecutionPayload: &ethereum.ExecutionPayload{
+							FeeRecipient: recipient,
+							Transactions: []string{"0x02f8b0"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	variants := []string{
+		"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+		"0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5",
+		"0X95222290DD7278AA3DDD389CC1E1D165CC4BAFE5",
+		"95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+	}
+
+	for _, relayConfig := range [][]string{{"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"}, {"95222290DD7278AA3DDD389CC1E1D165CC4BAFE5"}} {
+		svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), nil, ServiceConfig{MEVRelays: relayConfig})
+		assert.NoError(t, err)
+
+		for _, recipient := range variants {
+			assert.Equal(t, domain.StatusMEV, svc.(*validatorService).determineBlockStatus(blockWithRecipient(recipient)), recipient)
+		}
+		assert.Equal(t, domain.StatusVanilla, svc.(*validatorService).determineBlockStatus(blockWithRecipient("0x95222290dd7278aa3ddd389cc1e1d165cc4baf")))
+	}
+}
+
+func TestValidatorService_MEVRelays(t *testing.T) {
+	selectors := []string{"0xa22cb465", "0x095ea7b3", "0x23b872dd"}
+
+	t.Run("none", func(t *testing.T) {
+		svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		relays := svc.MEVRelays()
+		assert.Empty(t, relays.Relays)
+		assert.Equal(t, selectors, relays.Selectors)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		svc, err := NewValidatorService(new(mockEthClient), logger.New("error"), nil, ServiceConfig{
+			MEVRelays: []string{"DAFEA492D9C6733AE3D56B7ED1ADB60692C98BC5", "0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5"},
+		})
+		require.NoError(t, err)
+
+		relays := svc.MEVRelays()
+		assert.Equal(t, []string{
+			"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+			"0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+		}, relays.Relays)
+		assert.Equal(t, selectors, relays.Selectors)
+	})
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	valid := []string{
+		"0x388c818ca8b9251b393131c08a736a67ccb19297",
+		"0x388C818CA8B9251b393131C08a736A67ccB19297",
+		"388c818ca8b9251b393131c08a736a67ccb19297",
+		" 0x388c818ca8b9251b393131c08a736a67ccb19297 ",
+	}
+	for _, addr := range valid {
+		normalized, err := normalizeAddress(addr)
+		assert.NoError(t, err, addr)
+		assert.Equal(t, "0x388c818ca8b9251b393131c08a736a67ccb19297", normalized)
+	}
+
+	invalid := []string{"", "0x", "0x1234", "0xzz8c818ca8b9251b393131c08a736a67ccb19297", "0x388c818ca8b9251b393131c08a736a67ccb1929700"}
+	for _, addr := range invalid {
+		_, err := normalizeAddress(addr)
+		assert.Error(t, err, addr)
+	}
+}
+
+func TestValidatorService_GetBlockReward_Estimated(t *testing.T) {
+	raw, err := os.ReadFile("testdata/reward_estimate_block.json")
+	assert.NoError(t, err)
+	var block ethereum.BeaconBlock
+	assert.NoError(t, json.Unmarshal(raw, &block))
+
+	t.Run("estimate replaces the unsupported endpoint", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(10000000), nil)
+		client.On("GetBlockBySlot", mock.Anything, uint64(9000000)).Return(&block, nil)
+		client.On("GetBlockRewards", mock.Anything, uint64(9000000)).Return(nil, pkgerrors.ErrNotSupported)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{EstimateRewards: true})
+		assert.NoError(t, err)
+
+		result, err := svc.GetBlockReward(context.Background(), 9000000)
+		assert.NoError(t, err)
+		assert.True(t, result.Estimated)
+
+		// The estimator's gwei figure, checked in estimate_test.go, in wei.
+		expected, err := estimateBlockReward(block.Data.Message.Body)
+		require.NoError(t, err)
+		assert.Equal(t, new(big.Int).Mul(expected, domain.WeiPerGwei), result.Reward)
+	})
+
+	t.Run("estimation disabled", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(10000000), nil)
+		client.On("GetBlockBySlot", mock.Anything, uint64(9000000)).Return(&block, nil)
+		client.On("GetBlockRewards", mock.Anything, uint64(9000000)).Return(nil, pkgerrors.ErrNotSupported)
+
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		assert.NoError(t, err)
+
+		_, err = svc.GetBlockReward(context.Background(), 9000000)
+		assert.ErrorIs(t, err, pkgerrors.ErrNotSupported)
+	})
+}
+
+func TestValidatorService_GetBlockReward_Breakdown(t *testing.T) {
+	raw, err := os.ReadFile("testdata/reward_estimate_block.json")
+	assert.NoError(t, err)
+	var block ethereum.BeaconBlock
+	assert.NoError(t, json.Unmarshal(raw, &block))
+
+	raw, err = os.ReadFile("testdata/block_rewards.json")
+	assert.NoError(t, err)
+	var rewards struct {
+		Data ethereum.BlockRewards `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &rewards))
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(10000000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(9000000)).Return(&block, nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(9000000)).Return(&rewards.Data, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+	assert.NoError(t, err)
+
+	result, err := svc.GetBlockReward(context.Background(), 9000000)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Breakdown)
+
+	sum := new(big.Int)
+	sum.Add(sum, result.Breakdown.Attestations)
+	sum.Add(sum, result.Breakdown.SyncAggregate)
+	sum.Add(sum, result.Breakdown.ProposerSlashings)
+	sum.Add(sum, result.Breakdown.AttesterSlashings)
+	assert.Equal(t, 0, sum.Cmp(result.Reward))
+}
+
+func TestValidatorService_GetBlockReward_Genesis(t *testing.T) {
+	t.Run("slot 0 is the genesis block", func(t *testing.T) {
+		client := new(mockEthClient)
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		reward, err := svc.GetBlockReward(context.Background(), 0)
+		require.NoError(t, err)
+		assert.True(t, reward.Genesis)
+		assert.True(t, reward.Finalized)
+		assert.Equal(t, 0, reward.Reward.Sign())
+		client.AssertNotCalled(t, "GetBlockBySlot", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missed slot 1", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+		client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(nil, pkgerrors.ErrSlotNotFound)
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		_, err = svc.GetBlockReward(context.Background(), 1)
+		assert.ErrorIs(t, err, pkgerrors.ErrSlotNotFound)
+	})
+
+	t.Run("proposed slot 1", func(t *testing.T) {
+		client := new(mockEthClient)
+		client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+		client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(testBlock(), nil)
+		client.On("GetBlockRewards", mock.Anything, uint64(1)).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+		svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+		require.NoError(t, err)
+
+		reward, err := svc.GetBlockReward(context.Background(), 1)
+		require.NoError(t, err)
+		assert.False(t, reward.Genesis)
+		assert.Equal(t, "1000000000000", reward.Reward.String())
+	})
+}
+
+func TestValidatorService_GetBlockReward_ServeStale(t *testing.T) {
+	unavailable := pkgerrors.BeaconHTTPError{StatusCode: 503, Body: "down"}
+	finalized := testBlock()
+	finalized.Finalized = true
+
+	memCache := cache.NewMemoryCache(time.Minute, 100)
+	defer memCache.Close()
+	staleCache := cache.NewMemoryCache(time.Hour, 100)
+	defer staleCache.Close()
+
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(finalized, nil).Once()
+	client.On("GetBlockBySlot", mock.Anything, uint64(2)).Return(testBlock(), nil).Once()
+	client.On("GetBlockRewards", mock.Anything, mock.Anything).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{StaleCache: staleCache})
+	require.NoError(t, err)
+
+	for _, slot := range []uint64{1, 2} {
+		reward, err := svc.GetBlockReward(context.Background(), slot)
+		require.NoError(t, err)
+		assert.False(t, reward.Stale)
+	}
+
+	// Both entries expire from the main cache while the beacon node is down.
+	memCache.Clear()
+	client.On("GetBlockBySlot", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get block: %w", unavailable))
+
+	t.Run("finalized slot is served stale", func(t *testing.T) {
+		reward, err := svc.GetBlockReward(context.Background(), 1)
+		require.NoError(t, err)
+		assert.True(t, reward.Stale)
+		assert.True(t, reward.Finalized)
+		assert.Equal(t, "1000000000000", reward.Reward.String())
+	})
+
+	t.Run("stored entry is left unmarked", func(t *testing.T) {
+		cached, found := staleCache.Get("block_reward:1")
+		require.True(t, found)
+		assert.False(t, cached.(cacheEntry).value.(*domain.BlockReward).Stale)
+	})
+
+	t.Run("unfinalized slot fails", func(t *testing.T) {
+		_, err := svc.GetBlockReward(context.Background(), 2)
+		assert.ErrorIs(t, err, unavailable)
+	})
+
+	t.Run("slot never fetched fails", func(t *testing.T) {
+		_, err := svc.GetBlockReward(context.Background(), 3)
+		assert.ErrorIs(t, err, unavailable)
+	})
+}
+
+func TestValidatorService_GetBlockReward_StaleOnlyWhenUnavailable(t *testing.T) {
+	finalized := testBlock()
+	finalized.Finalized = true
+
+	for _, tt := range []struct {
+		name      string
+		err       error
+		wantStale bool
+	}{
+		{name: "connection refused", err: &url.Error{Op: "Get", URL: "http://beacon", Err: errors.New("connection refused")}, wantStale: true},
+		{name: "rate limited", err: pkgerrors.BeaconHTTPError{StatusCode: 429}, wantStale: true},
+		{name: "timeout", err: context.DeadlineExceeded, wantStale: true},
+		{name: "bad request", err: pkgerrors.BeaconHTTPError{StatusCode: 400}},
+		{name: "not found", err: pkgerrors.ErrSlotNotFound},
+		{name: "canceled", err: &url.Error{Op: "Get", URL: "http://beacon", Err: context.Canceled}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			staleCache := cache.NewMemoryCache(time.Hour, 100)
+			defer staleCache.Close()
+
+			client := new(mockEthClient)
+			client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+			client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(finalized, nil).Once()
+			client.On("GetBlockBySlot", mock.Anything, uint64(1)).Return(nil, tt.err)
+			client.On("GetBlockRewards", mock.Anything, uint64(1)).Return(&ethereum.BlockRewards{Total: "1000"}, nil)
+
+			svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{StaleCache: staleCache})
+			require.NoError(t, err)
+
+			_, err = svc.GetBlockReward(context.Background(), 1)
+			require.NoError(t, err)
+
+			reward, err := svc.GetBlockReward(context.Background(), 1)
+			if tt.wantStale {
+				require.NoError(t, err)
+				assert.True(t, reward.Stale)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatorService_GetBlockReward_ExecutionBlock(t *testing.T) {
+	raw, err := os.ReadFile("testdata/reward_estimate_block.json")
+	assert.NoError(t, err)
+	var postMerge ethereum.BeaconBlock
+	assert.NoError(t, json.Unmarshal(raw, &postMerge))
+
+	preMerge := testBlock()
+	preMerge.Data.Message.Body.ExecutionPayload = &ethereum.ExecutionPayload{BlockNumber: "0", BlockHash: zeroBlockHash}
+
+	malformed := testBlock()
+	malformed.Data.Message.Body.ExecutionPayload = &ethereum.ExecutionPayload{BlockNumber: "0x10", BlockHash: "0x3333"}
+
+	tests := []struct {
+		name       string
+		block      *ethereum.BeaconBlock
+		wantNumber uint64
+		wantHash   string
+	}{
+		{
+			name:       "post-merge",
+			block:      &postMerge,
+			wantNumber: 19500000,
+			wantHash:   "0x3333333333333333333333333333333333333333333333333333333333333333",
+		},
+		{name: "pre-merge empty payload", block: preMerge},
+		{name: "no payload", block: testBlock()},
+		{name: "malformed block number", block: malformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockEthClient)
+			client.On("GetCurrentSlot", mock.Anything).Return(uint64(10000000), nil)
+			client.On("GetBlockBySlot", mock.Anything, uint64(9000000)).Return(tt.block, nil)
+			client.On("GetBlockRewards", mock.Anything, uint64(9000000)).Return(&ethereum.BlockRewards{Total: "1"}, nil)
+
+			svc, err := NewValidatorService(client, logger.New("error"), nil, ServiceConfig{})
+			assert.NoError(t, err)
+
+			result, err := svc.GetBlockReward(context.Background(), 9000000)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, result.ExecutionBlockNumber)
+			assert.Equal(t, tt.wantHash, result.ExecutionBlockHash)
+		})
+	}
+}
+
+func TestValidatorService_GetBlockReward_OptimisticNotCached(t *testing.T) {
+	tests := []struct {
+		name              string
+		blockOptimistic   bool
+		rewardsOptimistic bool
+	}{
+		{name: "optimistic block", blockOptimistic: true},
+		{name: "optimistic rewards", rewardsOptimistic: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockEthClient)
+			cache := new(mockCache)
+
+			cache.On("Get", "block_reward:12345").Return(nil, false)
+			client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+			client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(&ethereum.BeaconBlock{
+				ExecutionOptimistic: tt.blockOptimistic,
+				Data:                ethereum.BeaconBlockData{Message: ethereum.BlockMessage{ProposerIndex: "1"}},
+			}, nil)
+			client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{
+				Total:               "1000",
+				ExecutionOptimistic: tt.rewardsOptimistic,
+			}, nil)
+
+			svc, err := NewValidatorService(client, logger.New("error"), cache, ServiceConfig{})
+			assert.NoError(t, err)
+
+			result, err := svc.GetBlockReward(context.Background(), 12345)
+			assert.NoError(t, err)
+			assert.True(t, result.Optimistic)
+			cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestValidatorService_GetSyncCommitteeByPeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		period         uint64
+		setupMocks     func(*mockEthClient, *mockCache)
+		expectedDuties *domain.SyncCommitteeDuties
+		expectedError  error
+	}{
+		{
+			name:   "past period",
+			period: 1,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "sync_duties_period:1").Return(nil, false)
+				client.On("GetSyncCommittee", mock.Anything, uint64(8192)).Return([]string{"0xvalidator1"}, nil)
+				cache.On("Set", "sync_duties_period:1", mock.Anything)
+			},
+			expectedDuties: &domain.SyncCommitteeDuties{
+				Period:          1,
+				PeriodStartSlot: 8192,
+				PeriodEndSlot:   16383,
+				Validators:      []string{"0xvalidator1"},
+			},
+		},
+		{
+			name:   "current period shares the slot-based cache entry",
+			period: 2,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "sync_duties_period:2").Return(&domain.SyncCommitteeDuties{
+					Period:     2,
+					Validators: []string{"0xcached"},
+				}, true)
+			},
+			expectedDuties: &domain.SyncCommitteeDuties{
+				Period:     2,
+				Validators: []string{"0xcached"},
+			},
+		},
+		{
+			name:   "next period",
+			period: 3,
+			setupMocks: func(client *mockEthClient, cache *mockCache) {
+				cache.On("Get", "sync_duties_period:3").Return(nil, false)
+				client.On("GetSyncCommittee", mock.Anything, uint64(24576)).Return([]string{"0xvalidator2"}, nil)
+				cache.On("Set", "sync_duties_period:3", mock.Anything)
+			},
+			expectedDuties: &domain.SyncCommitteeDuties{
+				Period:          3,
+				PeriodStartSlot: 24576,
+				PeriodEndSlot:   32767,
+				Validators:      []string{"0xvalidator2"},
+			},
+		},
+		{
+			name:          "beyond the next period",
+			period:        4,
+			setupMocks:    func(client *mockEthClient, cache *mockCache) {},
+			expectedError: pkgerrors.ErrPeriodTooFar,
+		},
+		{
+			name:          "period that would overflow a slot",
+			period:        1 << 60,
+			setupMocks:    func(client *mockEthClient, cache *mockCache) {},
+			expectedError: pkgerrors.ErrPeriodTooFar,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(mockEthClient)
+			cache := new(mockCache)
+			client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+			tt.setupMocks(client, cache)
+
+			svc, err := NewValidatorService(client, logger.New("error"), cache, ServiceConfig{})
+			assert.NoError(t, err)
+
+			result, err := svc.GetSyncCommitteeByPeriod(context.Background(), tt.period)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDuties, result)
+			}
+
+			cache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidatorService_GetBlockReward_RefreshAhead(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+
+	block := testBlock()
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(block, nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "1000"}, nil).Once()
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "2000"}, nil)
+
+	// A window longer than the TTL makes every cache hit eligible.
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{RefreshWindow: 2 * time.Minute})
+	require.NoError(t, err)
+
+	first, err := svc.GetBlockReward(context.Background(), 12345)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000", first.Reward.String())
+
+	block.Finalized = true
+
+	cached, err := svc.GetBlockReward(context.Background(), 12345)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000", cached.Reward.String())
+
+	assert.Eventually(t, func() bool {
+		value, found := memCache.Get("block_reward:12345")
+		return found && value.(cacheEntry).value.(*domain.BlockReward).Reward.String() == "2000000000000"
+	}, time.Second, 5*time.Millisecond)
+
+	refreshed, err := svc.GetBlockReward(context.Background(), 12345)
+	require.NoError(t, err)
+	assert.True(t, refreshed.Finalized)
+
+	// Finalized entries are never refreshed again.
+	client.AssertNumberOfCalls(t, "GetBlockRewards", 2)
+}
+
+func TestValidatorService_RefreshAhead_SinglePerKey(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+	memCache.Set("block_reward:12345", cacheEntry{value: &domain.BlockReward{Status: domain.StatusVanilla, Reward: big.NewInt(1)}})
+
+	release := make(chan struct{})
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Run(func(mock.Arguments) { <-release }).Return(uint64(20000), nil)
+	client.On("GetBlockBySlot", mock.Anything, uint64(12345)).Return(testBlock(), nil)
+	client.On("GetBlockRewards", mock.Anything, uint64(12345)).Return(&ethereum.BlockRewards{Total: "2"}, nil)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{RefreshWindow: 2 * time.Minute})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err := svc.GetBlockReward(context.Background(), 12345)
+		require.NoError(t, err)
+	}
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		value, _ := memCache.Get("block_reward:12345")
+		return value.(cacheEntry).value.(*domain.BlockReward).Reward.String() == "2000000000"
+	}, time.Second, 5*time.Millisecond)
+	client.AssertNumberOfCalls(t, "GetCurrentSlot", 1)
+}
+
+func TestValidatorService_Close_StopsRefresh(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute, 10)
+	defer memCache.Close()
+	memCache.Set("block_reward:12345", cacheEntry{value: &domain.BlockReward{Status: domain.StatusVanilla, Reward: big.NewInt(1)}})
+
+	started := make(chan struct{})
+	client := new(mockEthClient)
+	client.On("GetCurrentSlot", mock.Anything).Run(func(args mock.Arguments) {
+		close(started)
+		<-args.Get(0).(context.Context).Done()
+	}).Return(uint64(0), context.Canceled)
+
+	svc, err := NewValidatorService(client, logger.New("error"), memCache, ServiceConfig{RefreshWindow: 2 * time.Minute})
+	require.NoError(t, err)
+
+	_, err = svc.GetBlockReward(context.Background(), 12345)
+	require.NoError(t, err)
+	<-started
+
+	// Close cancels the refresh and waits for it.
+	closed := make(chan struct{})
+	go func() {
+		svc.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return after cancelling the refresh")
+	}
+
+	// A closed service still serves, without refreshing.
+	_, err = svc.GetBlockReward(context.Background(), 12345)
+	require.NoError(t, err)
+	client.AssertNumberOfCalls(t, "GetCurrentSlot", 1)
+}
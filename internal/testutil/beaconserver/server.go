@@ -0,0 +1,82 @@
+// Package beaconserver serves canned beacon API responses for integration tests.
+package beaconserver
+
+import (
+	"embed"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//go:embed testdata/*.json testdata/*.sse
+var fixtures embed.FS
+
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	routes map[string]string
+}
+
+// New starts a server answering the genesis, block, header, rewards and sync
+// committee endpoints from the bundled fixtures. Slot 9000000 is also the
+// finalized head, and the header list, whatever the ?slot, holds its
+// canonical header and an orphaned one with state root 0x55..55. The event
+// stream sends a head event for it and a finalized_checkpoint, then ends.
+// Any other path returns a beacon-style 404.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		routes: map[string]string{
+			"/eth/v1/beacon/genesis":                        "genesis.json",
+			"/eth/v2/beacon/blocks/9000000":                 "block_9000000.json",
+			"/eth/v1/beacon/rewards/blocks/9000000":         "rewards_9000000.json",
+			"/eth/v1/beacon/headers/9000000":                "header_9000000.json",
+			"/eth/v1/beacon/headers/finalized":              "header_9000000.json",
+			"/eth/v1/beacon/headers":                        "headers_slot_9000000.json",
+			"/eth/v1/beacon/states/8994816/sync_committees": "sync_committees_8994816.json",
+			"/eth/v1/events":                                "events.sse",
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Handle serves fixture (a file name under testdata) for requests to path.
+func (s *Server) Handle(path, fixture string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[path] = fixture
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	fixture, ok := s.routes[r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"NOT_FOUND: %s"}`, r.URL.Path)
+		return
+	}
+
+	body, err := fixtures.ReadFile("testdata/" + fixture)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if strings.HasSuffix(fixture, ".sse") {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,99 @@
+// Package beaconmath converts between times, slots, epochs and sync
+// committee periods for a given network's chain parameters.
+package beaconmath
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultSecondsPerSlot               = 12
+	DefaultSlotsPerEpoch                = 32
+	DefaultEpochsPerSyncCommitteePeriod = 256
+)
+
+// ErrBeforeGenesis is returned for times before the chain started.
+var ErrBeforeGenesis = errors.New("current time is before genesis")
+
+// Spec holds the chain parameters the slot arithmetic depends on. Build it
+// with NewSpec so zero values get the mainnet defaults; the methods divide
+// by its fields.
+type Spec struct {
+	SecondsPerSlot               uint64
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+}
+
+// NewSpec returns a Spec with zero parameters replaced by the mainnet
+// defaults.
+func NewSpec(secondsPerSlot, slotsPerEpoch, epochsPerSyncCommitteePeriod uint64) Spec {
+	if secondsPerSlot == 0 {
+		secondsPerSlot = DefaultSecondsPerSlot
+	}
+	if slotsPerEpoch == 0 {
+		slotsPerEpoch = DefaultSlotsPerEpoch
+	}
+	if epochsPerSyncCommitteePeriod == 0 {
+		epochsPerSyncCommitteePeriod = DefaultEpochsPerSyncCommitteePeriod
+	}
+	return Spec{
+		SecondsPerSlot:               secondsPerSlot,
+		SlotsPerEpoch:                slotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: epochsPerSyncCommitteePeriod,
+	}
+}
+
+// SlotAt returns the slot in progress at t for a chain that started at
+// genesisTime, in Unix seconds.
+func (s Spec) SlotAt(genesisTime uint64, t time.Time) (uint64, error) {
+	unix := t.Unix()
+	if unix < 0 || uint64(unix) < genesisTime {
+		return 0, ErrBeforeGenesis
+	}
+	return (uint64(unix) - genesisTime) / s.SecondsPerSlot, nil
+}
+
+// SlotStart returns when slot begins for a chain that started at
+// genesisTime, in Unix seconds.
+func (s Spec) SlotStart(genesisTime, slot uint64) time.Time {
+	return time.Unix(int64(genesisTime+slot*s.SecondsPerSlot), 0).UTC()
+}
+
+func (s Spec) SlotToEpoch(slot uint64) uint64 {
+	return slot / s.SlotsPerEpoch
+}
+
+func (s Spec) EpochStartSlot(epoch uint64) uint64 {
+	return epoch * s.SlotsPerEpoch
+}
+
+func (s Spec) EpochToSyncCommitteePeriod(epoch uint64) uint64 {
+	return epoch / s.EpochsPerSyncCommitteePeriod
+}
+
+func (s Spec) SlotToSyncCommitteePeriod(slot uint64) uint64 {
+	return s.EpochToSyncCommitteePeriod(s.SlotToEpoch(slot))
+}
+
+func (s Spec) SyncCommitteePeriodStartEpoch(period uint64) uint64 {
+	return period * s.EpochsPerSyncCommitteePeriod
+}
+
+func (s Spec) SyncCommitteePeriodStartSlot(period uint64) uint64 {
+	return s.EpochStartSlot(s.SyncCommitteePeriodStartEpoch(period))
+}
+
+// SlotsPerSyncCommitteePeriod is the length of one period in slots.
+func (s Spec) SlotsPerSyncCommitteePeriod() uint64 {
+	return s.EpochsPerSyncCommitteePeriod * s.SlotsPerEpoch
+}
+
+// SyncCommitteePeriodBounds returns the period slot belongs to and its first
+// and last slot.
+func (s Spec) SyncCommitteePeriodBounds(slot uint64) (period, startSlot, endSlot uint64) {
+	period = s.SlotToSyncCommitteePeriod(slot)
+	startSlot = s.SyncCommitteePeriodStartSlot(period)
+	endSlot = startSlot + s.SlotsPerSyncCommitteePeriod() - 1
+	return period, startSlot, endSlot
+}
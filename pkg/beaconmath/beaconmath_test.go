@@ -0,0 +1,127 @@
+package beaconmath
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mainnetGenesis = 1606824023
+
+func TestNewSpec(t *testing.T) {
+	assert.Equal(t, Spec{SecondsPerSlot: 12, SlotsPerEpoch: 32, EpochsPerSyncCommitteePeriod: 256}, NewSpec(0, 0, 0))
+	assert.Equal(t, Spec{SecondsPerSlot: 6, SlotsPerEpoch: 8, EpochsPerSyncCommitteePeriod: 4}, NewSpec(6, 8, 4))
+	assert.Equal(t, Spec{SecondsPerSlot: 5, SlotsPerEpoch: 32, EpochsPerSyncCommitteePeriod: 256}, NewSpec(5, 0, 0))
+}
+
+func TestSpec_SlotAt(t *testing.T) {
+	spec := NewSpec(0, 0, 0)
+
+	tests := []struct {
+		name    string
+		genesis uint64
+		at      time.Time
+		want    uint64
+		wantErr error
+	}{
+		{name: "at genesis", genesis: mainnetGenesis, at: time.Unix(mainnetGenesis, 0), want: 0},
+		{name: "within first slot", genesis: mainnetGenesis, at: time.Unix(mainnetGenesis+11, 0), want: 0},
+		{name: "second slot", genesis: mainnetGenesis, at: time.Unix(mainnetGenesis+12, 0), want: 1},
+		{name: "sub-second precision is ignored", genesis: mainnetGenesis, at: time.Unix(mainnetGenesis+23, 999_999_999), want: 1},
+		{name: "mainnet", genesis: mainnetGenesis, at: time.Unix(mainnetGenesis+9_000_000*12+5, 0), want: 9_000_000},
+		{name: "zero genesis", genesis: 0, at: time.Unix(120, 0), want: 10},
+		{name: "before genesis", genesis: mainnetGenesis, at: time.Unix(mainnetGenesis-1, 0), wantErr: ErrBeforeGenesis},
+		{name: "before the unix epoch", genesis: 0, at: time.Unix(-1, 0), wantErr: ErrBeforeGenesis},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, err := spec.SlotAt(tt.genesis, tt.at)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, slot)
+		})
+	}
+
+	t.Run("custom slot time", func(t *testing.T) {
+		slot, err := NewSpec(5, 0, 0).SlotAt(100, time.Unix(149, 0))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(9), slot)
+	})
+}
+
+func TestSpec_SlotStart(t *testing.T) {
+	spec := NewSpec(0, 0, 0)
+
+	assert.Equal(t, time.Unix(mainnetGenesis, 0).UTC(), spec.SlotStart(mainnetGenesis, 0))
+	assert.Equal(t, time.Unix(mainnetGenesis+12*100, 0).UTC(), spec.SlotStart(mainnetGenesis, 100))
+
+	for _, slot := range []uint64{0, 1, 31, 32, 8191, 9_000_000} {
+		got, err := spec.SlotAt(mainnetGenesis, spec.SlotStart(mainnetGenesis, slot))
+		require.NoError(t, err)
+		assert.Equal(t, slot, got, "slot %d", slot)
+	}
+}
+
+func TestSpec_Epochs(t *testing.T) {
+	tests := []struct {
+		spec  Spec
+		slot  uint64
+		epoch uint64
+		start uint64
+	}{
+		{spec: NewSpec(0, 0, 0), slot: 0, epoch: 0, start: 0},
+		{spec: NewSpec(0, 0, 0), slot: 31, epoch: 0, start: 0},
+		{spec: NewSpec(0, 0, 0), slot: 32, epoch: 1, start: 32},
+		{spec: NewSpec(0, 0, 0), slot: 63, epoch: 1, start: 32},
+		{spec: NewSpec(0, 0, 0), slot: 9_000_000, epoch: 281_250, start: 9_000_000},
+		{spec: NewSpec(0, 0, 0), slot: 9_000_031, epoch: 281_250, start: 9_000_000},
+		{spec: NewSpec(0, 8, 0), slot: 17, epoch: 2, start: 16},
+		{spec: NewSpec(0, 1, 0), slot: 17, epoch: 17, start: 17},
+	}
+
+	for _, tt := range tests {
+		epoch := tt.spec.SlotToEpoch(tt.slot)
+		assert.Equal(t, tt.epoch, epoch, "slot %d", tt.slot)
+		assert.Equal(t, tt.start, tt.spec.EpochStartSlot(epoch), "slot %d", tt.slot)
+	}
+}
+
+func TestSpec_SyncCommitteePeriods(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   Spec
+		slot   uint64
+		period uint64
+		start  uint64
+		end    uint64
+	}{
+		{name: "genesis", spec: NewSpec(0, 0, 0), slot: 0, period: 0, start: 0, end: 8191},
+		{name: "last slot of period 0", spec: NewSpec(0, 0, 0), slot: 8191, period: 0, start: 0, end: 8191},
+		{name: "first slot of period 1", spec: NewSpec(0, 0, 0), slot: 8192, period: 1, start: 8192, end: 16383},
+		{name: "within period 1", spec: NewSpec(0, 0, 0), slot: 12345, period: 1, start: 8192, end: 16383},
+		{name: "mainnet", spec: NewSpec(0, 0, 0), slot: 9_000_000, period: 1098, start: 8_994_816, end: 9_003_007},
+		{name: "custom epoch length", spec: NewSpec(0, 8, 0), slot: 2048, period: 1, start: 2048, end: 4095},
+		{name: "custom period length", spec: NewSpec(0, 32, 4), slot: 300, period: 2, start: 256, end: 383},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, start, end := tt.spec.SyncCommitteePeriodBounds(tt.slot)
+			assert.Equal(t, tt.period, period)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+
+			assert.Equal(t, tt.period, tt.spec.SlotToSyncCommitteePeriod(tt.slot))
+			assert.Equal(t, tt.period, tt.spec.EpochToSyncCommitteePeriod(tt.spec.SlotToEpoch(tt.slot)))
+			assert.Equal(t, tt.start, tt.spec.SyncCommitteePeriodStartSlot(tt.period))
+			assert.Equal(t, tt.spec.SlotToEpoch(tt.start), tt.spec.SyncCommitteePeriodStartEpoch(tt.period))
+			assert.Equal(t, end-start+1, tt.spec.SlotsPerSyncCommitteePeriod())
+		})
+	}
+}
@@ -2,15 +2,49 @@ package cache
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	// DefaultShards is the shard count used by NewMemoryCache.
+	DefaultShards = 16
+	// minShardSize keeps small caches from being split so finely that
+	// per-shard eviction drops entries long before the cache is full.
+	minShardSize = 64
+	// MinTTL is the shortest TTL a cache accepts; shorter ones, including
+	// zero and negative values, are raised to it. The expiry sweep runs every
+	// TTL/2, so this also bounds how often it can run.
+	MinTTL = time.Second
+)
+
+// MemoryCache is split into independently locked shards by key hash, so the
+// expiry sweep and evictions only block the keys of the shard they're in.
+// Size is bounded per shard, so eviction picks the oldest entry of the
+// shard being written rather than of the whole cache.
 type MemoryCache struct {
-	mu       sync.RWMutex
-	items    map[string]cacheItem
+	shards   []*shard
 	ttl      time.Duration
 	maxSize  int
 	stopChan chan struct{}
+	done     chan struct{}
+}
+
+// shard mirrors its item count in size after every change, so stats are
+// read with atomics instead of the shard lock.
+type shard struct {
+	mu        sync.RWMutex
+	items     map[string]cacheItem
+	maxSize   int
+	size      atomic.Int64
+	evictions atomic.Uint64
+}
+
+type Stats struct {
+	Size    int
+	MaxSize int
+	// Evictions counts entries dropped to make room, not expirations.
+	Evictions uint64
 }
 
 type cacheItem struct {
@@ -19,11 +53,36 @@ type cacheItem struct {
 }
 
 func NewMemoryCache(ttl time.Duration, maxSize int) *MemoryCache {
+	return NewShardedMemoryCache(ttl, maxSize, DefaultShards)
+}
+
+// NewShardedMemoryCache splits maxSize across shards. The count is lowered
+// so every shard holds at least minShardSize entries, and non-positive
+// counts mean a single shard. A ttl below MinTTL is raised to it.
+func NewShardedMemoryCache(ttl time.Duration, maxSize, shards int) *MemoryCache {
+	if ttl < MinTTL {
+		ttl = MinTTL
+	}
+	if shards > maxSize/minShardSize {
+		shards = maxSize / minShardSize
+	}
+	if shards < 1 {
+		shards = 1
+	}
+
 	c := &MemoryCache{
-		items:    make(map[string]cacheItem),
+		shards:   make([]*shard, shards),
 		ttl:      ttl,
 		maxSize:  maxSize,
 		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for i := range c.shards {
+		size := maxSize / shards
+		if i < maxSize%shards {
+			size++
+		}
+		c.shards[i] = &shard{items: make(map[string]cacheItem), maxSize: size}
 	}
 
 	go c.cleanupExpired()
@@ -31,56 +90,107 @@ func NewMemoryCache(ttl time.Duration, maxSize int) *MemoryCache {
 	return c
 }
 
-func (c *MemoryCache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// shardFor picks the shard of key by its FNV-1a hash.
+func (c *MemoryCache) shardFor(key string) *shard {
+	if len(c.shards) == 1 {
+		return c.shards[0]
+	}
 
-	item, found := c.items[key]
-	if !found {
-		return nil, false
+	hash := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
 	}
+	return c.shards[hash%uint32(len(c.shards))]
+}
+
+func (c *MemoryCache) Get(key string) (interface{}, bool) {
+	value, _, found := c.GetWithExpiration(key)
+	return value, found
+}
+
+// GetWithExpiration is Get that also reports when the entry expires.
+func (c *MemoryCache) GetWithExpiration(key string) (interface{}, time.Time, bool) {
+	s := c.shardFor(key)
 
-	if time.Now().After(item.expiration) {
-		return nil, false
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, found := s.items[key]
+	if !found || time.Now().After(item.expiration) {
+		return nil, time.Time{}, false
 	}
 
-	return item.value, true
+	return item.value, item.expiration, true
 }
 
 func (c *MemoryCache) Set(key string, value interface{}) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	s := c.shardFor(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if len(c.items) >= c.maxSize {
-		c.evictOldest()
+	if _, exists := s.items[key]; !exists && len(s.items) >= s.maxSize {
+		s.evictOldest()
 	}
 
-	c.items[key] = cacheItem{
+	s.items[key] = cacheItem{
 		value:      value,
 		expiration: time.Now().Add(c.ttl),
 	}
+	s.size.Store(int64(len(s.items)))
+}
+
+func (c *MemoryCache) Stats() Stats {
+	return c.SnapshotStats(false)
+}
+
+// SnapshotStats reads the stats without taking any shard lock. With reset,
+// the counters are zeroed as they're read, so consecutive snapshots each
+// cover only what happened since the previous one and no eviction is
+// counted twice or lost. Size is a gauge and is never reset.
+func (c *MemoryCache) SnapshotStats(reset bool) Stats {
+	stats := Stats{MaxSize: c.maxSize}
+	for _, s := range c.shards {
+		stats.Size += int(s.size.Load())
+		if reset {
+			stats.Evictions += s.evictions.Swap(0)
+		} else {
+			stats.Evictions += s.evictions.Load()
+		}
+	}
+	return stats
 }
 
 func (c *MemoryCache) Delete(key string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	s := c.shardFor(key)
 
-	delete(c.items, key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+	s.size.Store(int64(len(s.items)))
 }
 
 func (c *MemoryCache) Clear() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.items = make(map[string]cacheItem)
+	for _, s := range c.shards {
+		s.mu.Lock()
+		s.items = make(map[string]cacheItem)
+		s.size.Store(0)
+		s.mu.Unlock()
+	}
 }
 
+// Close stops the expiry cleanup and waits for it to exit.
 func (c *MemoryCache) Close() {
 	close(c.stopChan)
+	<-c.done
 }
 
 func (c *MemoryCache) cleanupExpired() {
-	ticker := time.NewTicker(c.ttl / 2)
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cleanupInterval())
 	defer ticker.Stop()
 
 	for {
@@ -93,23 +203,42 @@ func (c *MemoryCache) cleanupExpired() {
 	}
 }
 
-func (c *MemoryCache) removeExpired() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *MemoryCache) cleanupInterval() time.Duration {
+	return c.ttl / 2
+}
 
+// removeExpired sweeps the shards in parallel, each under its own lock.
+func (c *MemoryCache) removeExpired() {
 	now := time.Now()
-	for key, item := range c.items {
+
+	var wg sync.WaitGroup
+	for _, s := range c.shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.removeExpired(now)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *shard) removeExpired(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, item := range s.items {
 		if now.After(item.expiration) {
-			delete(c.items, key)
+			delete(s.items, key)
 		}
 	}
+	s.size.Store(int64(len(s.items)))
 }
 
-func (c *MemoryCache) evictOldest() {
+func (s *shard) evictOldest() {
 	var oldestKey string
 	var oldestTime time.Time
 
-	for key, item := range c.items {
+	for key, item := range s.items {
 		if oldestTime.IsZero() || item.expiration.Before(oldestTime) {
 			oldestKey = key
 			oldestTime = item.expiration
@@ -117,6 +246,7 @@ func (c *MemoryCache) evictOldest() {
 	}
 
 	if oldestKey != "" {
-		delete(c.items, oldestKey)
+		delete(s.items, oldestKey)
+		s.evictions.Add(1)
 	}
 }
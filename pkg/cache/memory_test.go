@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache_Sharded(t *testing.T) {
+	c := NewShardedMemoryCache(time.Minute, 1000, 8)
+	defer c.Close()
+
+	assert.Len(t, c.shards, 8)
+
+	for i := 0; i < 500; i++ {
+		c.Set(fmt.Sprintf("key:%d", i), i)
+	}
+	for i := 0; i < 500; i++ {
+		value, found := c.Get(fmt.Sprintf("key:%d", i))
+		assert.True(t, found)
+		assert.Equal(t, i, value)
+	}
+
+	c.Delete("key:1")
+	_, found := c.Get("key:1")
+	assert.False(t, found)
+	assert.Equal(t, Stats{Size: 499, MaxSize: 1000}, c.Stats())
+
+	c.Clear()
+	assert.Equal(t, 0, c.Stats().Size)
+}
+
+func TestMemoryCache_ShardCount(t *testing.T) {
+	tests := []struct {
+		maxSize, shards, want int
+	}{
+		{maxSize: 1000, shards: 16, want: 15},
+		{maxSize: 10, shards: 16, want: 1},
+		{maxSize: 4096, shards: 16, want: 16},
+		{maxSize: 4096, shards: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		c := NewShardedMemoryCache(time.Minute, tt.maxSize, tt.shards)
+		assert.Len(t, c.shards, tt.want, "maxSize=%d shards=%d", tt.maxSize, tt.shards)
+
+		total := 0
+		for _, s := range c.shards {
+			total += s.maxSize
+		}
+		assert.Equal(t, tt.maxSize, total)
+		c.Close()
+	}
+}
+
+func TestMemoryCache_EvictsWithinShard(t *testing.T) {
+	c := NewShardedMemoryCache(time.Minute, 128, 2)
+	defer c.Close()
+
+	for i := 0; i < 1000; i++ {
+		c.Set(fmt.Sprintf("key:%d", i), i)
+	}
+
+	stats := c.Stats()
+	assert.Equal(t, 128, stats.Size)
+	assert.Equal(t, uint64(1000-128), stats.Evictions)
+}
+
+func TestMemoryCache_SnapshotStats(t *testing.T) {
+	c := NewShardedMemoryCache(time.Minute, 128, 2)
+	defer c.Close()
+
+	const writers, writes = 4, 2000
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < writes; i++ {
+				key := fmt.Sprintf("key:%d:%d", w, i)
+				c.Set(key, i)
+				c.Get(key)
+			}
+		}()
+	}
+
+	stop := make(chan struct{})
+	done := make(chan uint64)
+	go func() {
+		var snapshotted uint64
+		for {
+			select {
+			case <-stop:
+				done <- snapshotted
+				return
+			default:
+			}
+			stats := c.SnapshotStats(true)
+			assert.LessOrEqual(t, stats.Size, stats.MaxSize)
+			snapshotted += stats.Evictions
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	snapshotted := <-done + c.SnapshotStats(true).Evictions
+
+	// Every eviction is counted by exactly one snapshot.
+	assert.Equal(t, uint64(writers*writes-128), snapshotted)
+	assert.Equal(t, Stats{Size: 128, MaxSize: 128}, c.Stats())
+}
+
+func TestMemoryCache_RemoveExpired(t *testing.T) {
+	c := NewShardedMemoryCache(time.Hour, 1024, 4)
+	defer c.Close()
+
+	for i := 0; i < 100; i++ {
+		c.Set(fmt.Sprintf("key:%d", i), i)
+	}
+	s := c.shardFor("short")
+	s.items["short"] = cacheItem{value: true, expiration: time.Now().Add(-time.Second)}
+
+	c.removeExpired()
+
+	assert.Equal(t, 100, c.Stats().Size)
+	_, found := c.Get("short")
+	assert.False(t, found)
+}
+
+func TestMemoryCache_ClampsTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Minute, time.Nanosecond, 500 * time.Microsecond} {
+		c := NewMemoryCache(ttl, 10)
+
+		assert.Equal(t, MinTTL, c.ttl, "ttl=%s", ttl)
+		assert.Equal(t, MinTTL/2, c.cleanupInterval(), "ttl=%s", ttl)
+
+		c.Set("key", true)
+		_, found := c.Get("key")
+		assert.True(t, found, "ttl=%s", ttl)
+		c.Close()
+	}
+
+	c := NewMemoryCache(time.Minute, 10)
+	defer c.Close()
+	assert.Equal(t, 30*time.Second, c.cleanupInterval())
+}
+
+// BenchmarkGetDuringCleanup reports the p99 Get latency while the expiry
+// sweep runs every 10ms; shards=1 is the cache before sharding.
+func BenchmarkGetDuringCleanup(b *testing.B) {
+	const size = 100000
+
+	for _, shards := range []int{1, DefaultShards} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			c := NewShardedMemoryCache(time.Hour, size, shards)
+			defer c.Close()
+
+			keys := make([]string, size)
+			for i := range keys {
+				keys[i] = fmt.Sprintf("block_reward:%d", i)
+				c.Set(keys[i], i)
+			}
+
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ticker := time.NewTicker(10 * time.Millisecond)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+						c.removeExpired()
+					}
+				}
+			}()
+
+			latencies := make([]time.Duration, b.N)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				c.Get(keys[i%size])
+				latencies[i] = time.Since(start)
+			}
+			b.StopTimer()
+
+			close(stop)
+			wg.Wait()
+
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns")
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 )
 
 var (
@@ -10,9 +13,35 @@ var (
 	ErrFutureSlot         = errors.New("requested slot is in the future")
 	ErrSlotTooFarInFuture = errors.New("requested slot is too far in the future")
 	ErrInvalidSlot        = errors.New("invalid slot number")
+	ErrInvalidSlotRange   = errors.New("invalid slot range")
+	ErrValidatorNotFound  = errors.New("validator not found")
+	ErrStateNotFound      = errors.New("state not found")
+	ErrInvalidStateID     = errors.New("invalid state ID")
+	ErrInvalidStateRoot   = errors.New("invalid state root")
+	ErrHeaderNotFound     = errors.New("block header not found")
+	ErrInvalidPeriod      = errors.New("invalid sync committee period")
+	ErrInvalidEpoch       = errors.New("invalid epoch")
+	ErrFutureEpoch        = errors.New("requested epoch is in the future")
+	ErrPeriodTooFar       = errors.New("requested sync committee period is beyond the next period")
+	ErrInvalidPeriodRange = errors.New("invalid sync committee period range")
+	ErrInvalidCursor      = errors.New("invalid pagination cursor")
+	ErrInvalidPagination  = errors.New("invalid pagination parameter")
+	ErrInvalidUnit        = errors.New("invalid reward unit")
+	ErrInvalidBatch       = errors.New("invalid batch request")
+	ErrInvalidTopic       = errors.New("invalid event topic")
+	ErrUnknownQueryParam  = errors.New("unknown query parameter")
 	ErrRPCConnection      = errors.New("RPC connection error")
 	ErrTimeout            = errors.New("request timeout")
 	ErrInternal           = errors.New("internal server error")
+	ErrNotSupported       = errors.New("beacon endpoint not supported")
+	ErrResponseTooLarge   = errors.New("beacon response exceeds size limit")
+	ErrBeaconBadRequest   = errors.New("beacon node rejected the request")
+	ErrBeaconUnavailable  = errors.New("beacon node unavailable")
+	ErrBeaconFailure      = errors.New("beacon node error")
+	ErrMalformedUpstream  = errors.New("beacon node returned malformed data")
+	// ErrUnexpectedBeaconResponse is a response missing a required field,
+	// usually because the beacon API shape changed.
+	ErrUnexpectedBeaconResponse = fmt.Errorf("%w: unexpected response shape", ErrMalformedUpstream)
 )
 
 type ValidationError struct {
@@ -39,6 +68,33 @@ func (e RPCError) Error() string {
 	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
 }
 
+// BeaconHTTPError is returned when the beacon node answers with a status the
+// client has no dedicated error for.
+type BeaconHTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e BeaconHTTPError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// UpstreamDataError reports a beacon response that decoded but holds a value
+// the API can't accept.
+type UpstreamDataError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e UpstreamDataError) Error() string {
+	return fmt.Sprintf("malformed %s from beacon node %q: %s", e.Field, e.Value, e.Reason)
+}
+
+func (e UpstreamDataError) Unwrap() error {
+	return ErrMalformedUpstream
+}
+
 func NewValidationError(field string, value interface{}, err error) error {
 	return ValidationError{
 		Field: field,
@@ -48,15 +104,67 @@ func NewValidationError(field string, value interface{}, err error) error {
 }
 
 func IsNotFound(err error) bool {
-	return errors.Is(err, ErrSlotNotFound)
+	return errors.Is(err, ErrSlotNotFound) || errors.Is(err, ErrValidatorNotFound) ||
+		errors.Is(err, ErrStateNotFound) ||
+		errors.Is(err, ErrHeaderNotFound)
 }
 
 func IsBadRequest(err error) bool {
 	return errors.Is(err, ErrFutureSlot) ||
 		errors.Is(err, ErrInvalidSlot) ||
-		errors.Is(err, ErrSlotTooFarInFuture)
+		errors.Is(err, ErrInvalidUnit) ||
+		errors.Is(err, ErrInvalidBatch) ||
+		errors.Is(err, ErrInvalidTopic) ||
+		errors.Is(err, ErrSlotTooFarInFuture) ||
+		errors.Is(err, ErrInvalidPeriod) ||
+		errors.Is(err, ErrInvalidEpoch) ||
+		errors.Is(err, ErrFutureEpoch) ||
+		errors.Is(err, ErrInvalidStateID) ||
+		errors.Is(err, ErrInvalidStateRoot) ||
+		errors.Is(err, ErrPeriodTooFar) ||
+		errors.Is(err, ErrInvalidPeriodRange) ||
+		errors.Is(err, ErrInvalidSlotRange) ||
+		errors.Is(err, ErrInvalidCursor) ||
+		errors.Is(err, ErrInvalidPagination) ||
+		errors.Is(err, ErrUnknownQueryParam)
+}
+
+func IsMalformedUpstream(err error) bool {
+	return errors.Is(err, ErrMalformedUpstream)
 }
 
 func IsTimeout(err error) bool {
 	return errors.Is(err, ErrTimeout)
 }
+
+func IsNotSupported(err error) bool {
+	return errors.Is(err, ErrNotSupported)
+}
+
+// IsUpstreamUnavailable reports whether err means the beacon node couldn't
+// be reached or couldn't answer right now: a transport failure, a timeout,
+// or a 429 or 5xx response. Requests the caller canceled don't count.
+func IsUpstreamUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrTimeout) || errors.Is(err, ErrRPCConnection) ||
+		errors.Is(err, ErrBeaconUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if status, ok := BeaconStatusCode(err); ok {
+		return status == http.StatusTooManyRequests || status >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// BeaconStatusCode returns the upstream status carried by a BeaconHTTPError in
+// err's chain.
+func BeaconStatusCode(err error) (int, bool) {
+	var httpErr BeaconHTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, true
+	}
+	return 0, false
+}
@@ -4,44 +4,265 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/pkg/beaconmath"
 	"github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/logger"
 )
 
 type Client interface {
 	GetBlockBySlot(ctx context.Context, slot uint64) (*BeaconBlock, error)
 	GetSyncCommittee(ctx context.Context, slot uint64) ([]string, error)
+	GetNextSyncCommittee(ctx context.Context, slot uint64) ([]string, error)
+	GetSyncCommitteeAtState(ctx context.Context, stateID string) ([]string, error)
 	GetCurrentSlot(ctx context.Context) (uint64, error)
+	Initialized() bool
 	GetBlockRewards(ctx context.Context, slot uint64) (*BlockRewards, error)
+	GetBlockHeader(ctx context.Context, blockID string) (*HeaderResponse, error)
+	GetBlockHeadersAtSlot(ctx context.Context, slot uint64) ([]HeaderData, error)
 	GetProposerDuties(ctx context.Context, epoch uint64) ([]ProposerDuty, error)
+	GetValidatorPubkey(ctx context.Context, index uint64) (string, error)
+	GetValidatorPubkeys(ctx context.Context, indices []uint64) (map[uint64]string, error)
+	GetPeerCount(ctx context.Context) (*PeerCount, error)
+	GetNodeVersion(ctx context.Context) (string, error)
+	SubscribeEvents(ctx context.Context, topics []string, fn func(Event)) error
 }
 
 type client struct {
 	httpClient     *http.Client
+	streamClient   *http.Client
 	rpcEndpoint    string
+	beaconMode     BeaconMode
 	requestCounter uint64
-	config         *config.RequestConfig
+	sem            chan struct{}
+	logger         logger.Logger
+
+	timeout          time.Duration
+	classTimeouts    map[EndpointClass]time.Duration
+	maxConcurrency   int
+	slowThreshold    time.Duration
+	logSampleRate    uint64
+	logSampleCounter atomic.Uint64
+	maxResponseBytes int64
+	strictResponses  bool
+	maxRetries       int
+	retryDelay       time.Duration
+	spec             beaconmath.Spec
+	now              func() time.Time
+
+	// initialized is set once genesisTime is known, from config or the
+	// first successful fetch.
+	genesisGroup singleflight.Group
+	genesisTime  atomic.Uint64
+	initialized  atomic.Bool
+}
+
+func NewClient(endpoint string, opts ...Option) (Client, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+
+	c := &client{
+		rpcEndpoint:      strings.TrimSuffix(endpoint, "/"),
+		beaconMode:       BeaconModeREST,
+		logger:           logger.Nop(),
+		maxResponseBytes: defaultMaxResponseBytes,
+		logSampleRate:    1,
+		spec:             beaconmath.NewSpec(0, 0, 0),
+		now:              time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.beaconMode != BeaconModeREST && c.beaconMode != BeaconModeJSONRPC {
+		return nil, fmt.Errorf("unknown beacon mode %q", c.beaconMode)
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{
+			Timeout:   defaultTimeout,
+			Transport: newTransport(defaultTransportConfig, c.maxConcurrency),
+		}
+	}
+
+	if c.timeout > 0 {
+		// Each attempt's context applies its own timeout; the shared client
+		// only has to allow the longest of them.
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		for _, timeout := range c.classTimeouts {
+			httpClient.Timeout = max(httpClient.Timeout, timeout)
+		}
+		c.httpClient = &httpClient
+	}
+
+	c.streamClient = &http.Client{
+		Transport: c.httpClient.Transport,
+	}
+	c.sem = newSemaphore(c.maxConcurrency)
+
+	return c, nil
+}
+
+func NewClientFromConfig(cfg *config.Config, logger logger.Logger) (Client, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+
+	transport, err := configTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(cfg.Ethereum.RPCEndpoint,
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithTimeout(cfg.Request.Timeout),
+		WithEndpointTimeout(ClassBlock, cfg.Request.BlockTimeout),
+		WithEndpointTimeout(ClassState, cfg.Request.StateTimeout),
+		WithLogger(logger),
+		WithMaxConcurrency(cfg.Request.MaxConcurrency),
+		WithSlowRequestThreshold(cfg.Request.SlowRequestThreshold),
+		WithLogSampleRate(cfg.Ethereum.LogSampleRate),
+		WithMaxResponseBytes(cfg.Ethereum.MaxResponseBytes),
+		WithStrictResponses(cfg.Ethereum.StrictResponses),
+		WithRetries(cfg.Request.MaxRetries, cfg.Request.RetryDelay),
+		WithBeaconMode(BeaconMode(cfg.Ethereum.BeaconMode)),
+		WithChainSpec(cfg.Ethereum.SecondsPerSlot, cfg.Ethereum.SlotsPerEpoch, cfg.Ethereum.GenesisTime),
+	)
+}
+
+// configTransport builds the beacon transport, wrapped for recording or
+// replaced by recorded responses when configured.
+func configTransport(cfg *config.Config) (http.RoundTripper, error) {
+	if cfg.Ethereum.ReplayDir != "" {
+		return NewReplayTransport(cfg.Ethereum.ReplayDir)
+	}
+
+	transport := newTransport(cfg.Transport, cfg.Request.MaxConcurrency)
+	if cfg.Ethereum.RecordDir != "" {
+		return NewRecordingTransport(transport, cfg.Ethereum.RecordDir, cfg.Ethereum.MaxResponseBytes)
+	}
+	return transport, nil
+}
+
+func newTransport(cfg config.TransportConfig, maxConcurrency int) *http.Transport {
+	// Keep at least one idle connection per permit so a saturated semaphore
+	// doesn't churn connections to the single beacon host.
+	idlePerHost := cfg.MaxIdleConnsPerHost
+	if idlePerHost < maxConcurrency {
+		idlePerHost = maxConcurrency
+	}
+
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   idlePerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+	}
+}
+
+// maxDrainBytes bounds how much of an unread body closeBody discards. A longer
+// remainder, such as an oversized response, costs more to read than a new
+// connection does.
+const maxDrainBytes = 64 << 10
+
+// closeBody drains the body before closing so the connection can be reused.
+func closeBody(body io.ReadCloser) {
+	io.CopyN(io.Discard, body, maxDrainBytes)
+	body.Close()
+}
+
+// limitedReader fails with ErrResponseTooLarge once more than remaining bytes
+// are read, unlike io.LimitReader which silently truncates.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (c *client) limitBody(body io.Reader) io.Reader {
+	return &limitedReader{r: body, remaining: c.maxResponseBytes}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Probe for one more byte to tell a body that fits exactly from an
+		// oversized one.
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, errors.ErrResponseTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+func newSemaphore(size int) chan struct{} {
+	if size <= 0 {
+		return nil
+	}
+	return make(chan struct{}, size)
+}
+
+func (c *client) acquire(ctx context.Context) error {
+	if c.sem == nil {
+		return nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+	default:
+		semaphoreWaits.Inc()
+		start := time.Now()
+		select {
+		case c.sem <- struct{}{}:
+			semaphoreWaitDuration.Observe(time.Since(start).Seconds())
+		case <-ctx.Done():
+			semaphoreWaitDuration.Observe(time.Since(start).Seconds())
+			return ctx.Err()
+		}
+	}
+
+	semaphoreInFlight.Inc()
+	return nil
 }
 
-func NewClient(cfg *config.Config) (Client, error) {
-	return &client{
-		httpClient: &http.Client{
-			Timeout: cfg.Request.Timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		},
-		rpcEndpoint: cfg.Ethereum.RPCEndpoint,
-		config:      &cfg.Request,
-	}, nil
+func (c *client) release() {
+	if c.sem == nil {
+		return
+	}
+
+	<-c.sem
+	semaphoreInFlight.Dec()
 }
 
 type rpcRequest struct {
@@ -65,8 +286,10 @@ type rpcError struct {
 }
 
 type BeaconBlock struct {
-	Version string          `json:"version"`
-	Data    BeaconBlockData `json:"data"`
+	Version             string          `json:"version"`
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
+	Data                BeaconBlockData `json:"data"`
 }
 
 type BeaconBlockData struct {
@@ -83,17 +306,62 @@ type BlockMessage struct {
 }
 
 type BlockBody struct {
-	ExecutionPayload *ExecutionPayload `json:"execution_payload,omitempty"`
-	SyncAggregate    *SyncAggregate    `json:"sync_aggregate,omitempty"`
+	RandaoReveal           string            `json:"randao_reveal,omitempty"`
+	Eth1Data               *Eth1Data         `json:"eth1_data,omitempty"`
+	Graffiti               string            `json:"graffiti,omitempty"`
+	ProposerSlashings      []json.RawMessage `json:"proposer_slashings,omitempty"`
+	AttesterSlashings      []json.RawMessage `json:"attester_slashings,omitempty"`
+	Attestations           []Attestation     `json:"attestations,omitempty"`
+	Deposits               []json.RawMessage `json:"deposits,omitempty"`
+	VoluntaryExits         []json.RawMessage `json:"voluntary_exits,omitempty"`
+	ExecutionPayload       *ExecutionPayload `json:"execution_payload,omitempty"`
+	ExecutionPayloadHeader *ExecutionPayload `json:"execution_payload_header,omitempty"`
+	SyncAggregate          *SyncAggregate    `json:"sync_aggregate,omitempty"`
+	// BlobKZGCommitments has one entry per blob, from Deneb on.
+	BlobKZGCommitments []string `json:"blob_kzg_commitments,omitempty"`
+}
+
+type Eth1Data struct {
+	DepositRoot  string `json:"deposit_root"`
+	DepositCount string `json:"deposit_count"`
+	BlockHash    string `json:"block_hash"`
+}
+
+type Attestation struct {
+	AggregationBits string          `json:"aggregation_bits"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	Signature       string          `json:"signature,omitempty"`
+	CommitteeBits   string          `json:"committee_bits,omitempty"`
 }
 
 type ExecutionPayload struct {
-	FeeRecipient  string   `json:"fee_recipient"`
-	BlockHash     string   `json:"block_hash"`
-	Transactions  []string `json:"transactions"`
-	BaseFeePerGas string   `json:"base_fee_per_gas"`
-	GasUsed       string   `json:"gas_used"`
-	BlockNumber   string   `json:"block_number"`
+	ParentHash       string            `json:"parent_hash,omitempty"`
+	FeeRecipient     string            `json:"fee_recipient"`
+	StateRoot        string            `json:"state_root,omitempty"`
+	ReceiptsRoot     string            `json:"receipts_root,omitempty"`
+	LogsBloom        string            `json:"logs_bloom,omitempty"`
+	PrevRandao       string            `json:"prev_randao,omitempty"`
+	BlockNumber      string            `json:"block_number"`
+	GasLimit         string            `json:"gas_limit,omitempty"`
+	GasUsed          string            `json:"gas_used"`
+	Timestamp        string            `json:"timestamp,omitempty"`
+	ExtraData        string            `json:"extra_data,omitempty"`
+	BaseFeePerGas    string            `json:"base_fee_per_gas"`
+	BlockHash        string            `json:"block_hash"`
+	Transactions     []string          `json:"transactions"`
+	TransactionsRoot string            `json:"transactions_root,omitempty"`
+	Withdrawals      []json.RawMessage `json:"withdrawals,omitempty"`
+}
+
+// IsBlinded reports whether the block only carries a blinded execution
+// payload header (transactions_root instead of the transaction list).
+func (b BlockBody) IsBlinded() bool {
+	if b.ExecutionPayloadHeader != nil {
+		return true
+	}
+	return b.ExecutionPayload != nil &&
+		b.ExecutionPayload.Transactions == nil &&
+		b.ExecutionPayload.TransactionsRoot != ""
 }
 
 type SyncAggregate struct {
@@ -108,6 +376,14 @@ type BlockRewards struct {
 	SyncAggregate     string `json:"sync_aggregate"`
 	ProposerSlashings string `json:"proposer_slashings"`
 	AttesterSlashings string `json:"attester_slashings"`
+
+	// ExecutionOptimistic is copied from the response envelope.
+	ExecutionOptimistic bool `json:"-"`
+}
+
+type BlockRewardsResponse struct {
+	ExecutionOptimistic bool         `json:"execution_optimistic"`
+	Data                BlockRewards `json:"data"`
 }
 
 type SyncCommitteeResponse struct {
@@ -128,6 +404,23 @@ type ProposerDutiesResponse struct {
 	Data []ProposerDuty `json:"data"`
 }
 
+type ValidatorResponse struct {
+	Data ValidatorData `json:"data"`
+}
+
+type ValidatorsResponse struct {
+	Data []ValidatorData `json:"data"`
+}
+
+type ValidatorData struct {
+	Index     string        `json:"index"`
+	Validator ValidatorInfo `json:"validator"`
+}
+
+type ValidatorInfo struct {
+	Pubkey string `json:"pubkey"`
+}
+
 type GenesisResponse struct {
 	Data GenesisData `json:"data"`
 }
@@ -136,12 +429,37 @@ type GenesisData struct {
 	GenesisTime string `json:"genesis_time"`
 }
 
+type PeerCountResponse struct {
+	Data PeerCount `json:"data"`
+}
+
+type PeerCount struct {
+	Connected     string `json:"connected"`
+	Connecting    string `json:"connecting"`
+	Disconnected  string `json:"disconnected"`
+	Disconnecting string `json:"disconnecting"`
+}
+
+type NodeVersionResponse struct {
+	Data struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
+
 type HeaderResponse struct {
-	Data HeaderData `json:"data"`
+	ExecutionOptimistic bool       `json:"execution_optimistic"`
+	Finalized           bool       `json:"finalized"`
+	Data                HeaderData `json:"data"`
+}
+
+type HeadersResponse struct {
+	Data []HeaderData `json:"data"`
 }
 
 type HeaderData struct {
-	Header HeaderInfo `json:"header"`
+	Root      string     `json:"root"`
+	Canonical bool       `json:"canonical"`
+	Header    HeaderInfo `json:"header"`
 }
 
 type HeaderInfo struct {
@@ -149,7 +467,11 @@ type HeaderInfo struct {
 }
 
 type HeaderMessage struct {
-	Slot string `json:"slot"`
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
 }
 
 func (c *client) doRequest(ctx context.Context, method string, params interface{}, result interface{}) error {
@@ -174,13 +496,18 @@ func (c *client) doRequest(ctx context.Context, method string, params interface{
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
+	defer closeBody(resp.Body)
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(c.limitBody(resp.Body))
 	if err != nil {
 		return fmt.Errorf("failed to read response: %w", err)
 	}
@@ -207,8 +534,135 @@ func (c *client) doRequest(ctx context.Context, method string, params interface{
 	return nil
 }
 
-func (c *client) doBeaconRequest(ctx context.Context, endpoint string, result interface{}) error {
-	url := fmt.Sprintf("%s/eth/v1/beacon/%s", c.rpcEndpoint, endpoint)
+func (c *client) doBeaconRequest(ctx context.Context, path string, result interface{}) error {
+	sampled := c.sampleLogs()
+	ctx = context.WithValue(ctx, logSampledKey{}, sampled)
+
+	start := time.Now()
+	attempts, err := c.doBeaconRequestWithRetries(ctx, path, result)
+	c.logRequest(path, attempts, time.Since(start), sampled, err)
+	return err
+}
+
+// logSampledKey carries whether the request was picked by sampleLogs, so its
+// retry logs follow the same decision as its summary line.
+type logSampledKey struct{}
+
+// sampleLogs picks one in logSampleRate requests to log in full.
+func (c *client) sampleLogs() bool {
+	return c.logSampleRate != 0 && (c.logSampleCounter.Add(1)-1)%c.logSampleRate == 0
+}
+
+// logSampled reports whether ctx belongs to a sampled request. Requests
+// that didn't go through doBeaconRequest are logged.
+func logSampled(ctx context.Context) bool {
+	sampled, ok := ctx.Value(logSampledKey{}).(bool)
+	return sampled || !ok
+}
+
+// doBeaconRequestWithRetries retries transient failures up to maxRetries
+// times, waiting retryDelay between attempts, and returns how many attempts
+// were made. Each attempt gets the timeout of the path's endpoint class.
+func (c *client) doBeaconRequestWithRetries(ctx context.Context, path string, result interface{}) (int, error) {
+	endpoint := endpointLabel(path)
+	timeout := c.timeoutFor(path)
+
+	for attempt := 1; ; attempt++ {
+		beaconRequestAttempts.WithLabelValues(endpoint, strconv.Itoa(attempt)).Inc()
+
+		err := c.doBeaconAttemptWithTimeout(ctx, timeout, path, result)
+		if err == nil || !isRetryable(err) || ctx.Err() != nil {
+			return attempt, err
+		}
+
+		if attempt > c.maxRetries {
+			if c.maxRetries > 0 {
+				beaconRetriesExhausted.WithLabelValues(endpoint).Inc()
+			}
+			return attempt, err
+		}
+
+		if logSampled(ctx) {
+			c.logger.Debug().
+				Str("endpoint", path).
+				Int("attempt", attempt).
+				Err(err).
+				Msg("retrying beacon request")
+		}
+
+		timer := time.NewTimer(c.retryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+	}
+}
+
+// logRequest logs every failed beacon request at ERROR and the sampled ones
+// among the rest at DEBUG. Not-found answers, e.g. for missed slots, and
+// requests abandoned by the caller count as the rest. The failure line
+// carries the attempt count and total duration, so it stands on its own
+// when the request's retry and slow-request logs were sampled out.
+func (c *client) logRequest(path string, attempts int, duration time.Duration, sampled bool, err error) {
+	if err != nil && !errors.IsNotFound(err) && !stderrors.Is(err, context.Canceled) {
+		c.logger.Error().
+			Str("endpoint", path).
+			Int("attempts", attempts).
+			Dur("duration", duration).
+			Err(err).
+			Msg("beacon request failed")
+		return
+	}
+
+	if !sampled {
+		return
+	}
+
+	event := c.logger.Debug().
+		Str("endpoint", path).
+		Int("attempts", attempts).
+		Dur("duration", duration).
+		Uint64("sample_rate", c.logSampleRate)
+	if err != nil {
+		event = event.Err(err)
+	}
+	event.Msg("beacon request")
+}
+
+func (c *client) doBeaconAttemptWithTimeout(ctx context.Context, timeout time.Duration, path string, result interface{}) error {
+	if timeout <= 0 {
+		return c.doBeaconAttempt(ctx, path, result)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.doBeaconAttempt(ctx, path, result)
+}
+
+func (c *client) doBeaconAttempt(ctx context.Context, path string, result interface{}) error {
+	var err error
+	if c.beaconMode == BeaconModeJSONRPC {
+		err = c.doBeaconRPCAttempt(ctx, path, result)
+	} else {
+		err = c.doBeaconRESTAttempt(ctx, path, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if v, ok := result.(requiredFields); ok && c.strictResponses {
+		if field := v.missingField(); field != "" {
+			return fmt.Errorf("%w: %s missing from %s", errors.ErrUnexpectedBeaconResponse, field, path)
+		}
+	}
+
+	return nil
+}
+
+func (c *client) doBeaconRESTAttempt(ctx context.Context, path string, result interface{}) error {
+	url := c.rpcEndpoint + path
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -217,31 +671,92 @@ func (c *client) doBeaconRequest(ctx context.Context, endpoint string, result in
 
 	req.Header.Set("Accept", "application/json")
 
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+
+	start := time.Now()
+	defer func() {
+		c.logIfSlow(path, time.Since(start))
+	}()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
+	defer closeBody(resp.Body)
 
 	if resp.StatusCode == http.StatusNotFound {
 		return errors.ErrSlotNotFound
 	}
 
+	if resp.StatusCode == http.StatusNotImplemented {
+		return errors.ErrNotSupported
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		body, _ := io.ReadAll(c.limitBody(resp.Body))
+		return errors.BeaconHTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+	if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return nil
 }
 
+// isRetryable reports whether a failed beacon request may succeed if sent
+// again: transport errors and 429/5xx responses other than 501.
+func isRetryable(err error) bool {
+	var httpErr errors.BeaconHTTPError
+	if stderrors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+
+	var urlErr *url.Error
+	return stderrors.As(err, &urlErr)
+}
+
+// endpointLabel replaces slot, index and root path segments so the metric
+// label stays low-cardinality.
+func endpointLabel(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil || strings.HasPrefix(segment, "0x") {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (c *client) logIfSlow(endpoint string, duration time.Duration) {
+	threshold := c.slowThreshold
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+
+	c.logger.Warn().
+		Str("endpoint", endpoint).
+		Dur("duration", duration).
+		Dur("threshold", threshold).
+		Msg("slow beacon request")
+}
+
+// GetBlockBySlot uses the v2 blocks endpoint: only it carries the fork
+// version and the finalized and execution_optimistic flags, and v1 has been
+// removed from current beacon nodes.
 func (c *client) GetBlockBySlot(ctx context.Context, slot uint64) (*BeaconBlock, error) {
 	var block BeaconBlock
-	endpoint := fmt.Sprintf("blocks/%d", slot)
+	endpoint := fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot)
 
 	if err := c.doBeaconRequest(ctx, endpoint, &block); err != nil {
 		return nil, err
@@ -251,11 +766,31 @@ func (c *client) GetBlockBySlot(ctx context.Context, slot uint64) (*BeaconBlock,
 }
 
 func (c *client) GetSyncCommittee(ctx context.Context, slot uint64) ([]string, error) {
-	epoch := slot / 32
-	syncCommitteePeriod := epoch / 256
+	period := c.spec.SlotToSyncCommitteePeriod(slot)
+
+	stateID := fmt.Sprintf("%d", c.spec.SyncCommitteePeriodStartSlot(period))
+	return c.GetSyncCommitteeAtState(ctx, stateID)
+}
+
+// GetSyncCommitteeAtState returns the current sync committee of stateID, which
+// is passed through to the beacon node unchanged.
+func (c *client) GetSyncCommitteeAtState(ctx context.Context, stateID string) ([]string, error) {
+	endpoint := fmt.Sprintf("/eth/v1/beacon/states/%s/sync_committees", url.PathEscape(stateID))
+
+	var resp SyncCommitteeResponse
+	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
 
-	stateID := fmt.Sprintf("%d", syncCommitteePeriod*256*32)
-	endpoint := fmt.Sprintf("states/%s/sync_committees", stateID)
+	return resp.Data.Validators, nil
+}
+
+func (c *client) GetNextSyncCommittee(ctx context.Context, slot uint64) ([]string, error) {
+	period := c.spec.SlotToSyncCommitteePeriod(slot)
+
+	stateID := fmt.Sprintf("%d", c.spec.SyncCommitteePeriodStartSlot(period))
+	nextEpoch := c.spec.SyncCommitteePeriodStartEpoch(period + 1)
+	endpoint := fmt.Sprintf("/eth/v1/beacon/states/%s/sync_committees?epoch=%d", stateID, nextEpoch)
 
 	var resp SyncCommitteeResponse
 	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
@@ -266,40 +801,138 @@ func (c *client) GetSyncCommittee(ctx context.Context, slot uint64) ([]string, e
 }
 
 func (c *client) GetCurrentSlot(ctx context.Context) (uint64, error) {
-	var genesis GenesisResponse
-	if err := c.doBeaconRequest(ctx, "genesis", &genesis); err != nil {
+	genesisTime, err := c.getGenesisTime(ctx)
+	if err != nil {
 		return 0, err
 	}
 
-	genesisTime, err := parseUint64(genesis.Data.GenesisTime)
+	return c.spec.SlotAt(genesisTime, c.now())
+}
+
+// Initialized reports whether genesis is known, i.e. whether the current
+// slot can be computed without a beacon request that might fail.
+func (c *client) Initialized() bool {
+	return c.initialized.Load()
+}
+
+func (c *client) setGenesisTime(genesisTime uint64) {
+	c.genesisTime.Store(genesisTime)
+	c.initialized.Store(true)
+}
+
+// getGenesisTime fetches genesis once and caches it. Concurrent cold-start
+// callers share a single in-flight request; a failed fetch is retried by the
+// next caller.
+func (c *client) getGenesisTime(ctx context.Context) (uint64, error) {
+	if c.initialized.Load() {
+		return c.genesisTime.Load(), nil
+	}
+
+	result, err := coalesce(&c.genesisGroup, "genesis", func() (interface{}, error) {
+		// Detached from the caller so one cancelled request doesn't fail
+		// everyone waiting on the shared fetch.
+		var genesis GenesisResponse
+		if err := c.doBeaconRequest(context.WithoutCancel(ctx), "/eth/v1/beacon/genesis", &genesis); err != nil {
+			return nil, err
+		}
+
+		genesisTime, err := strconv.ParseUint(genesis.Data.GenesisTime, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse genesis time: %w", err)
+		}
+
+		c.setGenesisTime(genesisTime)
+
+		return genesisTime, nil
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse genesis time: %w", err)
+		return 0, err
 	}
 
-	currentTime := uint64(time.Now().Unix())
-	if currentTime < genesisTime {
-		return 0, fmt.Errorf("current time is before genesis")
+	return result.(uint64), nil
+}
+
+// coalesce runs fn through group and counts the call as the leader, which
+// ran fn, or as shared, which got the leader's result without going upstream.
+// singleflight reports shared to the leader too once others joined it, so the
+// leader is told apart by having run fn.
+func coalesce(group *singleflight.Group, key string, fn func() (interface{}, error)) (interface{}, error) {
+	leader := false
+	result, err, shared := group.Do(key, func() (interface{}, error) {
+		leader = true
+		return fn()
+	})
+
+	role := "leader"
+	if shared && !leader {
+		role = "shared"
 	}
+	coalescedRequests.WithLabelValues(key, role).Inc()
 
-	return (currentTime - genesisTime) / 12, nil
+	return result, err
 }
 
 func (c *client) GetBlockRewards(ctx context.Context, slot uint64) (*BlockRewards, error) {
-	endpoint := fmt.Sprintf("rewards/blocks/%d", slot)
+	endpoint := fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%d", slot)
+
+	var resp BlockRewardsResponse
+	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	resp.Data.ExecutionOptimistic = resp.ExecutionOptimistic
+	return &resp.Data, nil
+}
 
-	type rewardsResponse struct {
-		Data BlockRewards `json:"data"`
+// GetBlockHeader fetches the header of blockID, a slot, block root or named
+// block such as "finalized". It's much cheaper than the full block.
+func (c *client) GetBlockHeader(ctx context.Context, blockID string) (*HeaderResponse, error) {
+	endpoint := fmt.Sprintf("/eth/v1/beacon/headers/%s", url.PathEscape(blockID))
+
+	var resp HeaderResponse
+	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
+		return nil, err
 	}
 
-	var resp rewardsResponse
+	return &resp, nil
+}
+
+// GetBlockHeadersAtSlot returns every header the beacon node knows at slot,
+// including ones from forks that lost to the canonical chain.
+func (c *client) GetBlockHeadersAtSlot(ctx context.Context, slot uint64) ([]HeaderData, error) {
+	endpoint := fmt.Sprintf("/eth/v1/beacon/headers?slot=%d", slot)
+
+	var resp HeadersResponse
 	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
 		return nil, err
 	}
 
+	return resp.Data, nil
+}
+
+// GetPeerCount returns the beacon node's libp2p peer counts by state.
+func (c *client) GetPeerCount(ctx context.Context) (*PeerCount, error) {
+	var resp PeerCountResponse
+	if err := c.doBeaconRequest(ctx, "/eth/v1/node/peer_count", &resp); err != nil {
+		return nil, err
+	}
+
 	return &resp.Data, nil
 }
+
+// GetNodeVersion returns the beacon node's version string, e.g.
+// "Lighthouse/v5.1.0-1b5c7a3/x86_64-linux".
+func (c *client) GetNodeVersion(ctx context.Context) (string, error) {
+	var resp NodeVersionResponse
+	if err := c.doBeaconRequest(ctx, "/eth/v1/node/version", &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Data.Version, nil
+}
+
 func (c *client) GetProposerDuties(ctx context.Context, epoch uint64) ([]ProposerDuty, error) {
-	endpoint := fmt.Sprintf("duties/proposer/%d", epoch)
+	endpoint := fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch)
 
 	var resp ProposerDutiesResponse
 	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
@@ -309,8 +942,41 @@ func (c *client) GetProposerDuties(ctx context.Context, epoch uint64) ([]Propose
 	return resp.Data, nil
 }
 
-func parseUint64(s string) (uint64, error) {
-	var n uint64
-	_, err := fmt.Sscanf(s, "%d", &n)
-	return n, err
+// GetValidatorPubkey reads the pubkey from the head state. A validator's
+// pubkey never changes once it's assigned an index.
+func (c *client) GetValidatorPubkey(ctx context.Context, index uint64) (string, error) {
+	endpoint := fmt.Sprintf("/eth/v1/beacon/states/head/validators/%d", index)
+
+	var resp ValidatorResponse
+	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Data.Validator.Pubkey, nil
+}
+
+// GetValidatorPubkeys looks up several validators in one head state query.
+// Indices the beacon node doesn't know are left out of the result.
+func (c *client) GetValidatorPubkeys(ctx context.Context, indices []uint64) (map[uint64]string, error) {
+	ids := make([]string, len(indices))
+	for i, index := range indices {
+		ids[i] = strconv.FormatUint(index, 10)
+	}
+	endpoint := "/eth/v1/beacon/states/head/validators?id=" + strings.Join(ids, ",")
+
+	var resp ValidatorsResponse
+	if err := c.doBeaconRequest(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	pubkeys := make(map[uint64]string, len(resp.Data))
+	for _, validator := range resp.Data {
+		index, err := strconv.ParseUint(validator.Index, 10, 64)
+		if err != nil {
+			return nil, errors.UpstreamDataError{Field: "index", Value: validator.Index, Reason: "not a decimal integer"}
+		}
+		pubkeys[index] = validator.Validator.Pubkey
+	}
+
+	return pubkeys, nil
 }
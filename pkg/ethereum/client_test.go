@@ -0,0 +1,826 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/pkg/beaconmath"
+	"github.com/matheus/eth-validator-api/pkg/errors"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func newTestConfig(endpoint string) *config.Config {
+	return &config.Config{
+		Ethereum: config.EthereumConfig{RPCEndpoint: endpoint},
+		Transport: config.TransportConfig{
+			MaxIdleConns:    100,
+			IdleConnTimeout: 90 * time.Second,
+		},
+		Request: config.RequestConfig{
+			Timeout:        5 * time.Second,
+			MaxConcurrency: 1,
+		},
+	}
+}
+
+func TestClient_SemaphoreSaturation(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientFromConfig(newTestConfig(server.URL), logger.New("error"))
+	require.NoError(t, err)
+
+	waitsBefore := testutil.ToFloat64(semaphoreWaits)
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := c.GetBlockBySlot(context.Background(), 1)
+			done <- err
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(semaphoreWaits) == waitsBefore+1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, float64(1), testutil.ToFloat64(semaphoreInFlight))
+
+	close(unblock)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-done)
+	}
+	assert.Equal(t, float64(0), testutil.ToFloat64(semaphoreInFlight))
+}
+
+func TestClient_SemaphoreRespectsContext(t *testing.T) {
+	c := &client{sem: newSemaphore(1)}
+	require.NoError(t, c.acquire(context.Background()))
+	defer c.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, c.acquire(ctx), context.DeadlineExceeded)
+}
+
+func TestClient_SubscribeEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eth/v1/events", r.URL.Path)
+		assert.Equal(t, "chain_reorg", r.URL.Query().Get("topics"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(": keep-alive\n\n"))
+		w.Write([]byte("event: chain_reorg\ndata: {\"slot\":\"100\",\"depth\":\"2\"}\n\n"))
+	}))
+	defer server.Close()
+
+	c, err := NewClientFromConfig(newTestConfig(server.URL), logger.New("error"))
+	require.NoError(t, err)
+
+	var events []Event
+	err = c.SubscribeEvents(context.Background(), []string{TopicChainReorg}, func(e Event) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, TopicChainReorg, events[0].Topic)
+	assert.JSONEq(t, `{"slot":"100","depth":"2"}`, string(events[0].Data))
+}
+
+func TestClient_SlowRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v2/beacon/blocks/1" {
+			time.Sleep(60 * time.Millisecond)
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	cfg := newTestConfig(server.URL)
+	cfg.Request.SlowRequestThreshold = 40 * time.Millisecond
+
+	c, err := NewClientFromConfig(cfg, logger.NewWithWriter("warn", &logs))
+	require.NoError(t, err)
+
+	_, err = c.GetBlockBySlot(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, logs.String())
+
+	_, err = c.GetBlockBySlot(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "slow beacon request")
+	assert.Contains(t, logs.String(), "/eth/v2/beacon/blocks/1")
+}
+
+func TestClient_LogSampling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v2/beacon/blocks/0" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	// Every attempt counts as slow and failures are retried once, so each
+	// sampled request logs its summary or retry plus one warning per attempt.
+	for _, tt := range []struct {
+		rate, wantDebug, wantRetries, wantSlow int
+	}{
+		{rate: 1, wantDebug: 100, wantRetries: 10, wantSlow: 120},
+		{rate: 10, wantDebug: 10, wantRetries: 1, wantSlow: 120},
+		{rate: 0, wantDebug: 0, wantRetries: 0, wantSlow: 120},
+	} {
+		t.Run(fmt.Sprintf("rate=%d", tt.rate), func(t *testing.T) {
+			var logs bytes.Buffer
+			c, err := NewClient(server.URL,
+				WithLogger(logger.NewWithWriter("debug", &logs)),
+				WithLogSampleRate(tt.rate),
+				WithSlowRequestThreshold(time.Nanosecond),
+				WithRetries(1, time.Millisecond),
+			)
+			require.NoError(t, err)
+
+			for i := 1; i <= 100; i++ {
+				_, err := c.GetBlockBySlot(context.Background(), uint64(i))
+				require.NoError(t, err)
+			}
+			for i := 0; i < 10; i++ {
+				_, err := c.GetBlockBySlot(context.Background(), 0)
+				require.Error(t, err)
+			}
+
+			assert.Equal(t, tt.wantDebug, strings.Count(logs.String(), `"message":"beacon request"`))
+			assert.Equal(t, tt.wantRetries, strings.Count(logs.String(), `"message":"retrying beacon request"`))
+			assert.Equal(t, tt.wantSlow, strings.Count(logs.String(), `"message":"slow beacon request"`))
+			assert.Equal(t, 10, strings.Count(logs.String(), `"message":"beacon request failed"`))
+			assert.Equal(t, 10, strings.Count(logs.String(), `"level":"error"`))
+		})
+	}
+}
+
+func TestClient_ConnectionReuse(t *testing.T) {
+	var newConns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v2/beacon/blocks/404" {
+			http.Error(w, `{"code":404,"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data":{"message":{"slot":"1"}}}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	c, err := NewClientFromConfig(newTestConfig(server.URL), logger.New("error"))
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		slot := uint64(1)
+		if i%5 == 0 {
+			slot = 404
+		}
+		c.GetBlockBySlot(context.Background(), slot)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&newConns))
+}
+
+func BenchmarkClient_SequentialRequests(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"message":{"slot":"1"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientFromConfig(newTestConfig(server.URL), logger.New("error"))
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.GetBlockBySlot(context.Background(), 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type countingTransport struct {
+	calls int32
+	next  http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return t.next.RoundTrip(req)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"genesis_time":"0"}}`))
+	}))
+	defer server.Close()
+
+	t.Run("custom http client", func(t *testing.T) {
+		transport := &countingTransport{next: http.DefaultTransport}
+
+		c, err := NewClient(server.URL+"/",
+			WithHTTPClient(&http.Client{Transport: transport}),
+			WithTimeout(time.Second),
+			WithMaxConcurrency(2),
+		)
+		require.NoError(t, err)
+
+		_, err = c.GetCurrentSlot(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&transport.calls))
+		assert.Equal(t, time.Second, c.(*client).httpClient.Timeout)
+		assert.Equal(t, 2, cap(c.(*client).sem))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		c, err := NewClient(server.URL)
+		require.NoError(t, err)
+
+		assert.Equal(t, defaultTimeout, c.(*client).httpClient.Timeout)
+		assert.Nil(t, c.(*client).sem)
+	})
+
+	t.Run("chain spec", func(t *testing.T) {
+		transport := &countingTransport{next: http.DefaultTransport}
+		genesis := time.Unix(1_700_000_000, 0)
+
+		c, err := NewClient(server.URL,
+			WithHTTPClient(&http.Client{Transport: transport}),
+			WithChainSpec(6, 16, uint64(genesis.Unix())),
+			WithClock(func() time.Time { return genesis.Add(time.Minute) }),
+		)
+		require.NoError(t, err)
+
+		slot, err := c.GetCurrentSlot(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(10), slot)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&transport.calls))
+		assert.Equal(t, uint64(16), c.(*client).spec.SlotsPerEpoch)
+	})
+
+	t.Run("empty endpoint", func(t *testing.T) {
+		_, err := NewClient("")
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_GetBlockBySlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v2/beacon/blocks/9000000" {
+			http.Error(w, `{"code":404,"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"version":"deneb","execution_optimistic":true,"finalized":true,"data":{"message":{"slot":"9000000","proposer_index":"42"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	block, err := c.GetBlockBySlot(context.Background(), 9000000)
+	require.NoError(t, err)
+	assert.Equal(t, "deneb", block.Version)
+	assert.True(t, block.Finalized)
+	assert.True(t, block.ExecutionOptimistic)
+	assert.Equal(t, "42", block.Data.Message.ProposerIndex)
+}
+
+func TestClient_GetBlockRewards_ExecutionOptimistic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"execution_optimistic":true,"finalized":false,"data":{"total":"1000"}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	rewards, err := c.GetBlockRewards(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1000", rewards.Total)
+	assert.True(t, rewards.ExecutionOptimistic)
+}
+
+func TestClient_GetValidatorPubkey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eth/v1/beacon/states/head/validators/4242", r.URL.Path)
+		w.Write([]byte(`{"data":{"index":"4242","validator":{"pubkey":"0xabcd"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	pubkey, err := c.GetValidatorPubkey(context.Background(), 4242)
+	require.NoError(t, err)
+	assert.Equal(t, "0xabcd", pubkey)
+}
+
+func TestClient_GetValidatorPubkeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eth/v1/beacon/states/head/validators", r.URL.Path)
+		assert.Equal(t, "1,2,99", r.URL.Query().Get("id"))
+		w.Write([]byte(`{"data":[
+			{"index":"1","validator":{"pubkey":"0x01"}},
+			{"index":"2","validator":{"pubkey":"0x02"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	pubkeys, err := c.GetValidatorPubkeys(context.Background(), []uint64{1, 2, 99})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]string{1: "0x01", 2: "0x02"}, pubkeys)
+}
+
+func TestClient_NodeInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/node/peer_count":
+			w.Write([]byte(`{"data":{"connected":"56","connecting":"1","disconnected":"12","disconnecting":"0"}}`))
+		case "/eth/v1/node/version":
+			w.Write([]byte(`{"data":{"version":"Lighthouse/v5.1.0-1b5c7a3/x86_64-linux"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	peers, err := c.GetPeerCount(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &PeerCount{Connected: "56", Connecting: "1", Disconnected: "12", Disconnecting: "0"}, peers)
+
+	version, err := c.GetNodeVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Lighthouse/v5.1.0-1b5c7a3/x86_64-linux", version)
+}
+
+func TestClient_GetSyncCommitteeAtState(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"data":{"validators":["1","2"]}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	for _, stateID := range []string{"head", "8994816"} {
+		validators, err := c.GetSyncCommitteeAtState(context.Background(), stateID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, validators)
+	}
+
+	assert.Equal(t, []string{
+		"/eth/v1/beacon/states/head/sync_committees",
+		"/eth/v1/beacon/states/8994816/sync_committees",
+	}, paths)
+}
+
+func TestClient_GetBlockHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/headers/finalized" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"execution_optimistic":false,"finalized":true,"data":{"header":{"message":{"slot":"9000000"}}}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	header, err := c.GetBlockHeader(context.Background(), "finalized")
+	require.NoError(t, err)
+	assert.True(t, header.Finalized)
+	assert.Equal(t, "9000000", header.Data.Header.Message.Slot)
+
+	_, err = c.GetBlockHeader(context.Background(), "9000001")
+	assert.ErrorIs(t, err, errors.ErrSlotNotFound)
+}
+
+func TestClient_GetBlockHeadersAtSlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/headers" || r.URL.Query().Get("slot") != "100" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"data":[
+			{"root":"0xaa","canonical":true,"header":{"message":{"slot":"100","proposer_index":"7","state_root":"0x01"}}},
+			{"root":"0xbb","canonical":false,"header":{"message":{"slot":"100","proposer_index":"8","state_root":"0x02"}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	headers, err := c.GetBlockHeadersAtSlot(context.Background(), 100)
+	require.NoError(t, err)
+	require.Len(t, headers, 2)
+	assert.True(t, headers[0].Canonical)
+	assert.Equal(t, "0x02", headers[1].Header.Message.StateRoot)
+	assert.Equal(t, "0xbb", headers[1].Root)
+}
+
+func TestClient_StrictResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// "total" renamed upstream.
+		w.Write([]byte(`{"execution_optimistic":false,"data":{"proposer_index":"1","total_reward":"1000"}}`))
+	}))
+	defer server.Close()
+
+	t.Run("lenient by default", func(t *testing.T) {
+		c, err := NewClient(server.URL)
+		require.NoError(t, err)
+
+		rewards, err := c.GetBlockRewards(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Empty(t, rewards.Total)
+	})
+
+	t.Run("strict rejects missing total", func(t *testing.T) {
+		c, err := NewClient(server.URL, WithStrictResponses(true))
+		require.NoError(t, err)
+
+		_, err = c.GetBlockRewards(context.Background(), 1)
+		assert.ErrorIs(t, err, errors.ErrUnexpectedBeaconResponse)
+		assert.ErrorIs(t, err, errors.ErrMalformedUpstream)
+		assert.ErrorContains(t, err, "data.total missing from /eth/v1/beacon/rewards/blocks/1")
+	})
+
+	t.Run("strict accepts complete response", func(t *testing.T) {
+		complete := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":{"total":"1000"}}`))
+		}))
+		defer complete.Close()
+
+		c, err := NewClient(complete.URL, WithStrictResponses(true))
+		require.NoError(t, err)
+
+		rewards, err := c.GetBlockRewards(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "1000", rewards.Total)
+	})
+}
+
+func TestClient_BeaconHTTPError(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				w.Write([]byte(`{"message":"upstream"}`))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(server.URL)
+			require.NoError(t, err)
+
+			_, err = c.GetBlockRewards(context.Background(), 1)
+
+			var httpErr errors.BeaconHTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, status, httpErr.StatusCode)
+			assert.Equal(t, `{"message":"upstream"}`, httpErr.Body)
+		})
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"data":{"total":"1000"}}`))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, WithRetries(3, time.Millisecond))
+		require.NoError(t, err)
+
+		endpoint := "/eth/v1/beacon/rewards/blocks/{id}"
+		before := make([]float64, 4)
+		for i := range before {
+			before[i] = testutil.ToFloat64(beaconRequestAttempts.WithLabelValues(endpoint, strconv.Itoa(i+1)))
+		}
+		exhaustedBefore := testutil.ToFloat64(beaconRetriesExhausted.WithLabelValues(endpoint))
+
+		rewards, err := c.GetBlockRewards(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "1000", rewards.Total)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		for i, want := range []float64{1, 1, 1, 0} {
+			got := testutil.ToFloat64(beaconRequestAttempts.WithLabelValues(endpoint, strconv.Itoa(i+1)))
+			assert.Equal(t, want, got-before[i], "attempt %d", i+1)
+		}
+		assert.Equal(t, exhaustedBefore, testutil.ToFloat64(beaconRetriesExhausted.WithLabelValues(endpoint)))
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, WithRetries(2, time.Millisecond))
+		require.NoError(t, err)
+
+		endpoint := "/eth/v2/beacon/blocks/{id}"
+		exhaustedBefore := testutil.ToFloat64(beaconRetriesExhausted.WithLabelValues(endpoint))
+
+		_, err = c.GetBlockBySlot(context.Background(), 1)
+
+		var httpErr errors.BeaconHTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, exhaustedBefore+1, testutil.ToFloat64(beaconRetriesExhausted.WithLabelValues(endpoint)))
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, WithRetries(3, time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = c.GetBlockBySlot(context.Background(), 1)
+		assert.ErrorIs(t, err, errors.ErrSlotNotFound)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("stops when the context ends", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, WithRetries(3, time.Hour))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = c.GetBlockBySlot(ctx, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestEndpointLabel(t *testing.T) {
+	assert.Equal(t, "/eth/v2/beacon/blocks/{id}", endpointLabel("/eth/v2/beacon/blocks/123"))
+	assert.Equal(t, "/eth/v1/beacon/headers/{id}", endpointLabel("/eth/v1/beacon/headers/0xabc"))
+	assert.Equal(t, "/eth/v1/beacon/headers/finalized", endpointLabel("/eth/v1/beacon/headers/finalized"))
+	assert.Equal(t, "/eth/v1/beacon/states/{id}/validators/{id}", endpointLabel("/eth/v1/beacon/states/5/validators/7"))
+	assert.Equal(t, "/eth/v1/beacon/states/{id}/sync_committees", endpointLabel("/eth/v1/beacon/states/64/sync_committees?epoch=3"))
+}
+
+func TestClient_MaxResponseBytes(t *testing.T) {
+	oversized := `{"data":{"genesis_time":"0","padding":"` + strings.Repeat("a", 4096) + `"}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(oversized))
+	}))
+	defer server.Close()
+
+	t.Run("beacon request over limit", func(t *testing.T) {
+		c, err := NewClient(server.URL, WithMaxResponseBytes(1024))
+		require.NoError(t, err)
+
+		_, err = c.GetCurrentSlot(context.Background())
+		assert.ErrorIs(t, err, errors.ErrResponseTooLarge)
+	})
+
+	t.Run("json-rpc request over limit", func(t *testing.T) {
+		c, err := NewClient(server.URL, WithMaxResponseBytes(1024))
+		require.NoError(t, err)
+
+		err = c.(*client).doRequest(context.Background(), "eth_blockNumber", nil, nil)
+		assert.ErrorIs(t, err, errors.ErrResponseTooLarge)
+	})
+
+	t.Run("body exactly at limit", func(t *testing.T) {
+		c, err := NewClient(server.URL, WithMaxResponseBytes(int64(len(oversized))))
+		require.NoError(t, err)
+
+		_, err = c.GetCurrentSlot(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+// endlessBody is a response body that never ends, counting what is read.
+type endlessBody struct {
+	read   int64
+	closed bool
+}
+
+func (b *endlessBody) Read(p []byte) (int, error) {
+	b.read += int64(len(p))
+	return len(p), nil
+}
+
+func (b *endlessBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestCloseBody_BoundsDrain(t *testing.T) {
+	body := &endlessBody{}
+	closeBody(body)
+
+	assert.Equal(t, int64(maxDrainBytes), body.read)
+	assert.True(t, body.closed)
+}
+
+func TestClient_GetCurrentSlot_Boundaries(t *testing.T) {
+	genesis := time.Unix(1_606_824_023, 0)
+	epochStart := genesis.Add(320 * 12 * time.Second) // slot 320, epoch 10
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected uint64
+	}{
+		{name: "genesis", now: genesis, expected: 0},
+		{name: "last second of slot 0", now: genesis.Add(11 * time.Second), expected: 0},
+		{name: "start of slot 1", now: genesis.Add(12 * time.Second), expected: 1},
+		{name: "second before epoch 10", now: epochStart.Add(-time.Second), expected: 319},
+		{name: "start of epoch 10", now: epochStart, expected: 320},
+		{name: "sub-second into epoch 10", now: epochStart.Add(999 * time.Millisecond), expected: 320},
+		{name: "last second of epoch 10's first slot", now: epochStart.Add(11 * time.Second), expected: 320},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient("http://beacon.invalid",
+				WithChainSpec(12, 32, uint64(genesis.Unix())),
+				WithClock(func() time.Time { return tt.now }),
+			)
+			require.NoError(t, err)
+
+			slot, err := c.GetCurrentSlot(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, slot)
+		})
+	}
+
+	t.Run("before genesis", func(t *testing.T) {
+		c, err := NewClient("http://beacon.invalid",
+			WithChainSpec(12, 32, uint64(genesis.Unix())),
+			WithClock(func() time.Time { return genesis.Add(-time.Second) }),
+		)
+		require.NoError(t, err)
+
+		_, err = c.GetCurrentSlot(context.Background())
+		assert.ErrorIs(t, err, beaconmath.ErrBeforeGenesis)
+	})
+}
+
+func TestClient_GenesisColdStartBurst(t *testing.T) {
+	var genesisCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&genesisCalls, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"data":{"genesis_time":"0"}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	start := make(chan struct{})
+	errs := make(chan error, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := c.GetCurrentSlot(context.Background())
+			errs <- err
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&genesisCalls))
+
+	_, err = c.GetCurrentSlot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&genesisCalls))
+}
+
+func TestClient_GenesisCoalescingMetrics(t *testing.T) {
+	const callers = 10
+
+	release := make(chan struct{})
+	var genesisCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&genesisCalls, 1)
+		<-release
+		w.Write([]byte(`{"data":{"genesis_time":"0"}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	leaders := coalescedRequests.WithLabelValues("genesis", "leader")
+	shared := coalescedRequests.WithLabelValues("genesis", "shared")
+	leadersBefore, sharedBefore := testutil.ToFloat64(leaders), testutil.ToFloat64(shared)
+
+	var started, done sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		started.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			started.Done()
+			_, err := c.GetCurrentSlot(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+
+	// Hold the leader's request until every caller has joined it.
+	started.Wait()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&genesisCalls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	assert.Equal(t, leadersBefore+1, testutil.ToFloat64(leaders))
+	assert.Equal(t, sharedBefore+callers-1, testutil.ToFloat64(shared))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&genesisCalls))
+}
+
+func TestClient_GenesisRetriedAfterFailure(t *testing.T) {
+	var genesisCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&genesisCalls, 1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data":{"genesis_time":"0"}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = c.GetCurrentSlot(context.Background())
+	assert.Error(t, err)
+
+	_, err = c.GetCurrentSlot(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&genesisCalls))
+}
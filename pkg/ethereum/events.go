@@ -0,0 +1,106 @@
+package ethereum
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+const (
+	TopicChainReorg          = "chain_reorg"
+	TopicHead                = "head"
+	TopicFinalizedCheckpoint = "finalized_checkpoint"
+)
+
+type Event struct {
+	Topic string
+	Data  json.RawMessage
+}
+
+type ChainReorgEvent struct {
+	Slot                string `json:"slot"`
+	Depth               string `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	Epoch               string `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type HeadEvent struct {
+	Slot                      string `json:"slot"`
+	Block                     string `json:"block"`
+	State                     string `json:"state"`
+	EpochTransition           bool   `json:"epoch_transition"`
+	PreviousDutyDependentRoot string `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool   `json:"execution_optimistic"`
+}
+
+// SubscribeEvents streams beacon node server-sent events for the given topics
+// and calls fn for each one. It blocks until the stream ends or ctx is done.
+func (c *client) SubscribeEvents(ctx context.Context, topics []string, fn func(Event)) error {
+	query := url.Values{}
+	query.Set("topics", strings.Join(topics, ","))
+	endpoint := fmt.Sprintf("%s/eth/v1/events?%s", c.rpcEndpoint, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return errors.BeaconHTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if err := readEvents(resp.Body, fn); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read event stream: %w", err)
+	}
+
+	return ctx.Err()
+}
+
+func readEvents(r io.Reader, fn func(Event)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var topic string
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if topic != "" && len(data) > 0 {
+				fn(Event{Topic: topic, Data: json.RawMessage(strings.Join(data, "\n"))})
+			}
+			topic, data = "", nil
+		case strings.HasPrefix(line, ":"):
+			// comment / keep-alive
+		case strings.HasPrefix(line, "event:"):
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,181 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// BeaconMode selects how beacon data is fetched.
+type BeaconMode string
+
+const (
+	// BeaconModeREST uses the standard beacon REST API.
+	BeaconModeREST BeaconMode = "rest"
+	// BeaconModeJSONRPC sends every beacon call as a JSON-RPC request to the
+	// beacon endpoint, for providers that only expose a JSON-RPC gateway.
+	BeaconModeJSONRPC BeaconMode = "jsonrpc"
+)
+
+// rpcMethodNotFound is the JSON-RPC code for an unknown method.
+const rpcMethodNotFound = -32601
+
+// beaconRPCMethods maps beacon REST paths to their JSON-RPC method. A "{}"
+// segment matches any value, which is passed as a positional parameter.
+var beaconRPCMethods = []struct {
+	pattern string
+	method  string
+}{
+	{"/eth/v1/beacon/genesis", "beacon_getGenesis"},
+	{"/eth/v2/beacon/blocks/{}", "beacon_getBlockV2"},
+	{"/eth/v1/beacon/headers", "beacon_getBlockHeaders"},
+	{"/eth/v1/beacon/headers/{}", "beacon_getBlockHeader"},
+	{"/eth/v1/beacon/rewards/blocks/{}", "beacon_getBlockRewards"},
+	{"/eth/v1/beacon/states/{}/sync_committees", "beacon_getStateSyncCommittees"},
+	{"/eth/v1/beacon/states/{}/validators", "beacon_getStateValidators"},
+	{"/eth/v1/beacon/states/{}/validators/{}", "beacon_getStateValidator"},
+	{"/eth/v1/validator/duties/proposer/{}", "validator_getProposerDuties"},
+	{"/eth/v1/node/peer_count", "node_getPeerCount"},
+	{"/eth/v1/node/version", "node_getVersion"},
+}
+
+// beaconRPCCall translates a beacon REST path into a JSON-RPC method and its
+// params: the path's variable segments in order, followed by an object of
+// the query parameters if there are any.
+func beaconRPCCall(path string) (string, []interface{}, error) {
+	rawQuery := ""
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path, rawQuery = path[:i], path[i+1:]
+	}
+
+	segments := strings.Split(path, "/")
+	for _, m := range beaconRPCMethods {
+		params, ok := matchRPCPattern(strings.Split(m.pattern, "/"), segments)
+		if !ok {
+			continue
+		}
+
+		if rawQuery != "" {
+			query, err := url.ParseQuery(rawQuery)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid query in %s: %w", path, err)
+			}
+			values := make(map[string]string, len(query))
+			for key := range query {
+				values[key] = query.Get(key)
+			}
+			params = append(params, values)
+		}
+		return m.method, params, nil
+	}
+
+	return "", nil, fmt.Errorf("%w: no JSON-RPC method for %s", errors.ErrNotSupported, path)
+}
+
+func matchRPCPattern(pattern, segments []string) ([]interface{}, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := []interface{}{}
+	for i, p := range pattern {
+		if p != "{}" {
+			if p != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+		if segments[i] == "" {
+			return nil, false
+		}
+		value, err := url.PathUnescape(segments[i])
+		if err != nil {
+			return nil, false
+		}
+		params = append(params, value)
+	}
+	return params, true
+}
+
+// doBeaconRPCAttempt is doBeaconAttempt for BeaconModeJSONRPC. The gateway's
+// result is the body the REST endpoint would have returned, so it decodes
+// into the same types. A null result means not found.
+func (c *client) doBeaconRPCAttempt(ctx context.Context, path string, result interface{}) error {
+	method, params, err := beaconRPCCall(path)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      atomic.AddUint64(&c.requestCounter, 1),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.rpcEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+
+	start := time.Now()
+	defer func() {
+		c.logIfSlow(path, time.Since(start))
+	}()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(c.limitBody(resp.Body))
+		return errors.BeaconHTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		if rpcResp.Error.Code == rpcMethodNotFound {
+			return fmt.Errorf("%w: %s", errors.ErrNotSupported, rpcResp.Error.Message)
+		}
+		return errors.RPCError{
+			Code:    rpcResp.Error.Code,
+			Message: rpcResp.Error.Message,
+			Data:    rpcResp.Error.Data,
+		}
+	}
+
+	if len(rpcResp.Result) == 0 || string(rpcResp.Result) == "null" {
+		return errors.ErrSlotNotFound
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+const (
+	rewardsBody    = `{"execution_optimistic":true,"data":{"proposer_index":"7","total":"1000"}}`
+	validatorsBody = `{"data":[{"index":"1","validator":{"pubkey":"0x01"}},{"index":"2","validator":{"pubkey":"0x02"}}]}`
+	committeeBody  = `{"data":{"validators":["3","4"]}}`
+	versionBody    = `{"data":{"version":"Lighthouse/v5.0.0"}}`
+)
+
+func TestClient_BeaconModes(t *testing.T) {
+	restServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/eth/v1/beacon/rewards/blocks/1":
+			w.Write([]byte(rewardsBody))
+		case "/eth/v1/beacon/states/head/validators?id=1,2":
+			w.Write([]byte(validatorsBody))
+		case "/eth/v1/beacon/states/8192/sync_committees?epoch=512":
+			w.Write([]byte(committeeBody))
+		case "/eth/v1/node/version":
+			w.Write([]byte(versionBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer restServer.Close()
+
+	var methods []string
+	rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/", r.URL.Path)
+
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     uint64            `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		methods = append(methods, req.Method)
+
+		params := ""
+		for _, p := range req.Params {
+			params += string(p)
+		}
+
+		result := "null"
+		switch req.Method + params {
+		case `beacon_getBlockRewards"1"`:
+			result = rewardsBody
+		case `beacon_getStateValidators"head"{"id":"1,2"}`:
+			result = validatorsBody
+		case `beacon_getStateSyncCommittees"8192"{"epoch":"512"}`:
+			result = committeeBody
+		case `node_getVersion`:
+			result = versionBody
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  json.RawMessage(result),
+		})
+	}))
+	defer rpcServer.Close()
+
+	for _, tt := range []struct {
+		mode     BeaconMode
+		endpoint string
+	}{
+		{BeaconModeREST, restServer.URL},
+		{BeaconModeJSONRPC, rpcServer.URL},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			c, err := NewClient(tt.endpoint, WithBeaconMode(tt.mode))
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			rewards, err := c.GetBlockRewards(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "7", rewards.ProposerIndex)
+			assert.Equal(t, "1000", rewards.Total)
+			assert.True(t, rewards.ExecutionOptimistic)
+
+			pubkeys, err := c.GetValidatorPubkeys(ctx, []uint64{1, 2})
+			require.NoError(t, err)
+			assert.Equal(t, map[uint64]string{1: "0x01", 2: "0x02"}, pubkeys)
+
+			committee, err := c.GetNextSyncCommittee(ctx, 8192)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"3", "4"}, committee)
+
+			version, err := c.GetNodeVersion(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "Lighthouse/v5.0.0", version)
+
+			_, err = c.GetBlockRewards(ctx, 2)
+			assert.ErrorIs(t, err, errors.ErrSlotNotFound)
+		})
+	}
+
+	assert.Equal(t, []string{
+		"beacon_getBlockRewards",
+		"beacon_getStateValidators",
+		"beacon_getStateSyncCommittees",
+		"node_getVersion",
+		"beacon_getBlockRewards",
+	}, methods)
+}
+
+func TestClient_JSONRPCErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		code := -32000
+		if req.Method == "node_getPeerCount" {
+			code = rpcMethodNotFound
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error":   map[string]interface{}{"code": code, "message": "boom"},
+		})
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL, WithBeaconMode(BeaconModeJSONRPC))
+	require.NoError(t, err)
+
+	_, err = c.GetPeerCount(context.Background())
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+
+	_, err = c.GetBlockRewards(context.Background(), 1)
+	var rpcErr errors.RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32000, rpcErr.Code)
+}
+
+func TestBeaconRPCCall(t *testing.T) {
+	tests := []struct {
+		path   string
+		method string
+		params string
+	}{
+		{"/eth/v2/beacon/blocks/5", "beacon_getBlockV2", `["5"]`},
+		{"/eth/v1/beacon/headers/0xabc", "beacon_getBlockHeader", `["0xabc"]`},
+		{"/eth/v1/beacon/headers?slot=5", "beacon_getBlockHeaders", `[{"slot":"5"}]`},
+		{"/eth/v1/beacon/states/head/validators/9", "beacon_getStateValidator", `["head","9"]`},
+		{"/eth/v1/validator/duties/proposer/3", "validator_getProposerDuties", `["3"]`},
+		{"/eth/v1/beacon/genesis", "beacon_getGenesis", `[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			method, params, err := beaconRPCCall(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.method, method)
+			encoded, err := json.Marshal(params)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.params, string(encoded))
+		})
+	}
+
+	_, _, err := beaconRPCCall("/eth/v1/beacon/pool/attestations")
+	assert.ErrorIs(t, err, errors.ErrNotSupported)
+}
+
+func TestNewClient_UnknownBeaconMode(t *testing.T) {
+	_, err := NewClient("http://localhost", WithBeaconMode("grpc"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package ethereum
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	semaphoreInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_semaphore_in_flight",
+		Help: "Number of beacon requests currently holding a concurrency permit.",
+	})
+
+	semaphoreWaits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_semaphore_waits_total",
+		Help: "Total number of beacon requests that had to wait for a concurrency permit.",
+	})
+
+	semaphoreWaitDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "beacon_semaphore_wait_duration_seconds",
+		Help: "Time spent waiting for a beacon concurrency permit.",
+	})
+
+	beaconRequestAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "beacon_request_attempts_total",
+		Help: "Total number of beacon request attempts by endpoint and attempt number.",
+	}, []string{"endpoint", "attempt"})
+
+	beaconRetriesExhausted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "beacon_request_retries_exhausted_total",
+		Help: "Total number of beacon requests that failed after exhausting their retries.",
+	}, []string{"endpoint"})
+
+	coalescedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "beacon_coalesced_requests_total",
+		Help: "Total number of calls through a singleflight group by key and role: leader calls went upstream, shared calls reused a leader's result.",
+	}, []string{"key", "role"})
+)
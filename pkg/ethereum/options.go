@@ -0,0 +1,155 @@
+package ethereum
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+const (
+	defaultTimeout          = 30 * time.Second
+	defaultMaxResponseBytes = 50 << 20
+)
+
+var defaultTransportConfig = config.TransportConfig{
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   10,
+	IdleConnTimeout:       90 * time.Second,
+	ResponseHeaderTimeout: 15 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+}
+
+type Option func(*client)
+
+// WithHTTPClient replaces the default pooled HTTP client. Its transport is
+// also used for event streams.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTimeout sets the per-request timeout, overriding the HTTP client's.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.timeout = timeout
+	}
+}
+
+// WithEndpointTimeout gives requests of class their own timeout instead of
+// the one from WithTimeout, applied through each attempt's context. Zero
+// keeps the global timeout. With WithTimeout set, the HTTP client's timeout
+// is raised to the longest class timeout.
+func WithEndpointTimeout(class EndpointClass, timeout time.Duration) Option {
+	return func(c *client) {
+		if timeout <= 0 {
+			return
+		}
+		if c.classTimeouts == nil {
+			c.classTimeouts = make(map[EndpointClass]time.Duration)
+		}
+		c.classTimeouts[class] = timeout
+	}
+}
+
+func WithLogger(logger logger.Logger) Option {
+	return func(c *client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithMaxConcurrency limits in-flight beacon requests. Zero means unlimited.
+func WithMaxConcurrency(n int) Option {
+	return func(c *client) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithSlowRequestThreshold logs beacon calls slower than threshold at WARN.
+// Zero disables it.
+func WithSlowRequestThreshold(threshold time.Duration) Option {
+	return func(c *client) {
+		c.slowThreshold = threshold
+	}
+}
+
+// WithLogSampleRate logs one in n beacon calls in full: the DEBUG summary of
+// a successful call and its retries. Failures are always logged at ERROR and
+// slow calls at WARN. Zero stops logging everything else; negative values
+// keep the default of logging every call.
+func WithLogSampleRate(n int) Option {
+	return func(c *client) {
+		if n >= 0 {
+			c.logSampleRate = uint64(n)
+		}
+	}
+}
+
+// WithMaxResponseBytes caps how much of a beacon response body is read.
+// Non-positive values keep the default.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *client) {
+		if n > 0 {
+			c.maxResponseBytes = n
+		}
+	}
+}
+
+// WithStrictResponses rejects beacon responses missing a required field with
+// ErrUnexpectedBeaconResponse instead of decoding them to zero values.
+func WithStrictResponses(strict bool) Option {
+	return func(c *client) {
+		c.strictResponses = strict
+	}
+}
+
+// WithRetries retries transient beacon failures up to maxRetries times,
+// waiting delay between attempts. Zero disables retries.
+func WithRetries(maxRetries int, delay time.Duration) Option {
+	return func(c *client) {
+		c.maxRetries = maxRetries
+		c.retryDelay = delay
+	}
+}
+
+// WithBeaconMode selects the REST API or a JSON-RPC gateway for beacon
+// calls. Event streams always use REST. An empty mode keeps REST.
+func WithBeaconMode(mode BeaconMode) Option {
+	return func(c *client) {
+		if mode != "" {
+			c.beaconMode = mode
+		}
+	}
+}
+
+// WithChainSpec sets the network's slot timing. Zero values keep the mainnet
+// defaults; a non-zero genesisTime is used instead of fetching genesis.
+func WithChainSpec(secondsPerSlot, slotsPerEpoch, genesisTime uint64) Option {
+	return func(c *client) {
+		if secondsPerSlot > 0 {
+			c.spec.SecondsPerSlot = secondsPerSlot
+		}
+		if slotsPerEpoch > 0 {
+			c.spec.SlotsPerEpoch = slotsPerEpoch
+		}
+		if genesisTime > 0 {
+			c.setGenesisTime(genesisTime)
+		}
+	}
+}
+
+// WithClock replaces time.Now for the current slot computation, so tests can
+// freeze time.
+func WithClock(now func() time.Time) Option {
+	return func(c *client) {
+		if now != nil {
+			c.now = now
+		}
+	}
+}
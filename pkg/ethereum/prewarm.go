@@ -0,0 +1,74 @@
+package ethereum
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+// Prewarm sends n concurrent node version requests so the transport holds
+// open connections before the first API request needs one. It returns how
+// many succeeded along with the failures, if any; either way the client
+// stays usable.
+func Prewarm(ctx context.Context, client Client, n int) (int, error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		errs      []error
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := client.GetNodeVersion(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			succeeded++
+		}()
+	}
+	wg.Wait()
+
+	return succeeded, stderrors.Join(errs...)
+}
+
+const defaultGenesisRetryInterval = time.Second
+
+// WarmGenesis fetches genesis, retrying every interval until it succeeds or
+// ctx is done, so the client is Initialized before traffic arrives. It
+// returns nil once genesis is known, even if the chain hasn't started yet,
+// and ctx.Err() otherwise.
+func WarmGenesis(ctx context.Context, client Client, interval time.Duration, log logger.Logger) error {
+	if interval <= 0 {
+		interval = defaultGenesisRetryInterval
+	}
+
+	for {
+		_, err := client.GetCurrentSlot(ctx)
+		if err == nil {
+			return nil
+		}
+		if client.Initialized() {
+			// Genesis is known but still ahead; retrying won't change that.
+			log.Info().Err(err).Msg("beacon chain has not reached genesis yet")
+			return nil
+		}
+		log.Warn().Err(err).Dur("retry_in", interval).Msg("failed to fetch beacon genesis")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
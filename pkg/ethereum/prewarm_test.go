@@ -0,0 +1,150 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func TestPrewarm(t *testing.T) {
+	var (
+		calls int32
+		mu    sync.Mutex
+		conns = make(map[net.Conn]struct{})
+	)
+	release := make(chan struct{})
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eth/v1/node/version", r.URL.Path)
+		// Hold every request until all have arrived, so each needs its own
+		// connection.
+		if atomic.AddInt32(&calls, 1) == 4 {
+			close(release)
+		}
+		<-release
+		w.Write([]byte(`{"data":{"version":"Lighthouse/v5.1.0"}}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns[conn] = struct{}{}
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	c, err := NewClient(server.URL, WithMaxConcurrency(4))
+	require.NoError(t, err)
+
+	succeeded, err := Prewarm(context.Background(), c, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, succeeded)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	mu.Lock()
+	assert.Len(t, conns, 4)
+	mu.Unlock()
+
+	// The warmed connections are reused rather than opening new ones.
+	_, err = c.GetNodeVersion(context.Background())
+	require.NoError(t, err)
+	mu.Lock()
+	assert.Len(t, conns, 4)
+	mu.Unlock()
+}
+
+func TestPrewarm_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	succeeded, err := Prewarm(context.Background(), c, 2)
+	assert.Error(t, err)
+	assert.Zero(t, succeeded)
+}
+
+func TestWarmGenesis(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eth/v1/beacon/genesis", r.URL.Path)
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data":{"genesis_time":"1606824023"}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+	assert.False(t, c.Initialized())
+
+	require.NoError(t, WarmGenesis(context.Background(), c, time.Millisecond, logger.Nop()))
+	assert.True(t, c.Initialized())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// Already known, so no further request.
+	require.NoError(t, WarmGenesis(context.Background(), c, time.Millisecond, logger.Nop()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestWarmGenesis_BeforeGenesis(t *testing.T) {
+	var calls int32
+	genesis := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"data":{"genesis_time":"%d"}}`, genesis)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	require.NoError(t, WarmGenesis(context.Background(), c, time.Millisecond, logger.NewWithWriter("info", &logs)))
+	assert.True(t, c.Initialized())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	assert.Equal(t, 1, strings.Count(logs.String(), "\n"))
+	assert.Contains(t, logs.String(), `"level":"info"`)
+	assert.Contains(t, logs.String(), "not reached genesis")
+}
+
+func TestWarmGenesis_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, WarmGenesis(ctx, c, time.Millisecond, logger.Nop()), context.DeadlineExceeded)
+	assert.False(t, c.Initialized())
+}
+
+func TestClient_InitializedFromConfig(t *testing.T) {
+	c, err := NewClient("http://localhost", WithChainSpec(0, 0, 1606824023))
+	require.NoError(t, err)
+	assert.True(t, c.Initialized())
+}
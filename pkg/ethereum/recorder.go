@@ -0,0 +1,214 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+// ErrNoRecording is returned in replay mode for a request that was never
+// recorded.
+var ErrNoRecording = stderrors.New("no recorded response")
+
+// recording is one request/response pair as stored on disk. The body is kept
+// as text so recordings can be read and edited by hand.
+type recording struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header"`
+	Body        string      `json:"body"`
+}
+
+type recordingTransport struct {
+	next             http.RoundTripper
+	dir              string
+	maxResponseBytes int64
+}
+
+// NewRecordingTransport wraps next so that every request/response pair is
+// also written to dir, one file per distinct request. A request made again
+// overwrites its earlier recording. Event streams are passed through
+// unrecorded since they never end. Responses over maxResponseBytes fail with
+// ErrResponseTooLarge instead of being recorded; non-positive values use the
+// client's default limit.
+func NewRecordingTransport(next http.RoundTripper, dir string, maxResponseBytes int64) (http.RoundTripper, error) {
+	if next == nil {
+		return nil, fmt.Errorf("transport is required")
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("record directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create record directory: %w", err)
+	}
+	if maxResponseBytes <= 0 {
+		maxResponseBytes = defaultMaxResponseBytes
+	}
+	return &recordingTransport{next: next, dir: dir, maxResponseBytes: maxResponseBytes}, nil
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isEventStream(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.maxResponseBytes+1))
+	closeBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	if int64(len(body)) > t.maxResponseBytes {
+		return nil, errors.ErrResponseTooLarge
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := recording{
+		Method:      req.Method,
+		URL:         req.URL.RequestURI(),
+		RequestBody: string(reqBody),
+		Status:      resp.StatusCode,
+		Header:      resp.Header,
+		Body:        string(body),
+	}
+	if err := t.write(recordingName(req.Method, req.URL.RequestURI(), reqBody), rec); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// write stores rec through a temporary file so a concurrent replay never
+// sees a partial recording.
+func (t *recordingTransport) write(name string, rec recording) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(t.dir, name+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(t.dir, name)); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+type replayTransport struct {
+	dir string
+}
+
+// NewReplayTransport serves responses recorded by NewRecordingTransport
+// from dir without any network access. Requests are matched on method,
+// path, query and body, so the beacon endpoint may differ from the one
+// used while recording.
+func NewReplayTransport(dir string) (http.RoundTripper, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("replay directory is required")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("replay path %s is not a directory", dir)
+	}
+	return &replayTransport{dir: dir}, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isEventStream(req) {
+		return nil, fmt.Errorf("event streams cannot be replayed: %w", ErrNoRecording)
+	}
+
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(t.dir, recordingName(req.Method, req.URL.RequestURI(), reqBody)))
+	if stderrors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoRecording, req.Method, req.URL.RequestURI())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode recording for %s %s: %w", req.Method, req.URL.RequestURI(), err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// recordingName derives the file a request is stored under. The host is
+// left out so recordings don't depend on where the beacon node ran.
+func recordingName(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)[:16]) + ".json"
+}
+
+// readRequestBody returns the request body and restores it so the request
+// can still be sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func isEventStream(req *http.Request) bool {
+	return req.Header.Get("Accept") == "text/event-stream"
+}
@@ -0,0 +1,96 @@
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/blocks/1":
+			w.Write([]byte(`{"execution_optimistic":true,"data":{"proposer_index":"7","total":"1000"}}`))
+		case "/eth/v1/beacon/states/head/validators":
+			w.Write([]byte(`{"data":[{"index":"1","validator":{"pubkey":"0x01"}},{"index":"2","validator":{"pubkey":"0x02"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	type results struct {
+		rewards   *BlockRewards
+		pubkeys   map[uint64]string
+		missedErr error
+	}
+	run := func(c Client) results {
+		ctx := context.Background()
+		rewards, err := c.GetBlockRewards(ctx, 1)
+		require.NoError(t, err)
+		pubkeys, err := c.GetValidatorPubkeys(ctx, []uint64{1, 2})
+		require.NoError(t, err)
+		_, missedErr := c.GetBlockRewards(ctx, 2)
+		return results{rewards: rewards, pubkeys: pubkeys, missedErr: missedErr}
+	}
+
+	recorder, err := NewRecordingTransport(http.DefaultTransport, dir, 0)
+	require.NoError(t, err)
+	c, err := NewClient(server.URL, WithHTTPClient(&http.Client{Transport: recorder}))
+	require.NoError(t, err)
+	recorded := run(c)
+	server.Close()
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	replayer, err := NewReplayTransport(dir)
+	require.NoError(t, err)
+	c, err = NewClient(server.URL, WithHTTPClient(&http.Client{Transport: replayer}))
+	require.NoError(t, err)
+	replayed := run(c)
+
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, "1000", replayed.rewards.Total)
+	assert.ErrorIs(t, replayed.missedErr, errors.ErrSlotNotFound)
+
+	t.Run("unrecorded request", func(t *testing.T) {
+		_, err := c.GetBlockRewards(context.Background(), 3)
+		assert.ErrorIs(t, err, ErrNoRecording)
+	})
+}
+
+func TestRecordingTransport_MaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"genesis_time":"0","padding":"` + strings.Repeat("a", 4096) + `"}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecordingTransport(http.DefaultTransport, dir, 1024)
+	require.NoError(t, err)
+	c, err := NewClient(server.URL, WithHTTPClient(&http.Client{Transport: recorder}))
+	require.NoError(t, err)
+
+	_, err = c.GetCurrentSlot(context.Background())
+	assert.ErrorIs(t, err, errors.ErrResponseTooLarge)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestNewReplayTransport_MissingDir(t *testing.T) {
+	_, err := NewReplayTransport(t.TempDir() + "/missing")
+	assert.Error(t, err)
+}
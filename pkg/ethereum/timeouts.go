@@ -0,0 +1,49 @@
+package ethereum
+
+import (
+	"strings"
+	"time"
+)
+
+// EndpointClass groups beacon endpoints with similar cost so they can be
+// given their own timeout.
+type EndpointClass string
+
+const (
+	// ClassBlock covers blocks, headers and block rewards.
+	ClassBlock EndpointClass = "block"
+	// ClassState covers anything read from a beacon state, such as sync
+	// committees and validators, which can be slow for historical states.
+	ClassState EndpointClass = "state"
+)
+
+var endpointClassPrefixes = []struct {
+	prefix string
+	class  EndpointClass
+}{
+	{"/eth/v2/beacon/blocks/", ClassBlock},
+	{"/eth/v1/beacon/headers", ClassBlock},
+	{"/eth/v1/beacon/rewards/blocks/", ClassBlock},
+	{"/eth/v1/beacon/states/", ClassState},
+}
+
+// endpointClass reports the class of a beacon request path, or "" for
+// endpoints that only use the global timeout.
+func endpointClass(path string) EndpointClass {
+	for _, p := range endpointClassPrefixes {
+		if strings.HasPrefix(path, p.prefix) {
+			return p.class
+		}
+	}
+	return ""
+}
+
+// timeoutFor returns the timeout of one attempt at path: its class timeout
+// if one is set, else the global one. Zero leaves the HTTP client's timeout
+// in charge.
+func (c *client) timeoutFor(path string) time.Duration {
+	if timeout, ok := c.classTimeouts[endpointClass(path)]; ok {
+		return timeout
+	}
+	return c.timeout
+}
@@ -0,0 +1,116 @@
+package ethereum
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deadlineTransport records the time left before each request's deadline.
+type deadlineTransport struct {
+	mu        sync.Mutex
+	remaining map[string]time.Duration
+	next      http.RoundTripper
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if deadline, ok := req.Context().Deadline(); ok {
+		t.mu.Lock()
+		t.remaining[req.URL.Path] = time.Until(deadline)
+		t.mu.Unlock()
+	}
+	return t.next.RoundTrip(req)
+}
+
+func TestClient_EndpointTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"version":"test","validators":[]}}`))
+	}))
+	defer server.Close()
+
+	newClient := func(t *testing.T, opts ...Option) (*client, *deadlineTransport) {
+		transport := &deadlineTransport{remaining: make(map[string]time.Duration), next: http.DefaultTransport}
+		c, err := NewClient(server.URL, append([]Option{WithHTTPClient(&http.Client{Transport: transport})}, opts...)...)
+		require.NoError(t, err)
+		return c.(*client), transport
+	}
+
+	t.Run("class timeouts", func(t *testing.T) {
+		c, transport := newClient(t,
+			WithTimeout(5*time.Second),
+			WithEndpointTimeout(ClassBlock, time.Second),
+			WithEndpointTimeout(ClassState, 3*time.Second),
+		)
+
+		_, _ = c.GetBlockBySlot(context.Background(), 100)
+		_, _ = c.GetSyncCommitteeAtState(context.Background(), "head")
+		_, err := c.GetNodeVersion(context.Background())
+		require.NoError(t, err)
+
+		blocks := transport.remaining["/eth/v2/beacon/blocks/100"]
+		states := transport.remaining["/eth/v1/beacon/states/head/sync_committees"]
+		node := transport.remaining["/eth/v1/node/version"]
+
+		assert.InDelta(t, time.Second, blocks, float64(100*time.Millisecond))
+		assert.InDelta(t, 3*time.Second, states, float64(100*time.Millisecond))
+		assert.InDelta(t, 5*time.Second, node, float64(100*time.Millisecond))
+
+		// Class timeouts go through the context; the shared client keeps the
+		// global one.
+		assert.Equal(t, 5*time.Second, c.httpClient.Timeout)
+	})
+
+	t.Run("class timeout longer than global", func(t *testing.T) {
+		c, transport := newClient(t, WithTimeout(5*time.Second), WithEndpointTimeout(ClassState, 30*time.Second))
+
+		_, _ = c.GetSyncCommitteeAtState(context.Background(), "head")
+		_, err := c.GetNodeVersion(context.Background())
+		require.NoError(t, err)
+
+		assert.InDelta(t, 30*time.Second, transport.remaining["/eth/v1/beacon/states/head/sync_committees"], float64(100*time.Millisecond))
+		assert.InDelta(t, 5*time.Second, transport.remaining["/eth/v1/node/version"], float64(100*time.Millisecond))
+		assert.Equal(t, 30*time.Second, c.httpClient.Timeout)
+	})
+
+	t.Run("global fallback", func(t *testing.T) {
+		c, transport := newClient(t, WithTimeout(5*time.Second), WithEndpointTimeout(ClassBlock, 0))
+
+		_, _ = c.GetBlockBySlot(context.Background(), 100)
+		assert.InDelta(t, 5*time.Second, transport.remaining["/eth/v2/beacon/blocks/100"], float64(100*time.Millisecond))
+		assert.Equal(t, 5*time.Second, c.httpClient.Timeout)
+	})
+
+	t.Run("caller deadline still applies", func(t *testing.T) {
+		c, transport := newClient(t, WithEndpointTimeout(ClassState, time.Minute))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, _ = c.GetSyncCommitteeAtState(ctx, "head")
+		assert.LessOrEqual(t, transport.remaining["/eth/v1/beacon/states/head/sync_committees"], time.Second)
+	})
+}
+
+func TestEndpointClass(t *testing.T) {
+	tests := map[string]EndpointClass{
+		"/eth/v2/beacon/blocks/100":                  ClassBlock,
+		"/eth/v1/beacon/headers/finalized":           ClassBlock,
+		"/eth/v1/beacon/headers?slot=1":              ClassBlock,
+		"/eth/v1/beacon/rewards/blocks/100":          ClassBlock,
+		"/eth/v1/beacon/states/head/sync_committees": ClassState,
+		"/eth/v1/beacon/states/head/validators/1":    ClassState,
+		"/eth/v1/beacon/genesis":                     "",
+		"/eth/v1/node/version":                       "",
+		"/eth/v1/validator/duties/proposer/10":       "",
+	}
+
+	for path, expected := range tests {
+		assert.Equal(t, expected, endpointClass(path), path)
+	}
+}
@@ -0,0 +1,59 @@
+package ethereum
+
+// requiredFields is implemented by responses that are checked in strict mode.
+// missingField returns the JSON path of the first empty required field.
+type requiredFields interface {
+	missingField() string
+}
+
+func (b *BeaconBlock) missingField() string {
+	switch {
+	case b.Data.Message.Slot == "":
+		return "data.message.slot"
+	case b.Data.Message.ProposerIndex == "":
+		return "data.message.proposer_index"
+	}
+	return ""
+}
+
+func (r *BlockRewardsResponse) missingField() string {
+	if r.Data.Total == "" {
+		return "data.total"
+	}
+	return ""
+}
+
+func (r *HeaderResponse) missingField() string {
+	if r.Data.Header.Message.Slot == "" {
+		return "data.header.message.slot"
+	}
+	return ""
+}
+
+func (r *GenesisResponse) missingField() string {
+	if r.Data.GenesisTime == "" {
+		return "data.genesis_time"
+	}
+	return ""
+}
+
+func (r *ValidatorResponse) missingField() string {
+	if r.Data.Validator.Pubkey == "" {
+		return "data.validator.pubkey"
+	}
+	return ""
+}
+
+func (r *PeerCountResponse) missingField() string {
+	if r.Data.Connected == "" {
+		return "data.connected"
+	}
+	return ""
+}
+
+func (r *NodeVersionResponse) missingField() string {
+	if r.Data.Version == "" {
+		return "data.version"
+	}
+	return ""
+}
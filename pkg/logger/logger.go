@@ -2,7 +2,10 @@ package logger
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -19,11 +22,23 @@ type Logger interface {
 	WithContext(ctx context.Context) Logger
 }
 
+// LevelSetter is implemented by loggers whose level can change at runtime.
+type LevelSetter interface {
+	SetLevel(level string) error
+	Level() string
+}
+
 type logger struct {
 	zl zerolog.Logger
+	// level is shared with every logger derived from this one.
+	level *atomic.Int32
 }
 
 func New(level string) Logger {
+	return NewWithWriter(level, os.Stdout)
+}
+
+func NewWithWriter(level string, w io.Writer) Logger {
 	zerolog.TimeFieldFormat = time.RFC3339Nano
 
 	logLevel, err := zerolog.ParseLevel(level)
@@ -31,29 +46,84 @@ func New(level string) Logger {
 		logLevel = zerolog.InfoLevel
 	}
 
-	zl := zerolog.New(os.Stdout).
-		Level(logLevel).
+	current := new(atomic.Int32)
+	current.Store(int32(logLevel))
+
+	// The zerolog level stays at trace; the hook applies the mutable level so
+	// loggers derived through With() follow changes too.
+	zl := zerolog.New(w).
+		Level(zerolog.TraceLevel).
+		Hook(levelHook{level: current}).
 		With().
 		Timestamp().
 		Caller().
 		Logger()
 
-	return &logger{zl: zl}
+	return &logger{zl: zl, level: current}
+}
+
+// Nop returns a logger that discards everything.
+func Nop() Logger {
+	level := new(atomic.Int32)
+	level.Store(int32(zerolog.Disabled))
+	return &logger{zl: zerolog.Nop(), level: level}
+}
+
+type levelHook struct {
+	level *atomic.Int32
+}
+
+func (h levelHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	if level != zerolog.NoLevel && level < zerolog.Level(h.level.Load()) {
+		e.Discard()
+	}
+}
+
+// SetLevel changes the level of this logger and every logger derived from it.
+func (l *logger) SetLevel(level string) error {
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil || level == "" {
+		return fmt.Errorf("invalid log level %q", level)
+	}
+
+	l.level.Store(int32(parsed))
+	return nil
+}
+
+func (l *logger) Level() string {
+	return zerolog.Level(l.level.Load()).String()
+}
+
+// enabled skips building events the hook would discard anyway.
+func (l *logger) enabled(level zerolog.Level) bool {
+	return level >= zerolog.Level(l.level.Load())
 }
 
 func (l *logger) Debug() *zerolog.Event {
+	if !l.enabled(zerolog.DebugLevel) {
+		return nil
+	}
 	return l.zl.Debug()
 }
 
 func (l *logger) Info() *zerolog.Event {
+	if !l.enabled(zerolog.InfoLevel) {
+		return nil
+	}
 	return l.zl.Info()
 }
 
 func (l *logger) Warn() *zerolog.Event {
+	if !l.enabled(zerolog.WarnLevel) {
+		return nil
+	}
 	return l.zl.Warn()
 }
 
 func (l *logger) Error() *zerolog.Event {
+	if !l.enabled(zerolog.ErrorLevel) {
+		return nil
+	}
 	return l.zl.Error()
 }
 
@@ -66,7 +136,7 @@ func (l *logger) With() zerolog.Context {
 }
 
 func (l *logger) WithContext(ctx context.Context) Logger {
-	return &logger{zl: l.zl.With().Ctx(ctx).Logger()}
+	return &logger{zl: l.zl.With().Ctx(ctx).Logger(), level: l.level}
 }
 
 func WithRequestID(ctx context.Context, requestID string) context.Context {
@@ -74,5 +144,7 @@ func WithRequestID(ctx context.Context, requestID string) context.Context {
 }
 
 func FromContext(ctx context.Context) Logger {
-	return &logger{zl: *log.Ctx(ctx)}
+	level := new(atomic.Int32)
+	level.Store(int32(zerolog.TraceLevel))
+	return &logger{zl: *log.Ctx(ctx), level: level}
 }
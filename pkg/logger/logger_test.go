@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithWriter("info", &buf)
+	derived := log.WithContext(context.Background())
+	child := log.With().Str("component", "test").Logger()
+
+	log.Debug().Msg("debug before")
+	derived.Debug().Msg("derived before")
+	child.Debug().Msg("child before")
+	assert.Empty(t, buf.String())
+
+	levels, ok := log.(LevelSetter)
+	require.True(t, ok)
+	require.NoError(t, levels.SetLevel("debug"))
+	assert.Equal(t, "debug", levels.Level())
+
+	log.Debug().Msg("debug after")
+	derived.Debug().Msg("derived after")
+	child.Debug().Msg("child after")
+	assert.Contains(t, buf.String(), "debug after")
+	assert.Contains(t, buf.String(), "derived after")
+	assert.Contains(t, buf.String(), "child after")
+
+	buf.Reset()
+	require.NoError(t, levels.SetLevel("warn"))
+
+	log.Info().Msg("info hidden")
+	child.Info().Msg("child hidden")
+	log.Warn().Msg("warn shown")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "warn shown")
+}
+
+func TestLogger_SetLevelInvalid(t *testing.T) {
+	levels := NewWithWriter("info", &bytes.Buffer{}).(LevelSetter)
+
+	assert.Error(t, levels.SetLevel("loud"))
+	assert.Error(t, levels.SetLevel(""))
+	assert.Equal(t, "info", levels.Level())
+}
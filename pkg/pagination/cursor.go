@@ -0,0 +1,75 @@
+// Package pagination implements opaque cursors for list endpoints.
+package pagination
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+const (
+	cursorVersion  = 1
+	checksumLength = 4
+	cursorLength   = 1 + 8 + checksumLength
+)
+
+// EncodeCursor returns an opaque cursor pointing at offset. The checksum
+// catches edited or truncated cursors; it isn't a signature, since a cursor
+// only ever encodes a position the client could request anyway.
+func EncodeCursor(offset uint64) string {
+	buf := make([]byte, cursorLength)
+	buf[0] = cursorVersion
+	binary.BigEndian.PutUint64(buf[1:9], offset)
+	copy(buf[9:], checksum(buf[:9]))
+
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeCursor returns the offset a cursor points at. Any malformed or
+// tampered cursor yields an error wrapping errors.ErrInvalidCursor.
+func DecodeCursor(cursor string) (uint64, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(buf) != cursorLength {
+		return 0, fmt.Errorf("%w: malformed", errors.ErrInvalidCursor)
+	}
+
+	if buf[0] != cursorVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", errors.ErrInvalidCursor, buf[0])
+	}
+
+	if !bytes.Equal(buf[9:], checksum(buf[:9])) {
+		return 0, fmt.Errorf("%w: checksum mismatch", errors.ErrInvalidCursor)
+	}
+
+	return binary.BigEndian.Uint64(buf[1:9]), nil
+}
+
+// Bounds returns the [start, end) window of a page over total items and the
+// cursor of the following page, which is empty on the last page. A limit of
+// zero or less means no limit.
+func Bounds(total int, offset uint64, limit int) (start, end int, next string) {
+	if offset >= uint64(total) {
+		return total, total, ""
+	}
+
+	start = int(offset)
+	end = total
+	if limit > 0 && limit < total-start {
+		end = start + limit
+	}
+
+	if end < total {
+		next = EncodeCursor(uint64(end))
+	}
+
+	return start, end, next
+}
+
+func checksum(payload []byte) []byte {
+	sum := sha256.Sum256(payload)
+	return sum[:checksumLength]
+}
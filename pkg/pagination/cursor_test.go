@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/pkg/errors"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	for _, offset := range []uint64{0, 1, 256, 511, math.MaxUint64} {
+		decoded, err := DecodeCursor(EncodeCursor(offset))
+		require.NoError(t, err)
+		assert.Equal(t, offset, decoded)
+	}
+}
+
+func TestCursor_RejectsTampering(t *testing.T) {
+	valid := EncodeCursor(42)
+
+	raw, err := base64.RawURLEncoding.DecodeString(valid)
+	require.NoError(t, err)
+
+	flipped := append([]byte(nil), raw...)
+	flipped[8] ^= 0x01
+
+	wrongVersion := append([]byte(nil), raw...)
+	wrongVersion[0] = 2
+
+	cursors := map[string]string{
+		"empty":          "",
+		"not base64":     "!!!",
+		"truncated":      valid[:len(valid)-2],
+		"extended":       valid + "AA",
+		"offset changed": base64.RawURLEncoding.EncodeToString(flipped),
+		"wrong version":  base64.RawURLEncoding.EncodeToString(wrongVersion),
+		"plain number":   base64.RawURLEncoding.EncodeToString([]byte("42")),
+	}
+
+	for name, cursor := range cursors {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeCursor(cursor)
+			assert.ErrorIs(t, err, errors.ErrInvalidCursor)
+			assert.True(t, errors.IsBadRequest(err))
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		offset     uint64
+		limit      int
+		start, end int
+		nextOffset int
+	}{
+		{name: "no limit", total: 10, offset: 0, limit: 0, start: 0, end: 10, nextOffset: -1},
+		{name: "first page", total: 10, offset: 0, limit: 4, start: 0, end: 4, nextOffset: 4},
+		{name: "middle page", total: 10, offset: 4, limit: 4, start: 4, end: 8, nextOffset: 8},
+		{name: "last partial page", total: 10, offset: 8, limit: 4, start: 8, end: 10, nextOffset: -1},
+		{name: "exact last page", total: 8, offset: 4, limit: 4, start: 4, end: 8, nextOffset: -1},
+		{name: "offset past end", total: 10, offset: 50, limit: 4, start: 10, end: 10, nextOffset: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, next := Bounds(tt.total, tt.offset, tt.limit)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+
+			if tt.nextOffset < 0 {
+				assert.Empty(t, next)
+				return
+			}
+
+			offset, err := DecodeCursor(next)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(tt.nextOffset), offset)
+		})
+	}
+}
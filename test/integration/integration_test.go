@@ -0,0 +1,233 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matheus/eth-validator-api/internal/api/handlers"
+	"github.com/matheus/eth-validator-api/internal/api/router"
+	"github.com/matheus/eth-validator-api/internal/config"
+	"github.com/matheus/eth-validator-api/internal/service"
+	"github.com/matheus/eth-validator-api/internal/testutil/beaconserver"
+	"github.com/matheus/eth-validator-api/pkg/ethereum"
+	"github.com/matheus/eth-validator-api/pkg/logger"
+)
+
+func newTestAPI(t *testing.T) http.Handler {
+	t.Helper()
+
+	beacon := beaconserver.New(t)
+	log := logger.Nop()
+
+	client, err := ethereum.NewClient(beacon.URL, ethereum.WithLogger(log))
+	require.NoError(t, err)
+
+	// The relays come from config, as they do in the server.
+	t.Setenv("ETH_RPC_ENDPOINT", beacon.URL)
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	svc, err := service.NewValidatorService(client, log, nil, service.ServiceConfig{MEVRelays: cfg.MEV.RelayAddresses})
+	require.NoError(t, err)
+
+	handler, err := handlers.NewValidatorHandler(svc, log, handlers.HandlerConfig{})
+	require.NoError(t, err)
+
+	mux := router.New(router.Config{NotFound: handlers.NewNotFoundHandler("")})
+	handler.RegisterRoutes(mux)
+
+	return mux
+}
+
+func get(t *testing.T, api http.Handler, path string) (int, map[string]interface{}) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	return rec.Code, body
+}
+
+func TestBlockReward(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/blockreward/9000000")
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{
+		"status":         "vanilla",
+		"reward":         "1950000000000000",
+		"unit":           "wei",
+		"finalized":      true,
+		"proposer_index": float64(123456),
+	}, body["data"])
+}
+
+func TestBlockReward_MissedSlot(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/blockreward/9000001")
+
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, handlers.CodeNotFound, body["code"])
+	assert.Nil(t, body["data"])
+}
+
+func TestSlotStatus(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/slot/9000000/status")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"slot": float64(9000000), "proposed": true, "finalized": true}, body["data"])
+
+	status, body = get(t, api, "/slot/9000001/status")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"slot": float64(9000001), "proposed": false, "finalized": false}, body["data"])
+}
+
+func TestBlock(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/block/9000000")
+	assert.Equal(t, http.StatusOK, status)
+	data, ok := body["data"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(9000000), data["slot"])
+	assert.Equal(t, float64(123456), data["proposer_index"])
+	assert.Equal(t, "0x1111111111111111111111111111111111111111111111111111111111111111", data["parent_root"])
+	assert.Equal(t, "0x2222222222222222222222222222222222222222222222222222222222222222", data["state_root"])
+	assert.Equal(t, true, data["finalized"])
+
+	blockBody, ok := data["body"].(map[string]interface{})
+	require.True(t, ok)
+	payload, ok := blockBody["execution_payload"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "0x1234567890123456789012345678901234567890", payload["fee_recipient"])
+	assert.Equal(t, "19000000", payload["block_number"])
+
+	status, _ = get(t, api, "/block/9000001")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestEvents(t *testing.T) {
+	server := httptest.NewServer(newTestAPI(t))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events?topics=head,finalized_checkpoint", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	type event struct {
+		topic string
+		data  map[string]interface{}
+	}
+	var events []event
+
+	scanner := bufio.NewScanner(resp.Body)
+	var topic string
+	for len(events) < 2 && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			topic = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			var data map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data))
+			events = append(events, event{topic: topic, data: data})
+		}
+	}
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "head", events[0].topic)
+	assert.Equal(t, float64(9000000), events[0].data["slot"])
+	assert.Equal(t, "0x4444444444444444444444444444444444444444444444444444444444444444", events[0].data["block"])
+	assert.Equal(t, map[string]interface{}{
+		"status":         "vanilla",
+		"reward":         "1950000000000000",
+		"unit":           "wei",
+		"finalized":      true,
+		"proposer_index": float64(123456),
+	}, events[0].data["reward"])
+
+	assert.Equal(t, "finalized_checkpoint", events[1].topic)
+	assert.Equal(t, "281250", events[1].data["epoch"])
+}
+
+func TestSyncDuties(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/syncduties/9000000")
+
+	assert.Equal(t, http.StatusOK, status)
+	data, ok := body["data"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{"1", "2", "3", "4"}, data["validators"])
+	assert.Equal(t, float64(1098), data["period"])
+}
+
+func TestSyncCommitteeByPeriod(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/synccommittee/period/1098")
+
+	assert.Equal(t, http.StatusOK, status)
+	data, ok := body["data"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(8994816), data["period_start_slot"])
+	assert.Equal(t, []interface{}{"1", "2", "3", "4"}, data["validators"])
+}
+
+func TestHeaderByStateRoot(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/header/stateroot/0x5555555555555555555555555555555555555555555555555555555555555555?slot=9000000")
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{
+		"slot":           float64(9000000),
+		"proposer_index": float64(123456),
+		"root":           "0x7777777777777777777777777777777777777777777777777777777777777777",
+		"parent_root":    "0x1111111111111111111111111111111111111111111111111111111111111111",
+		"state_root":     "0x5555555555555555555555555555555555555555555555555555555555555555",
+		"body_root":      "0x6666666666666666666666666666666666666666666666666666666666666666",
+		"canonical":      false,
+	}, body["data"])
+
+	status, body = get(t, api, "/header/stateroot/0x9999999999999999999999999999999999999999999999999999999999999999?slot=9000000")
+
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, handlers.CodeNotFound, body["code"])
+}
+
+func TestMEVRelays(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, body := get(t, api, "/mev/relays")
+
+	assert.Equal(t, http.StatusOK, status)
+	data, ok := body["data"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Len(t, data["relays"], 3)
+	assert.Contains(t, data["selectors"], "0xa22cb465")
+}